@@ -36,6 +36,9 @@ const WASM_CALLBACKS = [
 	"ofdgoExportPDF",
 	"ofdgoFontSystemNames",
 ];
+const WASM_OPTIONAL_CALLBACKS = [
+	"ofdgoCancelRender",
+];
 
 let wasmPromise = null;
 let wasmModule = null;
@@ -65,6 +68,7 @@ const state = {
 	pageInFlight: new Map(),
 	pageRenderQueue: [],
 	pageRenderRunning: false,
+	pageRenderCurrent: null,
 	pageObserver: null,
 	scrollFrame: 0,
 	thumbnailCache: new Map(),
@@ -298,7 +302,7 @@ async function loadWASM() {
 }
 
 function clearWASMCallbacks() {
-	for (const name of WASM_CALLBACKS) {
+	for (const name of [...WASM_CALLBACKS, ...WASM_OPTIONAL_CALLBACKS]) {
 		globalThis[name] = undefined;
 	}
 }
@@ -1236,15 +1240,23 @@ async function processPageRenderQueue() {
 					delayed = true;
 					continue;
 				}
-				const page = callWASM("ofdgoRenderPage", task.index);
+				state.pageRenderCurrent = task;
+				const page = await callWASMAsync("ofdgoRenderPage", task.index);
 				if (task.openSeq === state.openSeq) {
 					state.pageCache.set(task.index, page);
 					cacheThumbnail(task.index, page.svg);
 				}
 				task.resolve(page);
 			} catch (err) {
-				task.reject(err);
+				if (task.cancelled) {
+					task.resolve(null);
+				} else {
+					task.reject(err);
+				}
 			} finally {
+				if (state.pageRenderCurrent === task) {
+					state.pageRenderCurrent = null;
+				}
 				if (!delayed) {
 					state.pageInFlight.delete(task.key);
 				}
@@ -1258,6 +1270,22 @@ async function processPageRenderQueue() {
 	}
 }
 
+function cancelStalePageRender(index) {
+	const task = state.pageRenderCurrent;
+	if (!task || typeof globalThis.ofdgoCancelRender !== "function") {
+		return;
+	}
+	if (task.openSeq === state.openSeq && task.index >= index - 1 && task.index <= index + 2) {
+		return;
+	}
+	task.cancelled = true;
+	try {
+		callWASM("ofdgoCancelRender");
+	} catch {
+		// 渲染引擎退出时由恢复流程处理
+	}
+}
+
 function shouldDelayPageTask(task) {
 	return state.pageRenderQueue.some((next) => next.openSeq === state.openSeq && comparePageRenderTask(next, task) < 0);
 }
@@ -1277,6 +1305,7 @@ function mountPageSVG(index, page, openSeq = state.openSeq) {
 	const surface = shell.querySelector(".page-surface");
 	const svg = parseSVG(page.svg, `p${openSeq}-${index}`);
 	svg.classList.add("ofd-svg");
+	bindSVGLinks(svg);
 	surface.replaceChildren(svg);
 	shell.classList.add("rendered");
 	if (state.doc?.pages?.[index]) {
@@ -1284,6 +1313,25 @@ function mountPageSVG(index, page, openSeq = state.openSeq) {
 	}
 }
 
+function bindSVGLinks(svg) {
+	for (const link of svg.querySelectorAll("a:not([data-ofd-goto])")) {
+		link.setAttribute("target", "_blank");
+		link.setAttribute("rel", "noopener noreferrer");
+	}
+	svg.addEventListener("click", (event) => {
+		const link = event.target.closest("a[data-ofd-goto]");
+		if (!link) {
+			return;
+		}
+		event.preventDefault();
+		const pageID = link.getAttribute("data-ofd-goto");
+		const index = (state.doc?.pages || []).findIndex((page) => page.id === pageID);
+		if (index >= 0) {
+			renderPage(index);
+		}
+	});
+}
+
 function markFlowPageError(index, err) {
 	const shell = pageShell(index);
 	if (!shell) {
@@ -1364,6 +1412,7 @@ function pageShellAtPoint(x, y) {
 
 function setCurrentPage(index) {
 	state.pageIndex = index;
+	cancelStalePageRender(index);
 	if (state.fitMode === "width") {
 		state.scale = fitWidthScale(currentPageInfo());
 		el.zoomLabel.textContent = `${Math.round(state.scale * 100)}%`;
@@ -2158,6 +2207,14 @@ function setExportControlsDisabled(disabled) {
 }
 
 function callWASM(name, ...args) {
+	return wasmResultData(invokeWASM(name, args));
+}
+
+async function callWASMAsync(name, ...args) {
+	return wasmResultData(await invokeWASM(name, args));
+}
+
+function invokeWASM(name, args) {
 	const fn = globalThis[name];
 	if (typeof fn !== "function") {
 		throw new Error("渲染引擎未初始化");
@@ -2166,9 +2223,8 @@ function callWASM(name, ...args) {
 		scheduleWASMRecovery();
 		throw new Error(state.wasmRecovering ? "渲染引擎正在恢复" : "渲染引擎已退出，正在恢复");
 	}
-	let payload;
 	try {
-		payload = fn(...args);
+		return fn(...args);
 	} catch (err) {
 		const message = String(err.message || err);
 		if (message.includes("Go program has already exited")) {
@@ -2177,6 +2233,9 @@ function callWASM(name, ...args) {
 		}
 		throw err;
 	}
+}
+
+function wasmResultData(payload) {
 	const result = typeof payload === "string" ? JSON.parse(payload) : payload;
 	if (!result.ok) {
 		throw new Error(result.error || "WASM 调用失败");
@@ -2264,7 +2323,7 @@ function exportFormatInfo(value) {
 }
 
 function exportFormatUsesDPI(value) {
-	return value === "png" || value === "jpg";
+	return value === "png" || value === "jpg" || value === "tiff";
 }
 
 function updateDPIControl(disabled = false) {
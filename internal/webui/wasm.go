@@ -17,6 +17,7 @@
 package webui
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"syscall/js"
@@ -30,6 +31,9 @@ var currentSession *Session
 // callbacks 浏览器回调函数引用
 var callbacks []js.Func
 
+// renderCancel 取消当前页面渲染
+var renderCancel context.CancelFunc
+
 // apiResult 浏览器接口返回结果
 type apiResult struct {
 	OK    bool   `json:"ok"`
@@ -41,6 +45,7 @@ type apiResult struct {
 func RunWASM() {
 	registerCallback("ofdgoOpen", openDocument)
 	registerCallback("ofdgoRenderPage", renderPage)
+	registerCallback("ofdgoCancelRender", cancelRender)
 	registerCallback("ofdgoExportFormats", exportFormats)
 	registerCallback("ofdgoExportPage", exportPage)
 	registerCallback("ofdgoExportPDF", exportPDF)
@@ -93,6 +98,10 @@ func openDocument(args []js.Value) (any, error) {
 		return nil, err
 	}
 	renderAnnotations := args[2].Bool()
+	if renderCancel != nil {
+		renderCancel()
+		renderCancel = nil
+	}
 	if currentSession != nil {
 		_ = currentSession.Close()
 		currentSession = nil
@@ -105,9 +114,10 @@ func openDocument(args []js.Value) (any, error) {
 	return currentSession.Info(), nil
 }
 
-// renderPage 渲染OFD页面
+// renderPage 异步渲染OFD页面
+// 新的渲染请求会取消仍在进行的渲染
 // 入参: args 浏览器参数
-// 返回: any 页面SVG结果, error 错误信息
+// 返回: any 解析为页面SVG结果的Promise, error 错误信息
 func renderPage(args []js.Value) (any, error) {
 	if currentSession == nil {
 		return nil, fmt.Errorf("ofd document is not opened")
@@ -115,20 +125,63 @@ func renderPage(args []js.Value) (any, error) {
 	if len(args) == 0 {
 		return nil, fmt.Errorf("missing page index")
 	}
-	page, err := currentSession.RenderPageSVG(args[0].Int())
-	if err != nil {
-		return nil, err
+	session, index := currentSession, args[0].Int()
+	if renderCancel != nil {
+		renderCancel()
 	}
-	return successResult(map[string]any{
-		"index":  page.Index,
-		"number": page.Number,
-		"id":     page.ID,
-		"width":  page.Width,
-		"height": page.Height,
-		"svg":    page.SVG,
+	ctx, cancel := context.WithCancel(context.Background())
+	renderCancel = cancel
+	return newPromise(func() (any, error) {
+		defer cancel()
+		page, err := session.RenderPageSVGContext(ctx, index)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{
+			"index":  page.Index,
+			"number": page.Number,
+			"id":     page.ID,
+			"width":  page.Width,
+			"height": page.Height,
+			"svg":    page.SVG,
+		}, nil
 	}), nil
 }
 
+// cancelRender 取消进行中的页面渲染
+// 入参: args 浏览器参数
+// 返回: any 是否存在可取消的渲染, error 错误信息
+func cancelRender(args []js.Value) (any, error) {
+	if renderCancel == nil {
+		return false, nil
+	}
+	renderCancel()
+	renderCancel = nil
+	return true, nil
+}
+
+// newPromise 创建在协程中执行的浏览器Promise
+// 入参: fn 执行函数
+// 返回: js.Value Promise对象, 解析为接口结果
+func newPromise(fn func() (any, error)) js.Value {
+	executor := js.FuncOf(func(this js.Value, args []js.Value) any {
+		resolve := args[0]
+		go func() {
+			data, err := safeCall(func([]js.Value) (any, error) {
+				return fn()
+			}, nil)
+			if err != nil {
+				resolve.Invoke(encodeResult(apiResult{OK: false, Error: err.Error()}))
+				return
+			}
+			resolve.Invoke(successResult(data))
+		}()
+		return nil
+	})
+	defer executor.Release()
+	return js.Global().Get("Promise").New(executor)
+}
+
 // exportFormats 获取导出格式
 // 入参: args 浏览器参数
 // 返回: any 导出格式, error 错误信息
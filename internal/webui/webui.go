@@ -16,12 +16,14 @@ package webui
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"image"
 	"image/color"
 	"image/draw"
 	"image/jpeg"
 	"image/png"
+	"io"
 	"strings"
 
 	"github.com/xiaoqidun/ofdgo"
@@ -39,6 +41,9 @@ type FontFile = ofdgo.FontFile
 // FontInfo OFD字体信息
 type FontInfo = ofdgo.FontInfo
 
+// Diagnostic OFD诊断信息
+type Diagnostic = ofdgo.Diagnostic
+
 // SignatureInfo 签名验证信息
 type SignatureInfo struct {
 	ID                   string               `json:"id"`
@@ -68,6 +73,11 @@ type SignatureInfo struct {
 	CertTimeOK           bool                 `json:"certTimeOK,omitempty"`
 	CertTrustChecked     bool                 `json:"certTrustChecked,omitempty"`
 	CertTrustOK          bool                 `json:"certTrustOK,omitempty"`
+	RevocationChecked    bool                 `json:"revocationChecked,omitempty"`
+	RevocationOK         bool                 `json:"revocationOK,omitempty"`
+	RevocationSource     string               `json:"revocationSource,omitempty"`
+	ModifiedAfterSign    bool                 `json:"modifiedAfterSign,omitempty"`
+	UnprotectedEntries   []string             `json:"unprotectedEntries,omitempty"`
 	ReferenceCount       int                  `json:"referenceCount"`
 	ReferencePassed      int                  `json:"referencePassed"`
 	SignatureMethod      string               `json:"signatureMethod,omitempty"`
@@ -117,6 +127,7 @@ type DocumentInfo struct {
 	Fonts          []FontInfo      `json:"fonts"`
 	Signatures     []SignatureInfo `json:"signatures"`
 	Pages          []PageInfo      `json:"pages"`
+	Diagnostics    []Diagnostic    `json:"diagnostics"`
 }
 
 // PageInfo 页面信息
@@ -152,6 +163,7 @@ var supportedExportFormats = []ExportFormat{
 	{Value: "eps", Label: "EPS", Extension: "eps", MIME: "application/postscript"},
 	{Value: "png", Label: "PNG", Extension: "png", MIME: "image/png"},
 	{Value: "jpg", Label: "JPEG", Extension: "jpg", MIME: "image/jpeg"},
+	{Value: "tiff", Label: "TIFF", Extension: "tif", MIME: "image/tiff"},
 }
 
 // ExportFormats 获取导出格式
@@ -167,8 +179,11 @@ func ExportFormats() []ExportFormat {
 // 返回: ExportFormat 导出格式, bool 是否支持
 func exportFormat(value string) (ExportFormat, bool) {
 	value = strings.ToLower(strings.TrimSpace(value))
-	if value == "jpeg" {
+	switch value {
+	case "jpeg":
 		value = "jpg"
+	case "tif":
+		value = "tiff"
 	}
 	for _, format := range supportedExportFormats {
 		if format.Value == value {
@@ -203,7 +218,7 @@ func Open(data []byte, opts OpenOptions) (*Session, error) {
 		}
 		rendererOptions = append(rendererOptions, ofdgo.WithFontFS(fontFS))
 	}
-	rendererOptions = append(rendererOptions, ofdgo.WithAnnotations(opts.RenderAnnotations))
+	rendererOptions = append(rendererOptions, ofdgo.WithAnnotations(opts.RenderAnnotations), ofdgo.WithVectorSVG(nil))
 	return &Session{
 		Reader:    reader,
 		Renderer:  ofdgo.NewRenderer(reader, rendererOptions...),
@@ -260,6 +275,7 @@ func (s *Session) Info() DocumentInfo {
 		info.Fonts = fonts
 	}
 	info.FontCount = len(info.Fonts)
+	info.Diagnostics = s.Reader.Diagnostics().List()
 	return info
 }
 
@@ -267,6 +283,13 @@ func (s *Session) Info() DocumentInfo {
 // 入参: index 页面索引
 // 返回: PageSVG 页面SVG结果, error 错误信息
 func (s *Session) RenderPageSVG(index int) (PageSVG, error) {
+	return s.RenderPageSVGContext(context.Background(), index)
+}
+
+// RenderPageSVGContext 使用上下文渲染页面为SVG
+// 入参: ctx 上下文, index 页面索引
+// 返回: PageSVG 页面SVG结果, error 错误信息
+func (s *Session) RenderPageSVGContext(ctx context.Context, index int) (PageSVG, error) {
 	pageRef, page, err := s.pageContent(index)
 	if err != nil {
 		return PageSVG{}, err
@@ -276,7 +299,7 @@ func (s *Session) RenderPageSVG(index int) (PageSVG, error) {
 		return PageSVG{}, err
 	}
 	var buf bytes.Buffer
-	if err := s.Renderer.RenderToSVG(page, &buf); err != nil {
+	if err := s.Renderer.RenderToSVGContext(ctx, page, &buf); err != nil {
 		return PageSVG{}, err
 	}
 	return PageSVG{Index: index, Number: index + 1, ID: pageRef.ID, Width: box.W, Height: box.H, SVG: buf.String()}, nil
@@ -314,6 +337,8 @@ func (s *Session) ExportPage(index int, value string, dpi float64) ([]byte, Expo
 			return nil, ExportFormat{}, renderErr
 		}
 		err = jpeg.Encode(&buf, imageWithWhiteBackground(img), &jpeg.Options{Quality: 95})
+	case "tiff":
+		err = s.renderPageTIFF(page, dpi, &buf)
 	}
 	if err != nil {
 		return nil, ExportFormat{}, err
@@ -336,6 +361,20 @@ func (s *Session) renderPageImage(page *ofdgo.PageContent, dpi float64) (image.I
 	return s.Renderer.RenderToImage(page)
 }
 
+// renderPageTIFF 渲染页面为LZW压缩的TIFF
+// 入参: page 页面内容, dpi 图片DPI, writer 输出流
+// 返回: error 错误信息
+func (s *Session) renderPageTIFF(page *ofdgo.PageContent, dpi float64, writer io.Writer) error {
+	if dpi > 0 {
+		oldDPI := s.Renderer.DPI
+		s.Renderer.DPI = dpi
+		defer func() {
+			s.Renderer.DPI = oldDPI
+		}()
+	}
+	return s.Renderer.RenderToTIFF(page, writer)
+}
+
 // signatureInfos 获取签名验证信息
 // 返回: []SignatureInfo 签名验证信息, error 错误信息
 func (s *Session) signatureInfos() ([]SignatureInfo, error) {
@@ -382,6 +421,11 @@ func signatureInfo(report ofdgo.SignatureVerifyReport) SignatureInfo {
 		CertTimeOK:           report.CertTimeOK,
 		CertTrustChecked:     report.CertTrustChecked,
 		CertTrustOK:          report.CertTrustOK,
+		RevocationChecked:    report.RevocationChecked,
+		RevocationOK:         report.RevocationOK,
+		RevocationSource:     report.RevocationSource,
+		ModifiedAfterSign:    report.Coverage.ModifiedAfterSign,
+		UnprotectedEntries:   report.Coverage.Unprotected,
 		ReferenceCount:       len(report.References),
 		ReferencePassed:      signatureReferencePassed(report.References),
 		SignatureMethod:      report.SignatureMethod,
@@ -19,22 +19,36 @@ import (
 	"archive/zip"
 	"io"
 	"io/fs"
+	"os"
 
 	"github.com/tdewolff/canvas"
 )
 
 // Open 打开OFD文件
-// 入参: path 文件路径
+// path为目录时按解包后的OFD目录打开
+// 入参: path 文件或目录路径, opts 阅读器选项
 // 返回: *Reader 阅读器实例, error 错误信息
-func Open(path string) (*Reader, error) {
+func Open(path string, opts ...ReaderOption) (*Reader, error) {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		reader, err := NewReaderFS(os.DirFS(path), opts...)
+		if err != nil {
+			return nil, err
+		}
+		reader.Path = path
+		return reader, nil
+	}
 	r, err := zip.OpenReader(path)
 	if err != nil {
 		return nil, err
 	}
 	reader := &Reader{
-		Path:   path,
-		Zip:    &r.Reader,
-		Closer: r,
+		Path:    path,
+		Zip:     &r.Reader,
+		Closer:  r,
+		entries: zipEntries(r.File),
+	}
+	for _, opt := range opts {
+		opt(reader)
 	}
 	if err := reader.initRoot(); err != nil {
 		reader.Close()
@@ -44,15 +58,41 @@ func Open(path string) (*Reader, error) {
 }
 
 // NewReader 从IO读取器创建OFD阅读器
-// 入参: r IO读取器, size 数据大小
+// 入参: r IO读取器, size 数据大小, opts 阅读器选项
 // 返回: *Reader 阅读器实例, error 错误信息
-func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+func NewReader(r io.ReaderAt, size int64, opts ...ReaderOption) (*Reader, error) {
 	zr, err := zip.NewReader(r, size)
 	if err != nil {
 		return nil, err
 	}
 	reader := &Reader{
-		Zip: zr,
+		Zip:     zr,
+		source:  io.NewSectionReader(r, 0, size),
+		entries: zipEntries(zr.File),
+	}
+	for _, opt := range opts {
+		opt(reader)
+	}
+	if err := reader.initRoot(); err != nil {
+		return nil, err
+	}
+	return reader, nil
+}
+
+// NewReaderFS 从文件系统创建OFD阅读器
+// 文件系统根目录对应OFD包根目录, 适用于解包后的目录和embed.FS, 不跟随符号链接
+// 入参: fsys 文件系统, opts 阅读器选项
+// 返回: *Reader 阅读器实例, error 错误信息
+func NewReaderFS(fsys fs.FS, opts ...ReaderOption) (*Reader, error) {
+	entries, err := fsEntries(fsys)
+	if err != nil {
+		return nil, err
+	}
+	reader := &Reader{
+		entries: entries,
+	}
+	for _, opt := range opts {
+		opt(reader)
 	}
 	if err := reader.initRoot(); err != nil {
 		return nil, err
@@ -68,6 +108,7 @@ func NewRenderer(reader *Reader, opts ...RendererOption) *Renderer {
 		Reader:                reader,
 		DPI:                   300.0,
 		RenderAnnotations:     true,
+		pdfTextLayer:          true,
 		DrawParams:            reader.drawParamCache,
 		CompositeGraphicUnits: reader.compositeGraphicUnitCache,
 		FontMap:               make(map[string]*canvas.FontFamily),
@@ -78,11 +119,15 @@ func NewRenderer(reader *Reader, opts ...RendererOption) *Renderer {
 		fontSourceUsed:        make(map[string]fontSource),
 		textGlyphPathCache:    make(map[textGlyphPathCacheKey]textGlyphPathCacheValue),
 		templatePageCache:     make(map[string]*PageContent),
+		textLayerFonts:        make(map[*canvas.Font]bool),
+		limits:                reader.limits,
+		diagnostics:           reader.diagnostics,
 	}
 	for _, opt := range opts {
 		opt(r)
 	}
 	r.initCommon()
+	r.states = &renderStatePool{idle: []*renderState{r.saveState()}}
 	return r
 }
 
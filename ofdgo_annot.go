@@ -41,6 +41,12 @@ type Annotation struct {
 	Subtype     string `xml:"Subtype,attr"`
 	Creator     string `xml:"Creator,attr"`
 	LastModDate string `xml:"LastModDate,attr"`
+	Visible     *bool  `xml:"Visible,attr"`
+	Print       *bool  `xml:"Print,attr"`
+	NoZoom      bool   `xml:"NoZoom,attr"`
+	NoRotate    bool   `xml:"NoRotate,attr"`
+	ReadOnly    *bool  `xml:"ReadOnly,attr"`
+	Remark      string `xml:"Remark"`
 	Appearance  Appearance
 }
 
@@ -78,12 +84,14 @@ func (r *Reader) parseAnnotations(doc *Document) error {
 		annotPath := resolveResourcePath(annPath, "", page.FileLoc)
 		af, err := r.openFile(annotPath)
 		if err != nil {
+			r.diagnose(DiagnosticWarning, annotPath, page.PageID, "failed to open page annotations: %v", err)
 			continue
 		}
 		var pageAnnot PageAnnot
 		err = xml.NewDecoder(af).Decode(&pageAnnot)
 		_ = af.Close()
 		if err != nil {
+			r.diagnose(DiagnosticWarning, annotPath, page.PageID, "failed to unmarshal page annotations: %v", err)
 			continue
 		}
 		r.Annots[page.PageID] = append(r.Annots[page.PageID], pageAnnot.Annot...)
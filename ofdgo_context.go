@@ -0,0 +1,186 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"io"
+
+	"github.com/tdewolff/canvas"
+)
+
+// WithContext 获取绑定上下文的渲染器副本
+// 渲染在页面、图层、图元、字体加载和图片解码之间检查上下文取消
+// 入参: ctx 上下文
+// 返回: *Renderer 渲染器副本
+func (r *Renderer) WithContext(ctx context.Context) *Renderer {
+	if ctx == nil {
+		panic("ofdgo: nil context")
+	}
+	renderer := *r
+	renderer.ctx = ctx
+	return &renderer
+}
+
+// Context 获取渲染器上下文
+// 返回: context.Context 上下文, 未设置时为context.Background
+func (r *Renderer) Context() context.Context {
+	if r.ctx != nil {
+		return r.ctx
+	}
+	return context.Background()
+}
+
+// ctxErr 获取渲染器上下文错误
+// 返回: error 上下文取消或超时错误
+func (r *Renderer) ctxErr() error {
+	if r.ctx == nil {
+		return nil
+	}
+	return r.ctx.Err()
+}
+
+// RenderPageContext 使用上下文渲染特定页面内容
+// 入参: ctx 上下文, page 页面内容
+// 返回: *canvas.Canvas 画布实例, error 错误信息
+func (r *Renderer) RenderPageContext(ctx context.Context, page *PageContent) (*canvas.Canvas, error) {
+	return r.WithContext(ctx).RenderPage(page)
+}
+
+// RenderPageByIndexContext 使用上下文按索引渲染页面
+// 入参: ctx 上下文, index 页面索引
+// 返回: *canvas.Canvas 画布实例, error 错误信息
+func (r *Renderer) RenderPageByIndexContext(ctx context.Context, index int) (*canvas.Canvas, error) {
+	return r.WithContext(ctx).RenderPageByIndex(index)
+}
+
+// RenderToImageContext 使用上下文渲染为光栅图
+// 入参: ctx 上下文, page 页面内容
+// 返回: image.Image 图像对象, error 错误信息
+func (r *Renderer) RenderToImageContext(ctx context.Context, page *PageContent) (image.Image, error) {
+	return r.WithContext(ctx).RenderToImage(page)
+}
+
+// RenderToSVGContext 使用上下文渲染为SVG
+// 入参: ctx 上下文, page 页面内容, writer 输出流
+// 返回: error 错误信息
+func (r *Renderer) RenderToSVGContext(ctx context.Context, page *PageContent, writer io.Writer) error {
+	return r.WithContext(ctx).RenderToSVG(page, writer)
+}
+
+// RenderToPDFContext 使用上下文渲染为PDF
+// 入参: ctx 上下文, page 页面内容, writer 输出流
+// 返回: error 错误信息
+func (r *Renderer) RenderToPDFContext(ctx context.Context, page *PageContent, writer io.Writer) error {
+	return r.WithContext(ctx).RenderToPDF(page, writer)
+}
+
+// RenderToEPSContext 使用上下文渲染为EPS
+// 入参: ctx 上下文, page 页面内容, writer 输出流
+// 返回: error 错误信息
+func (r *Renderer) RenderToEPSContext(ctx context.Context, page *PageContent, writer io.Writer) error {
+	return r.WithContext(ctx).RenderToEPS(page, writer)
+}
+
+// RenderToMultiPagePDFContext 使用上下文将整个文档导出为多页PDF
+// 入参: ctx 上下文, writer 输出流
+// 返回: error 错误信息
+func (r *Renderer) RenderToMultiPagePDFContext(ctx context.Context, writer io.Writer) error {
+	return r.WithContext(ctx).RenderToMultiPagePDF(writer)
+}
+
+// RenderToImagesContext 使用上下文渲染多个页面为光栅图
+// 入参: ctx 上下文, indexes 页面索引列表, 为nil时渲染全部页面, fn 页面图像回调
+// 返回: error 错误信息
+func (r *Renderer) RenderToImagesContext(ctx context.Context, indexes []int, fn PageImageFunc) error {
+	return r.WithContext(ctx).RenderToImages(indexes, fn)
+}
+
+// FontInfosContext 使用上下文获取OFD字体诊断信息
+// 入参: ctx 上下文
+// 返回: []FontInfo 字体诊断列表, error 错误信息
+func (r *Renderer) FontInfosContext(ctx context.Context) ([]FontInfo, error) {
+	return r.WithContext(ctx).FontInfos()
+}
+
+// FontInfosFromPagesContext 使用上下文从页面内容获取OFD字体诊断信息
+// 入参: ctx 上下文, pages 页面内容列表
+// 返回: []FontInfo 字体诊断列表, error 错误信息
+func (r *Renderer) FontInfosFromPagesContext(ctx context.Context, pages []*PageContent) ([]FontInfo, error) {
+	return r.WithContext(ctx).FontInfosFromPages(pages)
+}
+
+// VerifySignaturesContext 使用上下文验证文档签名
+// 在签名和保护文件之间检查上下文取消
+// 入参: ctx 上下文, opts 签名验证选项
+// 返回: []SignatureVerifyReport 签名验证报告, error 错误信息
+func (r *Reader) VerifySignaturesContext(ctx context.Context, opts ...SignatureVerifyOption) ([]SignatureVerifyReport, error) {
+	if ctx == nil {
+		panic("ofdgo: nil context")
+	}
+	return r.VerifySignatures(append(opts, func(o *signatureVerifyOptions) {
+		o.ctx = ctx
+	})...)
+}
+
+// ValidateContext 使用上下文按指定规范校验文档结构
+// 在页面之间检查上下文取消
+// 入参: ctx 上下文, profile 校验规范, 为空时使用GB/T 33190
+// 返回: *ValidationReport 校验报告, error 错误信息
+func (r *Reader) ValidateContext(ctx context.Context, profile ValidationProfile) (*ValidationReport, error) {
+	if ctx == nil {
+		panic("ofdgo: nil context")
+	}
+	return r.validate(ctx, profile)
+}
+
+// VerifySignaturesBytesContext 使用上下文验证OFD字节数据签名
+// 入参: ctx 上下文, data OFD字节数据, opts 签名验证选项
+// 返回: []SignatureVerifyReport 签名验证报告, error 错误信息
+func VerifySignaturesBytesContext(ctx context.Context, data []byte, opts ...SignatureVerifyOption) ([]SignatureVerifyReport, error) {
+	reader, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	return reader.VerifySignaturesContext(ctx, opts...)
+}
+
+// contextReader 读取前检查上下文取消的读取器
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+// Read 读取数据
+// 入参: p 缓冲区
+// 返回: int 读取长度, error 错误信息
+func (cr contextReader) Read(p []byte) (int, error) {
+	if err := cr.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cr.r.Read(p)
+}
+
+// contextErr 获取可能为空的上下文错误
+// 入参: ctx 上下文
+// 返回: error 上下文取消或超时错误
+func contextErr(ctx context.Context) error {
+	if ctx == nil {
+		return nil
+	}
+	return ctx.Err()
+}
@@ -0,0 +1,38 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build js && wasm
+
+package ofdgo
+
+import "time"
+
+// renderYieldInterval 浏览器渲染让出事件循环的间隔
+const renderYieldInterval = 50 * time.Millisecond
+
+// renderYield 定期让出浏览器事件循环
+// WASM单线程运行, 渲染协程需休眠才能让浏览器处理取消等事件
+// 入参: last 上次让出时间
+func renderYield(last *time.Time) {
+	now := time.Now()
+	if last.IsZero() {
+		*last = now
+		return
+	}
+	if now.Sub(*last) < renderYieldInterval {
+		return
+	}
+	time.Sleep(time.Millisecond)
+	*last = time.Now()
+}
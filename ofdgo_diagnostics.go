@@ -0,0 +1,179 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"fmt"
+	"sync"
+)
+
+// DiagnosticSeverity 诊断级别
+type DiagnosticSeverity string
+
+const (
+	// DiagnosticInfo 提示信息, 不影响显示
+	DiagnosticInfo DiagnosticSeverity = "info"
+	// DiagnosticWarning 警告, 部分内容可能缺失或降级显示
+	DiagnosticWarning DiagnosticSeverity = "warning"
+	// DiagnosticError 错误, 对应内容无法显示
+	DiagnosticError DiagnosticSeverity = "error"
+)
+
+// maxDiagnostics 单个收集器最多保留的诊断条数
+const maxDiagnostics = 10000
+
+// Diagnostic 诊断信息
+type Diagnostic struct {
+	Severity DiagnosticSeverity `json:"severity"`
+	Part     string             `json:"part,omitempty"`
+	ObjectID string             `json:"objectId,omitempty"`
+	Message  string             `json:"message"`
+}
+
+// String 获取诊断描述
+// 返回: string 诊断描述
+func (d Diagnostic) String() string {
+	s := string(d.Severity) + ":"
+	if d.Part != "" {
+		s += " " + d.Part
+	}
+	if d.ObjectID != "" {
+		s += " #" + d.ObjectID
+	}
+	return s + " " + d.Message
+}
+
+// Diagnostics 诊断信息收集器
+// 可在多个协程中并发使用, 相同的诊断只记录一次
+type Diagnostics struct {
+	mu    sync.Mutex
+	items []Diagnostic
+	seen  map[Diagnostic]bool
+}
+
+// NewDiagnostics 创建诊断信息收集器
+// 返回: *Diagnostics 诊断信息收集器
+func NewDiagnostics() *Diagnostics {
+	return &Diagnostics{seen: make(map[Diagnostic]bool)}
+}
+
+// WithDiagnostics 设置阅读器诊断信息收集器
+// 由该阅读器创建的渲染器默认共用此收集器
+// 入参: d 诊断信息收集器
+// 返回: ReaderOption 阅读器选项
+func WithDiagnostics(d *Diagnostics) ReaderOption {
+	return func(r *Reader) {
+		r.diagnostics = d
+	}
+}
+
+// WithRenderDiagnostics 设置渲染器诊断信息收集器
+// 入参: d 诊断信息收集器
+// 返回: RendererOption 渲染选项
+func WithRenderDiagnostics(d *Diagnostics) RendererOption {
+	return func(r *Renderer) {
+		r.diagnostics = d
+	}
+}
+
+// Add 添加诊断信息
+// 入参: diag 诊断信息
+func (d *Diagnostics) Add(diag Diagnostic) {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.seen == nil {
+		d.seen = make(map[Diagnostic]bool)
+	}
+	if d.seen[diag] || len(d.items) >= maxDiagnostics {
+		return
+	}
+	d.seen[diag] = true
+	d.items = append(d.items, diag)
+}
+
+// List 获取已记录的诊断信息
+// 返回: []Diagnostic 按记录顺序排列的诊断信息
+func (d *Diagnostics) List() []Diagnostic {
+	if d == nil {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]Diagnostic(nil), d.items...)
+}
+
+// Filter 获取不低于指定级别的诊断信息
+// 入参: severity 最低诊断级别
+// 返回: []Diagnostic 诊断信息
+func (d *Diagnostics) Filter(severity DiagnosticSeverity) []Diagnostic {
+	var result []Diagnostic
+	for _, diag := range d.List() {
+		if diagnosticRank(diag.Severity) >= diagnosticRank(severity) {
+			result = append(result, diag)
+		}
+	}
+	return result
+}
+
+// Reset 清空已记录的诊断信息
+func (d *Diagnostics) Reset() {
+	if d == nil {
+		return
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.items = nil
+	d.seen = make(map[Diagnostic]bool)
+}
+
+// diagnosticRank 获取诊断级别排序值
+// 入参: severity 诊断级别
+// 返回: int 排序值
+func diagnosticRank(severity DiagnosticSeverity) int {
+	switch severity {
+	case DiagnosticError:
+		return 2
+	case DiagnosticWarning:
+		return 1
+	}
+	return 0
+}
+
+// Diagnostics 获取阅读器诊断信息收集器
+// 返回: *Diagnostics 诊断信息收集器
+func (r *Reader) Diagnostics() *Diagnostics {
+	return r.diagnostics
+}
+
+// diagnose 记录阅读器诊断信息
+// 入参: severity 诊断级别, part 包内文件路径, objectID 对象ID, format 消息格式, args 消息参数
+func (r *Reader) diagnose(severity DiagnosticSeverity, part, objectID, format string, args ...any) {
+	r.diagnostics.Add(Diagnostic{Severity: severity, Part: part, ObjectID: objectID, Message: fmt.Sprintf(format, args...)})
+}
+
+// Diagnostics 获取渲染器诊断信息收集器
+// 返回: *Diagnostics 诊断信息收集器
+func (r *Renderer) Diagnostics() *Diagnostics {
+	return r.diagnostics
+}
+
+// diagnose 记录渲染器诊断信息
+// 入参: severity 诊断级别, objectID 对象ID, format 消息格式, args 消息参数
+func (r *Renderer) diagnose(severity DiagnosticSeverity, objectID, format string, args ...any) {
+	r.diagnostics.Add(Diagnostic{Severity: severity, Part: r.part, ObjectID: objectID, Message: fmt.Sprintf(format, args...)})
+}
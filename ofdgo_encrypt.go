@@ -0,0 +1,61 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// encryptListPath 加密信息文件路径
+const encryptListPath = "Encryptions.xml"
+
+// ErrEncryptedPackage 加密文档暂不支持解密
+// 加密结构由GM/T 0099规定, 本库尚未实现, 读取加密文档内容时返回该错误
+var ErrEncryptedPackage = errors.New("encrypted ofd packages are not supported")
+
+// ReaderOption 阅读器选项函数
+type ReaderOption func(*Reader)
+
+// Encrypted 判断文档是否为加密文档
+// 包根目录存在Encryptions.xml时视为加密文档, 除OFD.xml和加密信息列表外的包内文件均无法读取
+// 返回: bool 是否加密
+func (r *Reader) Encrypted() bool {
+	return r.encrypted
+}
+
+// checkEncrypted 检查包内文件是否因文档加密而无法读取
+// 入参: name 清理后的文件路径
+// 返回: error 错误信息
+func (r *Reader) checkEncrypted(name string) error {
+	if !r.encrypted || strings.EqualFold(name, "OFD.xml") || strings.EqualFold(name, encryptListPath) {
+		return nil
+	}
+	return fmt.Errorf("%s: %w", name, ErrEncryptedPackage)
+}
+
+// readEntry 读取包内文件
+// 入参: name 清理后的文件路径, f 包内文件
+// 返回: []byte 文件内容, error 错误信息
+func (r *Reader) readEntry(name string, f *packageEntry) ([]byte, error) {
+	if err := r.checkEncrypted(cleanPackagePath(f.name)); err != nil {
+		return nil, err
+	}
+	if err := r.limits.checkEntry(f); err != nil {
+		return nil, err
+	}
+	return readPackageData(f)
+}
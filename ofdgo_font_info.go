@@ -71,7 +71,9 @@ func (r *Renderer) FontInfos() ([]FontInfo, error) {
 	if err != nil {
 		return nil, err
 	}
-	return r.fontInfos(doc, nil)
+	renderer, release := r.acquireState()
+	defer release()
+	return renderer.fontInfos(doc, nil)
 }
 
 // FontInfosFromPages 从页面内容获取OFD字体诊断信息
@@ -85,7 +87,9 @@ func (r *Renderer) FontInfosFromPages(pages []*PageContent) ([]FontInfo, error)
 	if pages == nil {
 		pages = []*PageContent{}
 	}
-	return r.fontInfos(doc, pages)
+	renderer, release := r.acquireState()
+	defer release()
+	return renderer.fontInfos(doc, pages)
 }
 
 // fontInfoDocument 获取字体诊断文档结构
@@ -116,6 +120,9 @@ func (r *Renderer) fontInfos(doc *Document, pages []*PageContent) ([]FontInfo, e
 	infos := make([]FontInfo, 0, len(fonts)+len(usage))
 	seen := make(map[string]bool)
 	for _, font := range fonts {
+		if err := r.ctxErr(); err != nil {
+			return nil, err
+		}
 		info := r.fontInfo(font)
 		info.Used = usage[font.ID]
 		infos = append(infos, info)
@@ -142,6 +149,9 @@ func (r *Renderer) fontInfos(doc *Document, pages []*PageContent) ([]FontInfo, e
 		}
 		return infos[i].ID < infos[j].ID
 	})
+	if err := r.ctxErr(); err != nil {
+		return nil, err
+	}
 	return infos, nil
 }
 
@@ -53,6 +53,7 @@ type fontCacheKey struct {
 }
 
 // loadFont 加载字体
+// 上下文取消导致未找到字体时返回默认字体且不写入缓存, 以免影响后续渲染
 // 入参: fontID 字体ID
 // 返回: *canvas.FontFamily 字体族
 func (r *Renderer) loadFont(fontID string) *canvas.FontFamily {
@@ -65,45 +66,51 @@ func (r *Renderer) loadFont(fontID string) *canvas.FontFamily {
 	}
 	of, ok := r.Reader.fontCache[fontID]
 	if !ok {
+		if fontID != "" {
+			r.diagnose(DiagnosticWarning, fontID, "font resource not found")
+		}
 		return defaultFont
 	}
 	fontStyle := canvasFontStyle(of)
 	ff := canvas.NewFontFamily(of.FontName)
 	if of.FontFile != "" {
-		if fontData, err := r.Reader.ResData(of.FontFile); err == nil {
-			if cidMap := getCFFCIDRuneMap(fontData); len(cidMap) > 0 {
-				if r.FontCIDMap == nil {
-					r.FontCIDMap = make(map[string]map[uint16]rune)
-				}
-				r.FontCIDMap[fontID] = cidMap
+		fontData, err := r.Reader.ResData(of.FontFile)
+		if err != nil {
+			r.diagnose(DiagnosticError, fontID, "failed to read embedded font %s: %v", of.FontFile, err)
+			return nil
+		}
+		if cidMap := getCFFCIDRuneMap(fontData); len(cidMap) > 0 {
+			if r.FontCIDMap == nil {
+				r.FontCIDMap = make(map[string]map[uint16]rune)
 			}
-			if _, fixedData, mapping, _, err := FixFontDataAggressive(fontData, true, true); err == nil {
-				fontData = fixedData
-				if mapping != nil {
-					if r.FontGIDMap == nil {
-						r.FontGIDMap = make(map[string]map[uint16]rune)
-					}
-					inv := make(map[uint16]rune)
-					for k, v := range mapping {
-						if k == packedGlyphRune(v) {
-							inv[v] = k
-						}
+			r.FontCIDMap[fontID] = cidMap
+		}
+		if _, fixedData, mapping, _, err := FixFontDataAggressive(fontData, true, true); err == nil {
+			fontData = fixedData
+			if mapping != nil {
+				if r.FontGIDMap == nil {
+					r.FontGIDMap = make(map[string]map[uint16]rune)
+				}
+				inv := make(map[uint16]rune)
+				for k, v := range mapping {
+					if k == packedGlyphRune(v) {
+						inv[v] = k
 					}
-					for k, v := range mapping {
-						if _, ok := inv[v]; !ok {
-							inv[v] = k
-						}
+				}
+				for k, v := range mapping {
+					if _, ok := inv[v]; !ok {
+						inv[v] = k
 					}
-					r.FontGIDMap[fontID] = inv
 				}
+				r.FontGIDMap[fontID] = inv
 			}
-			if err := ff.LoadFont(fontData, 0, fontStyle); err == nil {
-				r.FontMap[fontID] = ff
-				return ff
-			}
+		}
+		if err := ff.LoadFont(fontData, 0, fontStyle); err != nil {
+			r.diagnose(DiagnosticError, fontID, "failed to load embedded font %s: %v", of.FontFile, err)
 			return nil
 		}
-		return nil
+		r.FontMap[fontID] = ff
+		return ff
 	}
 	for _, source := range r.fontSources(fontID, of, fontStyle) {
 		if loaded := r.loadFontSource(ff, source, fontStyle); loaded != nil {
@@ -112,6 +119,14 @@ func (r *Renderer) loadFont(fontID string) *canvas.FontFamily {
 			return loaded
 		}
 	}
+	if r.ctxErr() != nil {
+		return defaultFont
+	}
+	if defaultFont != nil {
+		r.diagnose(DiagnosticInfo, fontID, "font %s not found, using default font", of.FontName)
+	} else {
+		r.diagnose(DiagnosticWarning, fontID, "font %s not found and no default font available", of.FontName)
+	}
 	r.FontMap[fontID] = defaultFont
 	return defaultFont
 }
@@ -143,6 +158,9 @@ func (r *Renderer) fontSources(fontID string, font *Font, style canvas.FontStyle
 	sources := make([]fontSource, 0)
 	seen := make(map[fontSourceKey]bool)
 	for _, dir := range r.fontDirs {
+		if r.ctxErr() != nil {
+			return sources
+		}
 		for _, name := range r.matchFontFiles(dir, patterns, bold, italic) {
 			sources = appendFontSource(sources, seen, fontSource{kind: fontSourceFile, name: name, exact: true})
 		}
@@ -159,7 +177,9 @@ func (r *Renderer) fontSources(fontID string, font *Font, style canvas.FontStyle
 				sources = appendFontSource(sources, seen, fontSource{kind: fontSourceFS, index: index, name: name})
 			}
 		}
-		r.fontSourceCache[fontID] = sources
+		if r.ctxErr() == nil {
+			r.fontSourceCache[fontID] = sources
+		}
 		return sources
 	}
 	systemDirs := systemFontDirs()
@@ -167,6 +187,9 @@ func (r *Renderer) fontSources(fontID string, font *Font, style canvas.FontStyle
 		for _, systemName := range fontSystemNames(name) {
 			systemPatterns := fontFilePatterns(systemName)
 			for _, dir := range systemDirs {
+				if r.ctxErr() != nil {
+					return sources
+				}
 				for _, match := range r.matchFontFiles(dir, systemPatterns, bold, italic) {
 					sources = appendFontSource(sources, seen, fontSource{kind: fontSourceFile, name: match, exact: true})
 				}
@@ -174,7 +197,9 @@ func (r *Renderer) fontSources(fontID string, font *Font, style canvas.FontStyle
 			sources = appendFontSource(sources, seen, fontSource{kind: fontSourceSystem, name: systemName, exact: true})
 		}
 	}
-	r.fontSourceCache[fontID] = sources
+	if r.ctxErr() == nil {
+		r.fontSourceCache[fontID] = sources
+	}
 	return sources
 }
 
@@ -225,6 +250,9 @@ func (r *Renderer) loadFontSource(family *canvas.FontFamily, source fontSource,
 	if cached, ok := r.fontCache[key]; ok {
 		return cached
 	}
+	if r.ctxErr() != nil {
+		return nil
+	}
 	var err error
 	switch source.kind {
 	case fontSourceFile:
@@ -250,11 +278,17 @@ func (r *Renderer) loadFontSource(family *canvas.FontFamily, source fontSource,
 // 入参: dir 目录, patterns 模式列表, bold 是否粗体, italic 是否斜体
 // 返回: []string 文件列表
 func (r *Renderer) matchFontFiles(dir string, patterns []string, bold, italic bool) []string {
+	if r.ctxErr() != nil {
+		return nil
+	}
 	files, _ := filepath.Glob(filepath.Join(dir, "*"))
 	candidates := fontFileCandidates(files, filepath.Base)
 	matches := make([]fontFileMatch, 0, len(candidates))
 	index := make(map[string]int, len(candidates))
 	for _, matcher := range newFontPatternMatchers(patterns) {
+		if r.ctxErr() != nil {
+			return nil
+		}
 		for _, file := range candidates {
 			rank := matcher.rankCandidate(file)
 			appendFontFileMatch(&matches, index, matcher, file, rank, bold, italic)
@@ -0,0 +1,277 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"time"
+)
+
+// LimitKind 资源限制项
+type LimitKind string
+
+const (
+	LimitEntrySize        LimitKind = "entry size"
+	LimitPackageSize      LimitKind = "package size"
+	LimitCompressionRatio LimitKind = "compression ratio"
+	LimitImagePixels      LimitKind = "image pixels"
+	LimitPageObjects      LimitKind = "page objects"
+	LimitNestingDepth     LimitKind = "nesting depth"
+	LimitRenderTime       LimitKind = "render time"
+)
+
+// limitRatioMinSize 参与压缩比检查的最小解压大小, 避免误判小文件
+const limitRatioMinSize = 1 << 20
+
+// ErrLimitExceeded 超出资源限制
+var ErrLimitExceeded = errors.New("limit exceeded")
+
+// LimitError 超出资源限制错误
+// 可通过errors.Is(err, ErrLimitExceeded)判断, 渲染时间的Value和Max单位为纳秒
+type LimitError struct {
+	Kind  LimitKind
+	Name  string
+	Value int64
+	Max   int64
+}
+
+// Error 获取错误描述
+// 返回: string 错误描述
+func (e *LimitError) Error() string {
+	if e.Kind == LimitRenderTime {
+		return fmt.Sprintf("%s limit exceeded: %s > %s", e.Kind, time.Duration(e.Value), time.Duration(e.Max))
+	}
+	if e.Name != "" {
+		return fmt.Sprintf("%s limit exceeded for %s: %d > %d", e.Kind, e.Name, e.Value, e.Max)
+	}
+	return fmt.Sprintf("%s limit exceeded: %d > %d", e.Kind, e.Value, e.Max)
+}
+
+// Is 判断是否为资源限制错误
+// 入参: target 目标错误
+// 返回: bool 是否匹配
+func (e *LimitError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// Limits 不可信输入的资源限制, 字段为零表示不限制
+type Limits struct {
+	MaxEntrySize        int64         // 单个文件解压后最大字节数
+	MaxPackageSize      int64         // 包内文件解压后总字节数
+	MaxCompressionRatio float64       // 单个文件最大压缩比
+	MaxImagePixels      int64         // 单张图片最大像素数
+	MaxPageObjects      int           // 单页最大图元数量
+	MaxNestingDepth     int           // 复合图元、绘制参数和嵌套印章最大嵌套深度
+	MaxRenderTime       time.Duration // 单页最大渲染时间
+}
+
+// DefaultLimits 获取适用于公开上传文件的默认资源限制
+// 返回: Limits 资源限制
+func DefaultLimits() Limits {
+	return Limits{
+		MaxEntrySize:        256 << 20,
+		MaxPackageSize:      1 << 30,
+		MaxCompressionRatio: 1000,
+		MaxImagePixels:      100_000_000,
+		MaxPageObjects:      1_000_000,
+		MaxNestingDepth:     64,
+		MaxRenderTime:       time.Minute,
+	}
+}
+
+// WithLimits 设置阅读器资源限制
+// 由该阅读器创建的渲染器默认继承此限制
+// 入参: limits 资源限制
+// 返回: ReaderOption 阅读器选项
+func WithLimits(limits Limits) ReaderOption {
+	return func(r *Reader) {
+		r.limits = limits
+	}
+}
+
+// WithRenderLimits 设置渲染器资源限制
+// 入参: limits 资源限制
+// 返回: RendererOption 渲染器选项
+func WithRenderLimits(limits Limits) RendererOption {
+	return func(r *Renderer) {
+		r.limits = limits
+	}
+}
+
+// checkPackage 检查压缩包解压后总大小
+// 入参: files 包内文件列表
+// 返回: error 错误信息
+func (l Limits) checkPackage(files []*packageEntry) error {
+	if l.MaxPackageSize <= 0 {
+		return nil
+	}
+	var total uint64
+	for _, f := range files {
+		total += f.size
+		if total > uint64(l.MaxPackageSize) {
+			return &LimitError{Kind: LimitPackageSize, Value: int64(min(total, 1<<63-1)), Max: l.MaxPackageSize}
+		}
+	}
+	return nil
+}
+
+// checkEntry 检查单个文件解压大小和压缩比
+// 读取时会校验实际长度与声明长度一致, 因此检查声明值即可
+// 入参: f 包内文件
+// 返回: error 错误信息
+func (l Limits) checkEntry(f *packageEntry) error {
+	size := int64(min(f.size, 1<<63-1))
+	if l.MaxEntrySize > 0 && size > l.MaxEntrySize {
+		return &LimitError{Kind: LimitEntrySize, Name: f.name, Value: size, Max: l.MaxEntrySize}
+	}
+	if l.MaxCompressionRatio > 0 && size >= limitRatioMinSize {
+		ratio := float64(size) / float64(max(f.compressedSize, 1))
+		if ratio > l.MaxCompressionRatio {
+			return &LimitError{Kind: LimitCompressionRatio, Name: f.name, Value: int64(ratio), Max: int64(l.MaxCompressionRatio)}
+		}
+	}
+	return nil
+}
+
+// checkImage 检查图片像素数
+// 入参: width 图片宽度, height 图片高度
+// 返回: error 错误信息
+func (l Limits) checkImage(width, height int) error {
+	if l.MaxImagePixels <= 0 {
+		return nil
+	}
+	pixels := int64(width) * int64(height)
+	if width < 0 || height < 0 || pixels > l.MaxImagePixels {
+		return &LimitError{Kind: LimitImagePixels, Value: pixels, Max: l.MaxImagePixels}
+	}
+	return nil
+}
+
+// decodeImageDataLimited 检查像素数后解码图片数据
+// 入参: data 图片数据, limits 资源限制
+// 返回: image.Image 图片对象, error 错误信息
+func decodeImageDataLimited(data []byte, limits Limits) (image.Image, error) {
+	if limits.MaxImagePixels > 0 {
+		if cfg, _, err := decodeImageConfigData(data); err == nil {
+			if err := limits.checkImage(cfg.Width, cfg.Height); err != nil {
+				return nil, err
+			}
+		}
+	}
+	img, _, err := decodeImageData(data)
+	if err != nil {
+		return nil, err
+	}
+	b := img.Bounds()
+	if err := limits.checkImage(b.Dx(), b.Dy()); err != nil {
+		return nil, err
+	}
+	return img, nil
+}
+
+// renderBudget 单页渲染资源计数
+type renderBudget struct {
+	ctx       context.Context
+	limits    Limits
+	deadline  time.Time
+	lastYield time.Time
+	objects   int
+	depth     int
+	err       error
+}
+
+// newRenderBudget 创建单页渲染资源计数
+// 入参: ctx 上下文, limits 资源限制
+// 返回: *renderBudget 渲染资源计数
+func newRenderBudget(ctx context.Context, limits Limits) *renderBudget {
+	b := &renderBudget{ctx: ctx, limits: limits}
+	if limits.MaxRenderTime > 0 {
+		b.deadline = time.Now().Add(limits.MaxRenderTime)
+	}
+	return b
+}
+
+// check 检查上下文取消和已记录的错误
+// 返回: bool 是否继续渲染
+func (b *renderBudget) check() bool {
+	if b == nil {
+		return true
+	}
+	if b.err != nil {
+		return false
+	}
+	if b.ctx != nil && b.ctx.Done() != nil {
+		renderYield(&b.lastYield)
+		if err := b.ctx.Err(); err != nil {
+			b.err = err
+			return false
+		}
+	}
+	return true
+}
+
+// object 记录一个图元并检查数量和时间限制
+// 返回: bool 是否继续渲染
+func (b *renderBudget) object() bool {
+	if !b.check() {
+		return false
+	}
+	b.objects++
+	if b.limits.MaxPageObjects > 0 && b.objects > b.limits.MaxPageObjects {
+		b.err = &LimitError{Kind: LimitPageObjects, Value: int64(b.objects), Max: int64(b.limits.MaxPageObjects)}
+		return false
+	}
+	if !b.deadline.IsZero() && time.Now().After(b.deadline) {
+		elapsed := time.Since(b.deadline.Add(-b.limits.MaxRenderTime))
+		b.err = &LimitError{Kind: LimitRenderTime, Value: int64(elapsed), Max: int64(b.limits.MaxRenderTime)}
+		return false
+	}
+	return true
+}
+
+// enter 进入一层嵌套并检查深度限制
+// 返回: bool 是否继续渲染, 为true时须调用leave
+func (b *renderBudget) enter() bool {
+	if b == nil {
+		return true
+	}
+	if b.err != nil {
+		return false
+	}
+	if b.limits.MaxNestingDepth > 0 && b.depth >= b.limits.MaxNestingDepth {
+		b.err = &LimitError{Kind: LimitNestingDepth, Value: int64(b.depth + 1), Max: int64(b.limits.MaxNestingDepth)}
+		return false
+	}
+	b.depth++
+	return true
+}
+
+// leave 退出一层嵌套
+func (b *renderBudget) leave() {
+	if b != nil {
+		b.depth--
+	}
+}
+
+// fail 记录渲染过程中的资源限制错误
+// 入参: err 错误信息
+func (b *renderBudget) fail(err error) {
+	if b != nil && b.err == nil && errors.Is(err, ErrLimitExceeded) {
+		b.err = err
+	}
+}
@@ -40,11 +40,17 @@ type DocInfo struct {
 	Author       string       `xml:"Author"`
 	Subject      string       `xml:"Subject"`
 	Abstract     string       `xml:"Abstract"`
+	Keywords     *Keywords    `xml:"Keywords"`
 	CreationDate string       `xml:"CreationDate"`
 	ModDate      string       `xml:"ModDate"`
 	CustomDatas  *CustomDatas `xml:"CustomDatas"`
 }
 
+// Keywords 关键词集合
+type Keywords struct {
+	Keyword []string `xml:"Keyword"`
+}
+
 // CustomDatas 自定义数据集合
 type CustomDatas struct {
 	CustomData []CustomData `xml:"CustomData"`
@@ -20,8 +20,10 @@ import "encoding/xml"
 type PageContent struct {
 	XMLName  xml.Name   `xml:"Page"`
 	ID       string     `xml:"-"`
+	Path     string     `xml:"-"`
 	Area     PageArea   `xml:"Area"`
 	Template []Template `xml:"Template"`
+	PageRes  []string   `xml:"PageRes"`
 	Content  Content    `xml:"Content"`
 	Actions  []Action   `xml:"Actions>Action"`
 }
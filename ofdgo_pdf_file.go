@@ -0,0 +1,732 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"bytes"
+	"compress/zlib"
+	"crypto/md5"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+)
+
+// pdfFile PDF对象级改写器
+// 用于在canvas写出的PDF上补充目录、元数据和嵌入文件等对象, 仅支持交叉引用表格式
+type pdfFile struct {
+	header  []byte
+	objects [][]byte // 按对象号索引的对象内容, 不含obj和endobj关键字
+	root    int
+	info    int
+}
+
+// parsePDFFile 解析PDF文件
+// 入参: data PDF数据
+// 返回: *pdfFile PDF改写器, error 错误信息
+func parsePDFFile(data []byte) (*pdfFile, error) {
+	idx := bytes.LastIndex(data, []byte("startxref"))
+	if idx < 0 {
+		return nil, fmt.Errorf("pdf startxref not found")
+	}
+	fields := strings.Fields(string(data[idx+len("startxref"):]))
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("invalid pdf startxref")
+	}
+	xrefOffset, err := strconv.Atoi(fields[0])
+	if err != nil || xrefOffset <= 0 || xrefOffset >= idx {
+		return nil, fmt.Errorf("invalid pdf startxref")
+	}
+	xref, trailer, ok := bytes.Cut(data[xrefOffset:idx], []byte("trailer"))
+	if !ok {
+		return nil, fmt.Errorf("pdf trailer not found")
+	}
+	lines := strings.Split(strings.TrimSpace(string(xref)), "\n")
+	if len(lines) < 2 || strings.TrimSpace(lines[0]) != "xref" {
+		return nil, fmt.Errorf("unsupported pdf cross-reference")
+	}
+	header := strings.Fields(lines[1])
+	if len(header) != 2 || header[0] != "0" {
+		return nil, fmt.Errorf("unsupported pdf cross-reference")
+	}
+	count, err := strconv.Atoi(header[1])
+	if err != nil || count != len(lines)-2 {
+		return nil, fmt.Errorf("invalid pdf cross-reference")
+	}
+	offsets := make([]int, count)
+	for i := 1; i < count; i++ {
+		entry := strings.Fields(lines[i+2])
+		if len(entry) != 3 || entry[2] != "n" {
+			return nil, fmt.Errorf("invalid pdf cross-reference entry %d", i)
+		}
+		if offsets[i], err = strconv.Atoi(entry[0]); err != nil || offsets[i] <= 0 || offsets[i] >= xrefOffset {
+			return nil, fmt.Errorf("invalid pdf cross-reference entry %d", i)
+		}
+	}
+	order := make([]int, 0, count)
+	for i := 1; i < count; i++ {
+		order = append(order, i)
+	}
+	sort.Slice(order, func(i, j int) bool { return offsets[order[i]] < offsets[order[j]] })
+	f := &pdfFile{objects: make([][]byte, count)}
+	if len(order) > 0 {
+		f.header = bytes.Clone(data[:offsets[order[0]]])
+	}
+	for k, num := range order {
+		end := xrefOffset
+		if k+1 < len(order) {
+			end = offsets[order[k+1]]
+		}
+		body := bytes.TrimSpace(data[offsets[num]:end])
+		prefix := fmt.Sprintf("%d 0 obj", num)
+		if !bytes.HasPrefix(body, []byte(prefix)) || !bytes.HasSuffix(body, []byte("endobj")) {
+			return nil, fmt.Errorf("invalid pdf object %d", num)
+		}
+		f.objects[num] = bytes.Clone(bytes.TrimSpace(body[len(prefix) : len(body)-len("endobj")]))
+	}
+	trailerDict := strings.TrimSpace(string(trailer))
+	f.root = pdfRefNumber(pdfDictGet(trailerDict, "Root"))
+	f.info = pdfRefNumber(pdfDictGet(trailerDict, "Info"))
+	if f.root <= 0 || f.root >= count {
+		return nil, fmt.Errorf("pdf catalog not found")
+	}
+	return f, nil
+}
+
+// add 添加对象
+// 入参: body 对象内容
+// 返回: int 对象号
+func (f *pdfFile) add(body string) int {
+	f.objects = append(f.objects, []byte(body))
+	return len(f.objects) - 1
+}
+
+// addStream 添加压缩的流对象
+// 入参: dict 流字典, 不含Length和Filter, data 流数据
+// 返回: int 对象号
+func (f *pdfFile) addStream(dict string, data []byte) int {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	_, _ = zw.Write(data)
+	_ = zw.Close()
+	dict = pdfDictSet(dict, "Filter", "/FlateDecode")
+	return f.addRawStream(dict, buf.Bytes())
+}
+
+// addRawStream 添加未压缩的流对象
+// 入参: dict 流字典, 不含Length, data 流数据
+// 返回: int 对象号
+func (f *pdfFile) addRawStream(dict string, data []byte) int {
+	f.objects = append(f.objects, pdfStreamBody(dict, data))
+	return len(f.objects) - 1
+}
+
+// streamData 获取流对象解码后的数据
+// 仅支持未压缩和FlateDecode压缩的流
+// 入参: num 对象号
+// 返回: []byte 流数据, bool 是否可解码
+func (f *pdfFile) streamData(num int) ([]byte, bool) {
+	dict := f.dict(num)
+	if dict == "" || pdfDictGet(dict, "DecodeParms") != "" {
+		return nil, false
+	}
+	length, err := strconv.Atoi(pdfDictGet(dict, "Length"))
+	if err != nil || length < 0 {
+		return nil, false
+	}
+	rest := f.objects[num][len(dict):]
+	i := pdfSkipSpace(rest, 0)
+	if !bytes.HasPrefix(rest[i:], []byte("stream")) {
+		return nil, false
+	}
+	i += len("stream")
+	if i < len(rest) && rest[i] == '\r' {
+		i++
+	}
+	if i < len(rest) && rest[i] == '\n' {
+		i++
+	}
+	if i+length > len(rest) {
+		return nil, false
+	}
+	data := rest[i : i+length]
+	switch pdfDictGet(dict, "Filter") {
+	case "":
+		return bytes.Clone(data), true
+	case "/FlateDecode":
+		zr, err := zlib.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, false
+		}
+		defer zr.Close()
+		decoded, err := io.ReadAll(zr)
+		if err != nil {
+			return nil, false
+		}
+		return decoded, true
+	}
+	return nil, false
+}
+
+// setStream 以FlateDecode压缩替换流对象数据
+// 入参: num 对象号, data 流数据
+func (f *pdfFile) setStream(num int, data []byte) {
+	var buf bytes.Buffer
+	zw := zlib.NewWriter(&buf)
+	_, _ = zw.Write(data)
+	_ = zw.Close()
+	f.objects[num] = pdfStreamBody(pdfDictSet(f.dict(num), "Filter", "/FlateDecode"), buf.Bytes())
+}
+
+// pdfStreamBody 生成流对象内容
+// 入参: dict 流字典, 不含Length, data 流数据
+// 返回: []byte 流对象内容
+func pdfStreamBody(dict string, data []byte) []byte {
+	dict = pdfDictSet(dict, "Length", strconv.Itoa(len(data)))
+	body := make([]byte, 0, len(dict)+len(data)+20)
+	body = append(body, dict...)
+	body = append(body, "\nstream\n"...)
+	body = append(body, data...)
+	return append(body, "\nendstream"...)
+}
+
+// dict 获取对象字典
+// 入参: num 对象号
+// 返回: string 对象字典, 非字典对象为空
+func (f *pdfFile) dict(num int) string {
+	if num <= 0 || num >= len(f.objects) {
+		return ""
+	}
+	body := f.objects[num]
+	if !bytes.HasPrefix(body, []byte("<<")) {
+		return ""
+	}
+	return string(body[:pdfSkipValue(body, 0)])
+}
+
+// setDict 替换对象字典, 保留流数据
+// 入参: num 对象号, dict 新字典
+func (f *pdfFile) setDict(num int, dict string) {
+	old := f.dict(num)
+	rest := f.objects[num][len(old):]
+	body := make([]byte, 0, len(dict)+len(rest))
+	body = append(body, dict...)
+	f.objects[num] = append(body, rest...)
+}
+
+// pages 获取按页面树顺序排列的页面对象号
+// 返回: []int 页面对象号
+func (f *pdfFile) pages() []int {
+	var pages []int
+	var walk func(num, depth int)
+	walk = func(num, depth int) {
+		dict := f.dict(num)
+		if dict == "" || depth > 32 {
+			return
+		}
+		if pdfDictGet(dict, "Type") == "/Page" {
+			pages = append(pages, num)
+			return
+		}
+		for _, kid := range pdfRefNumbers(pdfDictGet(dict, "Kids")) {
+			walk(kid, depth+1)
+		}
+	}
+	walk(pdfRefNumber(pdfDictGet(f.dict(f.root), "Pages")), 0)
+	return pages
+}
+
+// annots 获取页面注释数组的元素
+// 入参: page 页面对象号
+// 返回: []string 注释数组元素
+func (f *pdfFile) annots(page int) []string {
+	annots := pdfDictGet(f.dict(page), "Annots")
+	if num := pdfRefNumber(annots); num > 0 {
+		annots = string(f.objects[num])
+	}
+	return pdfArrayItems(annots)
+}
+
+// appendAnnots 向页面注释数组追加注释
+// 入参: page 页面对象号, refs 注释对象号
+func (f *pdfFile) appendAnnots(page int, refs []int) {
+	items := f.annots(page)
+	for _, num := range refs {
+		items = append(items, pdfRef(num))
+	}
+	f.setDict(page, pdfDictSet(f.dict(page), "Annots", "["+strings.Join(items, " ")+"]"))
+}
+
+// removeAnnots 从页面注释数组移除注释
+// 入参: page 页面对象号, drop 需移除的注释对象号
+func (f *pdfFile) removeAnnots(page int, drop map[int]bool) {
+	items := f.annots(page)
+	kept := items[:0]
+	for _, item := range items {
+		if !drop[pdfRefNumber(item)] {
+			kept = append(kept, item)
+		}
+	}
+	if len(kept) == len(items) {
+		return
+	}
+	dict := f.dict(page)
+	if len(kept) == 0 {
+		f.setDict(page, pdfDictDelete(dict, "Annots"))
+		return
+	}
+	f.setDict(page, pdfDictSet(dict, "Annots", "["+strings.Join(kept, " ")+"]"))
+}
+
+// hoistAnnots 将页面中内联的注释字典改写为间接对象
+// 便于后续逐对象补全注释属性, 同时补充注释所在页面
+func (f *pdfFile) hoistAnnots() {
+	for _, page := range f.pages() {
+		items := f.annots(page)
+		changed := false
+		for i, item := range items {
+			if !strings.HasPrefix(item, "<<") {
+				continue
+			}
+			items[i] = pdfRef(f.add(pdfDictSet(item, "P", pdfRef(page))))
+			changed = true
+		}
+		if changed {
+			f.setDict(page, pdfDictSet(f.dict(page), "Annots", "["+strings.Join(items, " ")+"]"))
+		}
+	}
+}
+
+// importPageForm 将其他PDF的首页导入为表单XObject
+// 页面引用的全部对象随之导入并重新编号, 页面内容流改写为表单
+// 入参: src 来源PDF
+// 返回: int 表单对象号, error 错误信息
+func (f *pdfFile) importPageForm(src *pdfFile) (int, error) {
+	pages := src.pages()
+	if len(pages) == 0 {
+		return 0, fmt.Errorf("pdf page not found")
+	}
+	page := src.dict(pages[0])
+	contents := pdfRefNumber(pdfDictGet(page, "Contents"))
+	if contents <= 0 || src.dict(contents) == "" {
+		return 0, fmt.Errorf("unsupported pdf page contents")
+	}
+	mapping := make(map[int]int)
+	for num := 1; num < len(src.objects); num++ {
+		dict := src.dict(num)
+		if num == src.info || num == pages[0] || pdfDictGet(dict, "Type") == "/Catalog" || pdfDictGet(dict, "Type") == "/Pages" {
+			continue
+		}
+		mapping[num] = f.add("null")
+	}
+	rewrite := func(s string) string {
+		return pdfRefPattern.ReplaceAllStringFunc(s, func(ref string) string {
+			if num, ok := mapping[pdfRefNumber(ref)]; ok {
+				return pdfRef(num)
+			}
+			return "null"
+		})
+	}
+	for num, target := range mapping {
+		dict := src.dict(num)
+		if dict == "" {
+			f.objects[target] = []byte(rewrite(string(src.objects[num])))
+			continue
+		}
+		rest := src.objects[num][len(dict):]
+		dict = rewrite(dict)
+		if num == contents {
+			dict = pdfDictSet(dict, "Type", "/XObject")
+			dict = pdfDictSet(dict, "Subtype", "/Form")
+			dict = pdfDictSet(dict, "BBox", pdfDictGet(page, "MediaBox"))
+			dict = pdfDictSet(dict, "Resources", rewrite(pdfDictGet(page, "Resources")))
+			if group := pdfDictGet(page, "Group"); group != "" {
+				dict = pdfDictSet(dict, "Group", group)
+			}
+		}
+		body := make([]byte, 0, len(dict)+len(rest))
+		body = append(body, dict...)
+		f.objects[target] = append(body, rest...)
+	}
+	return mapping[contents], nil
+}
+
+// bytes 写出PDF数据
+// 文件标识取交叉引用表之前全部内容的MD5
+// 返回: []byte PDF数据
+func (f *pdfFile) bytes() []byte {
+	var buf bytes.Buffer
+	buf.Write(f.header)
+	offsets := make([]int, len(f.objects))
+	for num := 1; num < len(f.objects); num++ {
+		offsets[num] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n", num)
+		buf.Write(f.objects[num])
+		buf.WriteString("\nendobj\n")
+	}
+	xrefOffset := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(f.objects))
+	for num := 1; num < len(f.objects); num++ {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", offsets[num])
+	}
+	sum := md5.Sum(buf.Bytes())
+	trailer := fmt.Sprintf("<</Root %d 0 R/Size %d", f.root, len(f.objects))
+	if f.info > 0 {
+		trailer += fmt.Sprintf("/Info %d 0 R", f.info)
+	}
+	trailer += fmt.Sprintf("/ID[<%X><%X>]>>", sum[:], sum[:])
+	fmt.Fprintf(&buf, "trailer\n%s\nstartxref\n%d\n%%%%EOF\n", trailer, xrefOffset)
+	return buf.Bytes()
+}
+
+// pdfDictGet 获取字典顶层键值
+// 入参: dict 字典, key 键名, 不含斜杠
+// 返回: string 值, 不存在时为空
+func pdfDictGet(dict string, key string) string {
+	if start, end, ok := pdfDictFind([]byte(dict), key); ok {
+		return strings.TrimSpace(dict[start:end])
+	}
+	return ""
+}
+
+// pdfDictSet 设置字典顶层键值
+// 入参: dict 字典, key 键名, 不含斜杠, value 值
+// 返回: string 新字典
+func pdfDictSet(dict string, key, value string) string {
+	if start, end, ok := pdfDictFind([]byte(dict), key); ok {
+		return dict[:start] + " " + value + dict[end:]
+	}
+	idx := strings.LastIndex(dict, ">>")
+	if idx < 0 {
+		return dict
+	}
+	return dict[:idx] + "/" + key + " " + value + dict[idx:]
+}
+
+// pdfDictDelete 删除字典顶层键
+// 入参: dict 字典, key 键名, 不含斜杠
+// 返回: string 新字典
+func pdfDictDelete(dict string, key string) string {
+	if start, end, ok := pdfDictFind([]byte(dict), key); ok {
+		return dict[:start-len(key)-1] + dict[end:]
+	}
+	return dict
+}
+
+// pdfDictKeys 获取字典顶层键名
+// 入参: dict 字典
+// 返回: []string 键名, 不含斜杠
+func pdfDictKeys(dict string) []string {
+	b := []byte(dict)
+	i := pdfSkipSpace(b, 0)
+	if !bytes.HasPrefix(b[i:], []byte("<<")) {
+		return nil
+	}
+	var keys []string
+	for i += 2; ; {
+		i = pdfSkipSpace(b, i)
+		if i >= len(b) || bytes.HasPrefix(b[i:], []byte(">>")) || b[i] != '/' {
+			return keys
+		}
+		nameEnd := pdfSkipValue(b, i)
+		end := pdfSkipValue(b, nameEnd)
+		if end <= nameEnd {
+			return keys
+		}
+		keys = append(keys, dict[i+1:nameEnd])
+		i = end
+	}
+}
+
+// pdfDictFind 查找字典顶层键值位置
+// 入参: dict 字典, key 键名, 不含斜杠
+// 返回: int 值起始位置, int 值结束位置, bool 是否存在
+func pdfDictFind(dict []byte, key string) (int, int, bool) {
+	i := pdfSkipSpace(dict, 0)
+	if !bytes.HasPrefix(dict[i:], []byte("<<")) {
+		return 0, 0, false
+	}
+	i += 2
+	for {
+		i = pdfSkipSpace(dict, i)
+		if i >= len(dict) || bytes.HasPrefix(dict[i:], []byte(">>")) {
+			return 0, 0, false
+		}
+		nameEnd := pdfSkipValue(dict, i)
+		name := string(dict[i:nameEnd])
+		end := pdfSkipValue(dict, nameEnd)
+		if name == "/"+key {
+			return nameEnd, end, true
+		}
+		if end <= i {
+			return 0, 0, false
+		}
+		i = end
+	}
+}
+
+// pdfSkipSpace 跳过空白和注释
+// 入参: b 数据, i 起始位置
+// 返回: int 下一个非空白位置
+func pdfSkipSpace(b []byte, i int) int {
+	for i < len(b) {
+		switch b[i] {
+		case ' ', '\t', '\r', '\n', '\f', 0:
+			i++
+		case '%':
+			for i < len(b) && b[i] != '\n' && b[i] != '\r' {
+				i++
+			}
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// pdfSkipValue 跳过一个PDF值, 间接引用视为一个值
+// 入参: b 数据, i 起始位置
+// 返回: int 值结束位置
+func pdfSkipValue(b []byte, i int) int {
+	i = pdfSkipSpace(b, i)
+	if i >= len(b) {
+		return i
+	}
+	switch b[i] {
+	case '<':
+		if i+1 < len(b) && b[i+1] == '<' {
+			i += 2
+			for {
+				i = pdfSkipSpace(b, i)
+				if i >= len(b) {
+					return i
+				}
+				if bytes.HasPrefix(b[i:], []byte(">>")) {
+					return i + 2
+				}
+				next := pdfSkipValue(b, i)
+				if next <= i {
+					return len(b)
+				}
+				i = next
+			}
+		}
+		if end := bytes.IndexByte(b[i:], '>'); end >= 0 {
+			return i + end + 1
+		}
+		return len(b)
+	case '[':
+		i++
+		for {
+			i = pdfSkipSpace(b, i)
+			if i >= len(b) {
+				return i
+			}
+			if b[i] == ']' {
+				return i + 1
+			}
+			next := pdfSkipValue(b, i)
+			if next <= i {
+				return len(b)
+			}
+			i = next
+		}
+	case '(':
+		depth := 0
+		for ; i < len(b); i++ {
+			switch b[i] {
+			case '\\':
+				i++
+			case '(':
+				depth++
+			case ')':
+				depth--
+				if depth == 0 {
+					return i + 1
+				}
+			}
+		}
+		return i
+	case '/':
+		i++
+		for i < len(b) && !pdfDelimiter(b[i]) {
+			i++
+		}
+		return i
+	}
+	start := i
+	for i < len(b) && !pdfDelimiter(b[i]) {
+		i++
+	}
+	if i == start {
+		return i + 1
+	}
+	if _, err := strconv.Atoi(string(b[start:i])); err == nil {
+		j := pdfSkipSpace(b, i)
+		k := j
+		for k < len(b) && b[k] >= '0' && b[k] <= '9' {
+			k++
+		}
+		if k > j {
+			if l := pdfSkipSpace(b, k); l < len(b) && b[l] == 'R' && (l+1 == len(b) || pdfDelimiter(b[l+1])) {
+				return l + 1
+			}
+		}
+	}
+	return i
+}
+
+// pdfDelimiter 判断是否为PDF分隔符或空白
+// 入参: c 字符
+// 返回: bool 是否为分隔符
+func pdfDelimiter(c byte) bool {
+	switch c {
+	case ' ', '\t', '\r', '\n', '\f', 0, '(', ')', '<', '>', '[', ']', '{', '}', '/', '%':
+		return true
+	}
+	return false
+}
+
+// pdfArrayItems 拆分数组元素
+// 入参: value 数组
+// 返回: []string 数组元素, 非数组时为空
+func pdfArrayItems(value string) []string {
+	value = strings.TrimSpace(value)
+	if !strings.HasPrefix(value, "[") || !strings.HasSuffix(value, "]") {
+		return nil
+	}
+	b := []byte(value[1 : len(value)-1])
+	var items []string
+	for i := pdfSkipSpace(b, 0); i < len(b); i = pdfSkipSpace(b, i) {
+		next := pdfSkipValue(b, i)
+		if next <= i {
+			break
+		}
+		items = append(items, string(b[i:next]))
+		i = next
+	}
+	return items
+}
+
+// pdfRefNumber 解析间接引用对象号
+// 入参: value 间接引用
+// 返回: int 对象号, 无效时为0
+func pdfRefNumber(value string) int {
+	fields := strings.Fields(value)
+	if len(fields) != 3 || fields[2] != "R" {
+		return 0
+	}
+	num, _ := strconv.Atoi(fields[0])
+	return num
+}
+
+// pdfRefPattern 间接引用匹配
+var pdfRefPattern = regexp.MustCompile(`\b\d+\s+0\s+R\b`)
+
+// pdfRefNumbers 解析值中全部间接引用的对象号
+// 入参: value PDF值
+// 返回: []int 对象号
+func pdfRefNumbers(value string) []int {
+	refs := pdfRefPattern.FindAllString(value, -1)
+	nums := make([]int, 0, len(refs))
+	for _, ref := range refs {
+		nums = append(nums, pdfRefNumber(ref))
+	}
+	return nums
+}
+
+// pdfRef 生成间接引用
+// 入参: num 对象号
+// 返回: string 间接引用
+func pdfRef(num int) string {
+	return fmt.Sprintf("%d 0 R", num)
+}
+
+// pdfTextString 编码PDF文本字符串
+// ASCII文本使用字面字符串, 其他文本使用带BOM的UTF-16BE十六进制字符串
+// 入参: s 文本
+// 返回: string PDF字符串
+func pdfTextString(s string) string {
+	ascii := true
+	for _, c := range s {
+		if c < 0x20 || c >= 0x7F {
+			ascii = false
+			break
+		}
+	}
+	if ascii {
+		return pdfByteString(s)
+	}
+	var sb strings.Builder
+	sb.WriteString("<FEFF")
+	for _, u := range utf16.Encode([]rune(s)) {
+		fmt.Fprintf(&sb, "%04X", u)
+	}
+	sb.WriteString(">")
+	return sb.String()
+}
+
+// pdfByteString 编码PDF字面字符串
+// 入参: s 字节内容
+// 返回: string PDF字符串
+func pdfByteString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('(')
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '\\' || c == '(' || c == ')':
+			sb.WriteByte('\\')
+			sb.WriteByte(c)
+		case c < 0x20 || c >= 0x7F:
+			fmt.Fprintf(&sb, "\\%03o", c)
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	sb.WriteByte(')')
+	return sb.String()
+}
+
+// pdfNumber 格式化PDF数值
+// 入参: v 数值
+// 返回: string 保留三位小数的数值文本
+func pdfNumber(v float64) string {
+	v = math.Round(v*1000) / 1000
+	if v == 0 {
+		return "0"
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// pdfNameString 编码PDF名称
+// 入参: s 名称文本
+// 返回: string PDF名称, 含斜杠
+func pdfNameString(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('/')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c <= 0x20 || c >= 0x7F || c == '#' || pdfDelimiter(c) {
+			fmt.Fprintf(&sb, "#%02X", c)
+			continue
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
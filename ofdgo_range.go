@@ -0,0 +1,413 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	defaultRangeBlockSize   = 256 << 10 // 默认缓存块大小
+	defaultRangeCacheBlocks = 64        // 默认缓存块数量
+	defaultRangeReadAhead   = 4         // 默认单次请求块数量
+)
+
+// RangeFetcher 远程对象范围读取接口
+// 可基于HTTP范围请求或对象存储SDK实现
+type RangeFetcher interface {
+	// FetchRange 读取指定范围的数据
+	// 入参: ctx 上下文, off 起始偏移, length 读取长度
+	// 返回: []byte 数据, 长度须等于length, error 错误信息
+	FetchRange(ctx context.Context, off, length int64) ([]byte, error)
+}
+
+// HTTPRangeFetcher 基于HTTP范围请求的远程对象读取器
+// 适用于S3兼容对象存储的预签名地址等支持Range请求头的服务
+type HTTPRangeFetcher struct {
+	URL    string
+	Client *http.Client // 为nil时使用http.DefaultClient
+	Header http.Header  // 附加请求头, 如鉴权信息
+}
+
+// RangeStats 范围读取统计
+type RangeStats struct {
+	Requests int64 // 远程请求次数
+	Bytes    int64 // 远程读取字节数
+}
+
+// RangeReaderAt 带块缓存和预读的远程随机读取器
+// 实现io.ReaderAt, 可在多个协程中并发使用, 同一块的并发读取只请求一次
+type RangeReaderAt struct {
+	fetcher   RangeFetcher
+	size      int64
+	ctx       context.Context
+	blockSize int64
+	maxBlocks int
+	readAhead int
+	mu        sync.Mutex
+	blocks    map[int64]*rangeBlock
+	lru       *list.List
+	stats     RangeStats
+}
+
+// rangeBlock 缓存块
+type rangeBlock struct {
+	index int64
+	data  []byte
+	err   error
+	ready chan struct{}
+	elem  *list.Element
+}
+
+// rangeReaderOptions 远程读取器选项
+type rangeReaderOptions struct {
+	ctx       context.Context
+	client    *http.Client
+	header    http.Header
+	blockSize int
+	maxBlocks int
+	readAhead int
+}
+
+// RangeReaderOption 远程读取器配置选项
+type RangeReaderOption func(*rangeReaderOptions)
+
+// WithRangeContext 设置远程请求上下文
+// 入参: ctx 上下文
+// 返回: RangeReaderOption 远程读取器选项
+func WithRangeContext(ctx context.Context) RangeReaderOption {
+	return func(o *rangeReaderOptions) {
+		o.ctx = ctx
+	}
+}
+
+// WithRangeHTTPClient 设置HTTP客户端
+// 入参: client HTTP客户端
+// 返回: RangeReaderOption 远程读取器选项
+func WithRangeHTTPClient(client *http.Client) RangeReaderOption {
+	return func(o *rangeReaderOptions) {
+		o.client = client
+	}
+}
+
+// WithRangeHeader 添加HTTP请求头
+// 入参: key 请求头名称, value 请求头值
+// 返回: RangeReaderOption 远程读取器选项
+func WithRangeHeader(key, value string) RangeReaderOption {
+	return func(o *rangeReaderOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Add(key, value)
+	}
+}
+
+// WithRangeBlockSize 设置缓存块大小
+// 入参: size 缓存块字节数, 默认256KiB
+// 返回: RangeReaderOption 远程读取器选项
+func WithRangeBlockSize(size int) RangeReaderOption {
+	return func(o *rangeReaderOptions) {
+		o.blockSize = size
+	}
+}
+
+// WithRangeCache 设置缓存块数量
+// 入参: blocks 最多缓存的块数量, 默认64
+// 返回: RangeReaderOption 远程读取器选项
+func WithRangeCache(blocks int) RangeReaderOption {
+	return func(o *rangeReaderOptions) {
+		o.maxBlocks = blocks
+	}
+}
+
+// WithRangeReadAhead 设置预读块数量
+// 缓存未命中时单次请求连续读取的块数量, 包含当前块
+// 入参: blocks 预读块数量, 默认4
+// 返回: RangeReaderOption 远程读取器选项
+func WithRangeReadAhead(blocks int) RangeReaderOption {
+	return func(o *rangeReaderOptions) {
+		o.readAhead = blocks
+	}
+}
+
+// newRangeReaderOptions 创建远程读取器选项
+// 入参: opts 配置选项
+// 返回: rangeReaderOptions 远程读取器选项
+func newRangeReaderOptions(opts []RangeReaderOption) rangeReaderOptions {
+	o := rangeReaderOptions{
+		ctx:       context.Background(),
+		blockSize: defaultRangeBlockSize,
+		maxBlocks: defaultRangeCacheBlocks,
+		readAhead: defaultRangeReadAhead,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.ctx == nil {
+		o.ctx = context.Background()
+	}
+	o.blockSize = max(o.blockSize, 1)
+	o.readAhead = max(o.readAhead, 1)
+	o.maxBlocks = max(o.maxBlocks, o.readAhead)
+	return o
+}
+
+// NewRangeReaderAt 创建远程随机读取器
+// 入参: fetcher 范围读取接口, size 对象大小, opts 配置选项
+// 返回: *RangeReaderAt 远程随机读取器
+func NewRangeReaderAt(fetcher RangeFetcher, size int64, opts ...RangeReaderOption) *RangeReaderAt {
+	o := newRangeReaderOptions(opts)
+	return &RangeReaderAt{
+		fetcher:   fetcher,
+		size:      size,
+		ctx:       o.ctx,
+		blockSize: int64(o.blockSize),
+		maxBlocks: o.maxBlocks,
+		readAhead: o.readAhead,
+		blocks:    make(map[int64]*rangeBlock),
+		lru:       list.New(),
+	}
+}
+
+// NewHTTPRangeReaderAt 创建基于HTTP范围请求的远程随机读取器
+// 首次请求读取对象末尾一个缓存块, 同时获取对象大小和zip中央目录
+// 入参: url 对象地址, opts 配置选项
+// 返回: *RangeReaderAt 远程随机读取器, error 错误信息
+func NewHTTPRangeReaderAt(url string, opts ...RangeReaderOption) (*RangeReaderAt, error) {
+	o := newRangeReaderOptions(opts)
+	fetcher := &HTTPRangeFetcher{URL: url, Client: o.client, Header: o.header}
+	tail, size, err := fetcher.fetchTail(o.ctx, int64(o.blockSize))
+	if err != nil {
+		return nil, err
+	}
+	r := NewRangeReaderAt(fetcher, size, opts...)
+	r.stats = RangeStats{Requests: 1, Bytes: int64(len(tail))}
+	r.seed(size-int64(len(tail)), tail)
+	return r, nil
+}
+
+// Size 获取对象大小
+// 返回: int64 对象大小
+func (r *RangeReaderAt) Size() int64 {
+	return r.size
+}
+
+// Stats 获取范围读取统计
+// 返回: RangeStats 范围读取统计
+func (r *RangeReaderAt) Stats() RangeStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
+
+// ReadAt 读取指定偏移的数据
+// 入参: p 缓冲区, off 起始偏移
+// 返回: int 读取长度, error 错误信息
+func (r *RangeReaderAt) ReadAt(p []byte, off int64) (int, error) {
+	if off < 0 {
+		return 0, fmt.Errorf("negative offset: %d", off)
+	}
+	n := 0
+	for n < len(p) && off < r.size {
+		index := off / r.blockSize
+		data, err := r.block(index)
+		if err != nil {
+			return n, err
+		}
+		c := copy(p[n:], data[off-index*r.blockSize:])
+		n += c
+		off += int64(c)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// seed 将已读取的数据写入缓存
+// 仅缓存完整覆盖的块
+// 入参: off 数据起始偏移, data 数据
+func (r *RangeReaderAt) seed(off int64, data []byte) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	first := (off + r.blockSize - 1) / r.blockSize
+	for index := first; index*r.blockSize < r.size && len(r.blocks) < r.maxBlocks; index++ {
+		start := index*r.blockSize - off
+		end := min((index+1)*r.blockSize, r.size) - off
+		b := &rangeBlock{index: index, data: bytes.Clone(data[start:end]), ready: make(chan struct{})}
+		close(b.ready)
+		b.elem = r.lru.PushFront(b)
+		r.blocks[index] = b
+	}
+}
+
+// block 获取缓存块, 未命中时连同后续未缓存的块一次请求
+// 入参: index 块索引
+// 返回: []byte 块数据, error 错误信息
+func (r *RangeReaderAt) block(index int64) ([]byte, error) {
+	r.mu.Lock()
+	if b, ok := r.blocks[index]; ok {
+		r.lru.MoveToFront(b.elem)
+		r.mu.Unlock()
+		<-b.ready
+		return b.data, b.err
+	}
+	count := int64(1)
+	for count < int64(r.readAhead) && (index+count)*r.blockSize < r.size {
+		if _, ok := r.blocks[index+count]; ok {
+			break
+		}
+		count++
+	}
+	claimed := make([]*rangeBlock, count)
+	for i := range claimed {
+		b := &rangeBlock{index: index + int64(i), ready: make(chan struct{})}
+		b.elem = r.lru.PushFront(b)
+		r.blocks[b.index] = b
+		claimed[i] = b
+	}
+	r.mu.Unlock()
+	off := index * r.blockSize
+	length := min((index+count)*r.blockSize, r.size) - off
+	data, err := r.fetcher.FetchRange(r.ctx, off, length)
+	if err == nil && int64(len(data)) != length {
+		err = fmt.Errorf("range response length %d, want %d", len(data), length)
+	}
+	r.mu.Lock()
+	r.stats.Requests++
+	r.stats.Bytes += int64(len(data))
+	for i, b := range claimed {
+		if err != nil {
+			b.err = err
+			r.remove(b)
+		} else {
+			start := int64(i) * r.blockSize
+			b.data = bytes.Clone(data[start:min(start+r.blockSize, length)])
+		}
+		close(b.ready)
+	}
+	for r.lru.Len() > r.maxBlocks {
+		r.remove(r.lru.Back().Value.(*rangeBlock))
+	}
+	r.mu.Unlock()
+	return claimed[0].data, claimed[0].err
+}
+
+// remove 移除缓存块
+// 入参: b 缓存块
+func (r *RangeReaderAt) remove(b *rangeBlock) {
+	if r.blocks[b.index] == b {
+		delete(r.blocks, b.index)
+		r.lru.Remove(b.elem)
+	}
+}
+
+// FetchRange 读取指定范围的数据
+// 入参: ctx 上下文, off 起始偏移, length 读取长度
+// 返回: []byte 数据, error 错误信息
+func (f *HTTPRangeFetcher) FetchRange(ctx context.Context, off, length int64) ([]byte, error) {
+	if length <= 0 {
+		return nil, nil
+	}
+	resp, err := f.do(ctx, fmt.Sprintf("bytes=%d-%d", off, off+length-1))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusPartialContent {
+		return nil, fmt.Errorf("range request not supported: %s", resp.Status)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, length+1))
+}
+
+// fetchTail 读取对象末尾数据并获取对象大小
+// 入参: ctx 上下文, length 读取长度
+// 返回: []byte 末尾数据, int64 对象大小, error 错误信息
+func (f *HTTPRangeFetcher) fetchTail(ctx context.Context, length int64) ([]byte, int64, error) {
+	resp, err := f.do(ctx, fmt.Sprintf("bytes=-%d", length))
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusPartialContent:
+		size, err := parseContentRangeSize(resp.Header.Get("Content-Range"))
+		if err != nil {
+			return nil, 0, err
+		}
+		data, err := io.ReadAll(io.LimitReader(resp.Body, length+1))
+		if err != nil {
+			return nil, 0, err
+		}
+		if int64(len(data)) != min(length, size) {
+			return nil, 0, fmt.Errorf("range response length %d, want %d", len(data), min(length, size))
+		}
+		return data, size, nil
+	case http.StatusOK:
+		data, err := io.ReadAll(io.LimitReader(resp.Body, length+1))
+		if err != nil {
+			return nil, 0, err
+		}
+		if int64(len(data)) > length {
+			return nil, 0, fmt.Errorf("range request not supported: %s", resp.Status)
+		}
+		return data, int64(len(data)), nil
+	}
+	return nil, 0, fmt.Errorf("range request failed: %s", resp.Status)
+}
+
+// do 发送范围请求
+// 入参: ctx 上下文, byteRange Range请求头
+// 返回: *http.Response 响应, error 错误信息
+func (f *HTTPRangeFetcher) do(ctx context.Context, byteRange string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, f.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range f.Header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	req.Header.Set("Range", byteRange)
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return client.Do(req)
+}
+
+// parseContentRangeSize 解析Content-Range中的对象大小
+// 入参: value Content-Range响应头
+// 返回: int64 对象大小, error 错误信息
+func parseContentRangeSize(value string) (int64, error) {
+	_, total, ok := strings.Cut(value, "/")
+	if !ok || !strings.HasPrefix(value, "bytes ") {
+		return 0, fmt.Errorf("invalid content range: %q", value)
+	}
+	size, err := strconv.ParseInt(strings.TrimSpace(total), 10, 64)
+	if err != nil || size < 0 {
+		return 0, fmt.Errorf("invalid content range: %q", value)
+	}
+	return size, nil
+}
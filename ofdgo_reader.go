@@ -19,15 +19,21 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"io/fs"
 	"path"
+	"sort"
 	"strings"
+	"sync"
 )
 
 // Reader OFD文件阅读器
+// 初始化完成后可在多个协程中并发使用, 文档结构和资源表在首次访问时加锁加载
+// 由NewReaderFS或目录创建时Zip为nil
 type Reader struct {
 	Path                      string
 	Zip                       *zip.Reader
 	Closer                    io.Closer
+	source                    *io.SectionReader
 	OFD                       *OFD
 	RootDir                   string
 	ResMap                    map[string]string
@@ -37,8 +43,13 @@ type Reader struct {
 	doc                       *Document
 	Stamps                    map[string][]Stamp
 	Annots                    map[string][]Annotation
-	fileIndex                 map[string]*zip.File
-	fileIndexFold             map[string]*zip.File
+	entries                   []*packageEntry
+	fileIndex                 map[string]*packageEntry
+	fileIndexFold             map[string]*packageEntry
+	limits                    Limits
+	diagnostics               *Diagnostics
+	encrypted                 bool
+	mu                        sync.Mutex
 }
 
 // Close 关闭阅读器
@@ -53,16 +64,23 @@ func (r *Reader) Close() error {
 // initRoot 读取根节点信息
 // 返回: error 错误信息
 func (r *Reader) initRoot() error {
-	r.fileIndex = make(map[string]*zip.File)
-	r.fileIndexFold = make(map[string]*zip.File)
-	for _, f := range r.Zip.File {
-		name := cleanPackagePath(f.Name)
+	if r.diagnostics == nil {
+		r.diagnostics = NewDiagnostics()
+	}
+	r.fileIndex = make(map[string]*packageEntry)
+	r.fileIndexFold = make(map[string]*packageEntry)
+	for _, f := range r.entries {
+		name := cleanPackagePath(f.name)
 		r.fileIndex[name] = f
 		fold := strings.ToLower(name)
 		if _, ok := r.fileIndexFold[fold]; !ok {
 			r.fileIndexFold[fold] = f
 		}
 	}
+	if err := r.limits.checkPackage(r.entries); err != nil {
+		return err
+	}
+	_, r.encrypted = r.packageFile(encryptListPath)
 	data, err := r.readFile("OFD.xml")
 	if err != nil {
 		return fmt.Errorf("failed to read ofd.xml: %w", err)
@@ -85,7 +103,7 @@ func (r *Reader) initRoot() error {
 func (r *Reader) readFile(name string) ([]byte, error) {
 	name = cleanPackagePath(name)
 	if f, ok := r.packageFile(name); ok {
-		return readZipFile(f)
+		return r.readEntry(name, f)
 	}
 	return nil, fmt.Errorf("file not found: %s", name)
 }
@@ -96,7 +114,13 @@ func (r *Reader) readFile(name string) ([]byte, error) {
 func (r *Reader) openFile(name string) (io.ReadCloser, error) {
 	name = cleanPackagePath(name)
 	if f, ok := r.packageFile(name); ok {
-		return f.Open()
+		if err := r.checkEncrypted(cleanPackagePath(f.name)); err != nil {
+			return nil, err
+		}
+		if err := r.limits.checkEntry(f); err != nil {
+			return nil, err
+		}
+		return f.open()
 	}
 	return nil, fmt.Errorf("file not found: %s", name)
 }
@@ -112,8 +136,8 @@ func cleanPackagePath(name string) string {
 
 // packageFile 获取包内文件
 // 入参: name 文件路径
-// 返回: *zip.File 压缩包文件, bool 是否存在
-func (r *Reader) packageFile(name string) (*zip.File, bool) {
+// 返回: *packageEntry 包内文件, bool 是否存在
+func (r *Reader) packageFile(name string) (*packageEntry, bool) {
 	if f, ok := r.fileIndex[name]; ok {
 		return f, true
 	}
@@ -123,21 +147,136 @@ func (r *Reader) packageFile(name string) (*zip.File, bool) {
 	return nil, false
 }
 
-// readZipFile 读取zip文件内容
-// 入参: f zip文件对象
+// packageEntries 获取包内文件列表
+// 返回: []string 按名称排序的包内文件路径
+func (r *Reader) packageEntries() []string {
+	entries := make([]string, 0, len(r.fileIndex))
+	for name, f := range r.fileIndex {
+		if !f.isDir() {
+			entries = append(entries, name)
+		}
+	}
+	sort.Strings(entries)
+	return entries
+}
+
+// packageEntry 包内文件
+// 来自zip压缩包或文件系统, 文件系统中的文件按声明大小读取
+type packageEntry struct {
+	name           string
+	zip            *zip.File
+	fsys           fs.FS
+	size           uint64
+	compressedSize uint64
+}
+
+// zipEntries 获取压缩包文件列表
+// 入参: files 压缩包文件
+// 返回: []*packageEntry 包内文件列表
+func zipEntries(files []*zip.File) []*packageEntry {
+	entries := make([]*packageEntry, 0, len(files))
+	for _, f := range files {
+		entries = append(entries, &packageEntry{
+			name:           f.Name,
+			zip:            f,
+			size:           f.UncompressedSize64,
+			compressedSize: f.CompressedSize64,
+		})
+	}
+	return entries
+}
+
+// fsEntries 获取文件系统中的文件列表
+// 入参: fsys 文件系统
+// 返回: []*packageEntry 包内文件列表, error 错误信息
+func fsEntries(fsys fs.FS) ([]*packageEntry, error) {
+	var entries []*packageEntry
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size := uint64(max(info.Size(), 0))
+		entries = append(entries, &packageEntry{name: name, fsys: fsys, size: size, compressedSize: size})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// open 打开包内文件的原始数据流
+// 返回: io.ReadCloser 数据流, error 错误信息
+func (e *packageEntry) open() (io.ReadCloser, error) {
+	if e.zip != nil {
+		return e.zip.Open()
+	}
+	return e.fsys.Open(e.name)
+}
+
+// isDir 判断是否为目录
+// 返回: bool 是否为目录
+func (e *packageEntry) isDir() bool {
+	return e.zip != nil && e.zip.FileInfo().IsDir()
+}
+
+// readPackageData 读取包内文件的原始数据
+// 入参: f 包内文件
 // 返回: []byte 文件内容, error 错误信息
-func readZipFile(f *zip.File) ([]byte, error) {
-	rc, err := f.Open()
+func readPackageData(f *packageEntry) ([]byte, error) {
+	rc, err := f.open()
 	if err != nil {
 		return nil, err
 	}
 	defer rc.Close()
-	return io.ReadAll(rc)
+	if f.zip != nil {
+		return io.ReadAll(rc)
+	}
+	data, err := io.ReadAll(io.LimitReader(rc, int64(min(f.size, 1<<62))+1))
+	if err != nil {
+		return nil, err
+	}
+	if uint64(len(data)) != f.size {
+		return nil, fmt.Errorf("file size changed: %s", f.name)
+	}
+	return data, nil
+}
+
+// copyPackageEntry 复制包内文件到压缩包
+// zip文件按原始压缩数据复制
+// 入参: zw 压缩包写入器, f 包内文件
+// 返回: error 错误信息
+func copyPackageEntry(zw *zip.Writer, f *packageEntry) error {
+	if f.zip != nil {
+		return zw.Copy(f.zip)
+	}
+	data, err := readPackageData(f)
+	if err != nil {
+		return err
+	}
+	return writeZipEntry(zw, cleanPackagePath(f.name), data)
+}
+
+// loadedDoc 获取已加载的主文档结构
+// 返回: *Document 文档结构, 尚未加载时为nil
+func (r *Reader) loadedDoc() *Document {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.doc
 }
 
 // Doc 获取主文档结构
 // 返回: *Document 文档结构, error 错误信息
 func (r *Reader) Doc() (*Document, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	if r.doc != nil {
 		return r.doc, nil
 	}
@@ -165,8 +304,12 @@ func (r *Reader) Doc() (*Document, error) {
 		r.loadRes(doc.CommonData.PublicRes)
 	}
 	r.doc = &doc
-	_ = r.parseAnnotations(&doc)
-	_ = r.parseSignatures(&doc)
+	if err := r.parseAnnotations(&doc); err != nil {
+		r.diagnose(DiagnosticWarning, r.ResPath(doc.Annotations), "", "failed to parse annotations: %v", err)
+	}
+	if err := r.parseSignatures(&doc); err != nil {
+		r.diagnose(DiagnosticWarning, r.ResPath(doc.Signatures), "", "failed to parse signatures: %v", err)
+	}
 	return r.doc, nil
 }
 
@@ -179,10 +322,12 @@ func (r *Reader) loadRes(resPath string) {
 	fullPath := r.ResPath(resPath)
 	data, err := r.readFile(fullPath)
 	if err != nil {
+		r.diagnose(DiagnosticError, fullPath, "", "failed to read resource file: %v", err)
 		return
 	}
 	var res Res
 	if err := xml.Unmarshal(data, &res); err != nil {
+		r.diagnose(DiagnosticError, fullPath, "", "failed to unmarshal resource file: %v", err)
 		return
 	}
 	baseLoc := res.BaseLoc
@@ -245,6 +390,7 @@ func (r *Reader) PageContent(page Page) (*PageContent, error) {
 		return nil, fmt.Errorf("failed to unmarshal page content: %w", err)
 	}
 	content.ID = page.ID
+	content.Path = fullPath
 	return &content, nil
 }
 
@@ -368,6 +514,8 @@ func (r *Reader) Attachments() ([]Attachment, error) {
 	if err != nil {
 		return nil, err
 	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	if strings.TrimSpace(doc.Attachments.Path) != "" && doc.Attachments.Attachment == nil {
 		var attachments Attachments
 		partPath, err := r.readDocumentPart(doc.Attachments.Path, &attachments)
@@ -393,6 +541,8 @@ func (r *Reader) CustomTags() ([]CustomTag, error) {
 	if err != nil {
 		return nil, err
 	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	if strings.TrimSpace(doc.CustomTags.Path) != "" && doc.CustomTags.CustomTag == nil {
 		var customTags CustomTags
 		partPath, err := r.readDocumentPart(doc.CustomTags.Path, &customTags)
@@ -432,6 +582,8 @@ func (r *Reader) Extensions() ([]Extension, error) {
 	if err != nil {
 		return nil, err
 	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
 	if strings.TrimSpace(doc.Extensions.Path) != "" && doc.Extensions.Extension == nil {
 		var extensions Extensions
 		partPath, err := r.readDocumentPart(doc.Extensions.Path, &extensions)
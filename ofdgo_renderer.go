@@ -15,6 +15,7 @@
 package ofdgo
 
 import (
+	"context"
 	"fmt"
 	"io/fs"
 
@@ -22,6 +23,8 @@ import (
 )
 
 // Renderer 渲染器实现
+// 由NewRenderer创建的渲染器可在多个协程中并发渲染同一文档的不同页面
+// 字体、字形和模板缓存按渲染独占使用, 并发渲染时各自持有一份缓存
 type Renderer struct {
 	Reader                *Reader
 	DPI                   float64
@@ -38,9 +41,33 @@ type Renderer struct {
 	fontSourceUsed        map[string]fontSource
 	textGlyphPathCache    map[textGlyphPathCacheKey]textGlyphPathCacheValue
 	templatePageCache     map[string]*PageContent
+	textLayerFonts        map[*canvas.Font]bool
 	fontDirs              []string
 	fontFS                []fs.FS
 	decodeImages          bool
+	pdfTextLayer          bool
+	pdfa                  PDFAConformance
+	pdfSignature          *pdfSignatureStatus
+	svgVector             bool
+	svgFonts              SVGFontHandler
+	svg                   *svgWriter
+	svgBookmarks          map[string]Dest
+	htmlBackground        HTMLBackground
+	htmlLang              string
+	htmlLabels            HTMLLabels
+	textLayer             bool
+	textLayerGlyphs       *textLayerGlyphFonts
+	fontText              bool
+	pdfAnnotations        bool
+	ctx                   context.Context
+	limits                Limits
+	budget                *renderBudget
+	diagnostics           *Diagnostics
+	part                  string
+	workers               int
+	progress              ProgressFunc
+	pageErrorPolicy       PageErrorPolicy
+	states                *renderStatePool
 }
 
 // RendererOption 渲染器配置选项
@@ -83,8 +110,10 @@ func (r *Renderer) GetPageBox(page *PageContent) (Box, error) {
 	if boxStr == "" {
 		boxStr = page.Area.ContentBox
 	}
-	if boxStr == "" && r.Reader.doc != nil {
-		boxStr = r.Reader.doc.CommonData.PageArea.PhysicalBox
+	if boxStr == "" && r.Reader != nil {
+		if doc := r.Reader.loadedDoc(); doc != nil {
+			boxStr = doc.CommonData.PageArea.PhysicalBox
+		}
 	}
 	if boxStr == "" {
 		boxStr = "0 0 210 297"
@@ -105,6 +134,16 @@ func (r *Renderer) RenderPageToContext(ctx *canvas.Context, page *PageContent) e
 // 入参: ctx 画布上下文, page 页面内容, drawBackground 是否绘制页面背景
 // 返回: error 错误信息
 func (r *Renderer) renderPageToContext(ctx *canvas.Context, page *PageContent, drawBackground bool) error {
+	if r.budget == nil {
+		if r.Reader != nil {
+			_, _ = r.Reader.Doc()
+		}
+		renderer, release := r.acquireState()
+		defer release()
+		renderer.budget = newRenderBudget(r.ctx, r.limits)
+		renderer.part = page.Path
+		return renderer.renderPageToContext(ctx, page, drawBackground)
+	}
 	box, err := r.GetPageBox(page)
 	if err != nil {
 		return err
@@ -114,19 +153,23 @@ func (r *Renderer) renderPageToContext(ctx *canvas.Context, page *PageContent, d
 		ctx.SetFillColor(canvas.White)
 		ctx.DrawPath(0, 0, canvas.Rectangle(box.W, box.H))
 	}
-	if len(page.Template) > 0 && r.Reader.doc != nil {
+	templates := len(page.Template) > 0 && r.Reader.loadedDoc() != nil
+	if templates {
 		for _, tplRef := range page.Template {
 			if tplRef.ZOrder != "Foreground" {
 				r.renderTemplate(ctx, tplRef.TemplateID, pageH)
 			}
 		}
 	}
+	if !r.budget.check() {
+		return r.budget.err
+	}
 	if page.Content.Layer != nil {
 		for _, layer := range page.Content.Layer {
 			r.renderLayer(ctx, layer, pageH, nil, nil, 0, nil)
 		}
 	}
-	if len(page.Template) > 0 && r.Reader.doc != nil {
+	if templates {
 		for _, tplRef := range page.Template {
 			if tplRef.ZOrder == "Foreground" {
 				r.renderTemplate(ctx, tplRef.TemplateID, pageH)
@@ -141,7 +184,7 @@ func (r *Renderer) renderPageToContext(ctx *canvas.Context, page *PageContent, d
 			r.renderStamp(ctx, stamp, pageH)
 		}
 	}
-	return nil
+	return r.budget.err
 }
 
 // RenderPageByIndex 按索引渲染页面
@@ -182,7 +225,12 @@ func (r *Renderer) childRenderer(reader *Reader) *Renderer {
 	if len(r.fontFS) > 0 {
 		opts = append(opts, WithFontFS(r.fontFS...))
 	}
+	opts = append(opts, WithRenderLimits(r.limits))
 	renderer := NewRenderer(reader, opts...)
 	renderer.decodeImages = r.decodeImages
+	renderer.textLayer = r.textLayer
+	renderer.textLayerGlyphs = r.textLayerGlyphs
+	renderer.budget = r.budget
+	renderer.ctx = r.ctx
 	return renderer
 }
@@ -0,0 +1,152 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/tdewolff/canvas"
+)
+
+// ExportStage 整文档导出阶段
+type ExportStage string
+
+const (
+	ExportStageRead   ExportStage = "read"   // 读取页面内容
+	ExportStageRender ExportStage = "render" // 渲染页面
+	ExportStageWrite  ExportStage = "write"  // 写出页面
+)
+
+// ExportProgress 整文档导出进度
+type ExportProgress struct {
+	Stage ExportStage
+	Index int // 当前页面索引
+	Done  int // 当前阶段已完成页数
+	Total int // 页面总数
+}
+
+// ProgressFunc 导出进度回调, 在调用导出方法的协程中串行执行
+type ProgressFunc func(progress ExportProgress)
+
+// PageErrorPolicy 整文档导出的页面错误处理策略
+type PageErrorPolicy int
+
+const (
+	PageErrorFail        PageErrorPolicy = iota // 遇到页面错误立即终止导出
+	PageErrorSkip                               // 跳过出错页面
+	PageErrorPlaceholder                        // 以显示错误信息的占位页替换出错页面
+)
+
+// PageError 页面导出错误
+type PageError struct {
+	Index int
+	Stage ExportStage
+	Err   error
+}
+
+// Error 获取错误描述
+// 返回: string 错误描述
+func (e *PageError) Error() string {
+	return fmt.Sprintf("failed to %s page %d: %v", e.Stage, e.Index+1, e.Err)
+}
+
+// Unwrap 获取原始错误
+// 返回: error 原始错误
+func (e *PageError) Unwrap() error {
+	return e.Err
+}
+
+// ExportSummary 整文档导出结果摘要
+type ExportSummary struct {
+	Total    int          // 页面总数
+	Rendered int          // 成功渲染页数
+	Failed   []*PageError // 被跳过或替换为占位页的页面, 按页面索引排序
+}
+
+// WithProgress 设置整文档导出进度回调
+// 入参: fn 进度回调
+// 返回: RendererOption 渲染选项
+func WithProgress(fn ProgressFunc) RendererOption {
+	return func(r *Renderer) {
+		r.progress = fn
+	}
+}
+
+// WithPageErrorPolicy 设置整文档导出的页面错误处理策略
+// 上下文取消和超时始终终止导出
+// 入参: policy 页面错误处理策略
+// 返回: RendererOption 渲染选项
+func WithPageErrorPolicy(policy PageErrorPolicy) RendererOption {
+	return func(r *Renderer) {
+		r.pageErrorPolicy = policy
+	}
+}
+
+// reportProgress 报告导出进度
+// 入参: stage 导出阶段, index 页面索引, done 已完成页数, total 页面总数
+func (r *Renderer) reportProgress(stage ExportStage, index, done, total int) {
+	if r.progress != nil {
+		r.progress(ExportProgress{Stage: stage, Index: index, Done: done, Total: total})
+	}
+}
+
+// pageFailed 按错误处理策略处理页面错误
+// 入参: summary 导出结果摘要, err 页面错误
+// 返回: bool 是否插入占位页, error 需要终止导出时的错误
+func (r *Renderer) pageFailed(summary *ExportSummary, err error) (bool, error) {
+	var pageErr *PageError
+	if r.pageErrorPolicy == PageErrorFail || !errors.As(err, &pageErr) {
+		return false, err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false, err
+	}
+	summary.Failed = append(summary.Failed, pageErr)
+	return r.pageErrorPolicy == PageErrorPlaceholder, nil
+}
+
+// finish 整理导出结果摘要
+// 入参: summary 导出结果摘要
+func (summary *ExportSummary) finish() {
+	sort.SliceStable(summary.Failed, func(i, j int) bool {
+		return summary.Failed[i].Index < summary.Failed[j].Index
+	})
+}
+
+// placeholderCanvas 创建页面错误占位页
+// 入参: box 页面区域, err 页面错误
+// 返回: *canvas.Canvas 占位页画布
+func (r *Renderer) placeholderCanvas(box Box, err error) *canvas.Canvas {
+	c := canvas.New(box.W, box.H)
+	ctx := canvas.NewContext(c)
+	ctx.SetFillColor(canvas.White)
+	ctx.DrawPath(0, 0, canvas.Rectangle(box.W, box.H))
+	margin := min(box.W, box.H) * 0.05
+	ctx.SetFillColor(canvas.Transparent)
+	ctx.SetStrokeColor(canvas.Red)
+	ctx.SetStrokeWidth(0.5)
+	ctx.DrawPath(margin, margin, canvas.Rectangle(box.W-2*margin, box.H-2*margin))
+	renderer, release := r.acquireState()
+	defer release()
+	if ff := renderer.signatureReportFont(); ff != nil {
+		face := ff.Face(12, canvas.Red, canvas.FontRegular, canvas.FontNormal)
+		text := canvas.NewTextBox(face, err.Error(), box.W-4*margin, box.H-4*margin, canvas.Left, canvas.Top, nil)
+		ctx.DrawText(2*margin, box.H-2*margin, text)
+	}
+	return c
+}
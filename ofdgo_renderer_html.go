@@ -0,0 +1,580 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"html"
+	"image/png"
+	"io"
+	"math"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/tdewolff/canvas"
+	"github.com/tdewolff/canvas/renderers/rasterizer"
+	"github.com/tdewolff/canvas/renderers/svg"
+)
+
+// htmlPxPerMM HTML导出的CSS像素与毫米换算比例
+const htmlPxPerMM = 96 / 25.4
+
+// htmlStyle HTML导出样式表
+const htmlStyle = `body{margin:0;display:flex;height:100vh;background:#e5e5e5;font-family:sans-serif}
+nav{flex:none;width:260px;overflow:auto;box-sizing:border-box;padding:8px 12px;background:#fff;border-right:1px solid #ccc;font-size:14px}
+nav h2{margin:8px 0;font-size:14px}
+nav ul{margin:0;padding-left:16px;list-style:none}
+nav>ul{padding-left:0}
+nav li{margin:4px 0}
+nav a{color:#222;text-decoration:none}
+nav a:hover{text-decoration:underline}
+main{flex:1;overflow:auto}
+.ofd-page{position:relative;margin:16px auto;overflow:hidden;background:#fff;box-shadow:0 1px 4px rgba(0,0,0,.3)}
+.ofd-page>img{position:absolute;left:0;top:0;width:100%;height:100%;user-select:none;pointer-events:none}
+.ofd-text span{position:absolute;left:0;top:0;transform-origin:0 0;white-space:pre;line-height:1;color:transparent;font-family:sans-serif}
+.ofd-text span::selection{background:rgba(0,100,255,.3)}
+.ofd-link{position:absolute;display:block}
+.ofd-dest{position:absolute;width:0;height:0}
+@media print{nav{display:none}main{overflow:visible}.ofd-page{margin:0;box-shadow:none;break-after:page}}
+`
+
+// htmlScript HTML导出脚本, 按字形总宽度横向缩放文本层, 使浏览器字体下的选区与字形对齐
+const htmlScript = `const spans=[...document.querySelectorAll(".ofd-text span")],widths=spans.map(s=>s.offsetWidth);
+spans.forEach((s,i)=>{if(widths[i]>0)s.style.transform+=" scaleX("+s.dataset.w/widths[i]+")"});
+`
+
+// htmlURISchemes HTML导出允许的外部链接协议
+var htmlURISchemes = map[string]bool{"http": true, "https": true, "mailto": true, "ftp": true, "tel": true}
+
+// HTMLBackground HTML导出页面背景格式
+type HTMLBackground int
+
+const (
+	HTMLBackgroundSVG HTMLBackground = iota // SVG矢量背景
+	HTMLBackgroundPNG                       // 按渲染DPI栅格化的PNG背景
+)
+
+// WithHTMLBackground 设置HTML导出的页面背景格式
+// 背景包含页面全部图形, 文本按字形轮廓绘制, 默认使用SVG
+// 入参: background 背景格式
+// 返回: RendererOption 渲染选项
+func WithHTMLBackground(background HTMLBackground) RendererOption {
+	return func(r *Renderer) {
+		r.htmlBackground = background
+	}
+}
+
+// htmlDefaultLang HTML导出默认语言
+const htmlDefaultLang = "zh-CN"
+
+// HTMLLabels HTML导出导航区标题, 字段为空时按导出语言使用默认标题
+type HTMLLabels struct {
+	Outline     string // 大纲标题
+	Attachments string // 附件标题
+}
+
+// WithHTMLLanguage 设置HTML导出的文档语言
+// 写入html元素的lang属性, 中文以外的语言默认使用英文导航标题, 默认为zh-CN
+// 入参: lang BCP 47语言标记
+// 返回: RendererOption 渲染选项
+func WithHTMLLanguage(lang string) RendererOption {
+	return func(r *Renderer) {
+		r.htmlLang = strings.TrimSpace(lang)
+	}
+}
+
+// WithHTMLLabels 设置HTML导出的导航区标题
+// 入参: labels 导航区标题
+// 返回: RendererOption 渲染选项
+func WithHTMLLabels(labels HTMLLabels) RendererOption {
+	return func(r *Renderer) {
+		r.htmlLabels = labels
+	}
+}
+
+// htmlLanguage 获取HTML导出语言和导航区标题
+// 返回: string 语言标记, HTMLLabels 导航区标题
+func (r *Renderer) htmlLanguage() (string, HTMLLabels) {
+	lang := r.htmlLang
+	if lang == "" {
+		lang = htmlDefaultLang
+	}
+	labels := HTMLLabels{Outline: "Contents", Attachments: "Attachments"}
+	if primary, _, _ := strings.Cut(strings.ToLower(lang), "-"); primary == "zh" {
+		labels = HTMLLabels{Outline: "目录", Attachments: "附件"}
+	}
+	if r.htmlLabels.Outline != "" {
+		labels.Outline = r.htmlLabels.Outline
+	}
+	if r.htmlLabels.Attachments != "" {
+		labels.Attachments = r.htmlLabels.Attachments
+	}
+	return lang, labels
+}
+
+// htmlOutput HTML导出资源输出
+type htmlOutput struct {
+	dir string
+}
+
+// asset 写出资源并获取引用地址
+// 单文件导出时资源以data URI内嵌, 目录导出时写入目录下的相对路径
+// 入参: name 相对路径, mediaType 媒体类型, data 资源数据
+// 返回: string 引用地址, error 错误信息
+func (o *htmlOutput) asset(name, mediaType string, data []byte) (string, error) {
+	if o.dir == "" {
+		return "data:" + mediaType + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+	}
+	target := filepath.Join(o.dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(target, data, 0o644); err != nil {
+		return "", err
+	}
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/"), nil
+}
+
+// htmlText 文本层文本
+type htmlText struct {
+	Text   string
+	Size   float64
+	Ascent float64
+	Width  float64
+	M      canvas.Matrix
+}
+
+// htmlPage HTML页面内容
+type htmlPage struct {
+	Background []byte
+	MediaType  string
+	Texts      []htmlText
+}
+
+// htmlAttachment HTML导出附件
+type htmlAttachment struct {
+	Name string
+	URL  string
+}
+
+// htmlLayer 拆分页面背景和文本层的渲染器
+// 文本按字形轮廓绘制到背景, 同时记录到文本层, 文本层专用的透明文本只记录不绘制
+type htmlLayer struct {
+	canvas.Renderer
+	texts []htmlText
+}
+
+// RenderText 渲染文本
+// 横向缩放折算到文本宽度中, 由页面脚本按宽度统一缩放
+// 入参: text 文本, m 变换矩阵
+func (l *htmlLayer) RenderText(text *canvas.Text, m canvas.Matrix) {
+	layerOnly := true
+	text.WalkSpans(func(x, y float64, span canvas.TextSpan) {
+		if !span.IsText() || span.Face.Fill.Color != textLayerFill {
+			layerOnly = false
+		}
+		if !span.IsText() || strings.TrimSpace(span.Text) == "" {
+			return
+		}
+		item := htmlText{
+			Text:   span.Text,
+			Size:   span.Face.Size,
+			Ascent: span.Face.Metrics().Ascent,
+			Width:  span.Width,
+			M:      m.Translate(x, y),
+		}
+		if ratio := math.Hypot(item.M[0][0], item.M[1][0]) / math.Hypot(item.M[0][1], item.M[1][1]); ratio > 0 && !math.IsInf(ratio, 0) {
+			item.M = item.M.Scale(1/ratio, 1)
+			item.Width *= ratio
+		}
+		l.add(item)
+	})
+	if !layerOnly {
+		text.RenderTo(l.Renderer, m, canvas.DefaultResolution)
+	}
+}
+
+// add 添加文本层文本
+// 与前一文本同一基线且首尾相接的文本合并, 间距较大时以空格分隔
+// 入参: item 文本层文本
+func (l *htmlLayer) add(item htmlText) {
+	if n := len(l.texts); n > 0 {
+		prev := &l.texts[n-1]
+		if prev.Size == item.Size && htmlSameLinear(prev.M, item.M) {
+			p := prev.M.Inv().Dot(item.M.Dot(canvas.Point{}))
+			if math.Abs(p.Y) < item.Size*0.1 && p.X > prev.Width-item.Size*0.3 && p.X < prev.Width+item.Size*3 {
+				if p.X > prev.Width+item.Size*0.25 {
+					prev.Text += " "
+				}
+				prev.Text += item.Text
+				prev.Width = p.X + item.Width
+				return
+			}
+		}
+	}
+	l.texts = append(l.texts, item)
+}
+
+// htmlSameLinear 判断两个变换矩阵的线性部分是否相同
+// 入参: a 变换矩阵, b 变换矩阵
+// 返回: bool 是否相同
+func htmlSameLinear(a, b canvas.Matrix) bool {
+	return math.Abs(a[0][0]-b[0][0]) < 1e-6 && math.Abs(a[0][1]-b[0][1]) < 1e-6 &&
+		math.Abs(a[1][0]-b[1][0]) < 1e-6 && math.Abs(a[1][1]-b[1][1]) < 1e-6
+}
+
+// ExportHTML 将整个文档导出为单个HTML文件
+// 每页为绝对定位的背景与可选择文本层, 附带大纲、书签跳转、链接和可下载的附件,
+// 页面背景和附件以data URI内嵌; 页面错误按WithPageErrorPolicy处理, 进度通过WithProgress报告
+// 入参: writer 输出流
+// 返回: *ExportSummary 导出结果摘要, error 错误信息
+func (r *Renderer) ExportHTML(writer io.Writer) (*ExportSummary, error) {
+	var buf bytes.Buffer
+	summary, err := r.exportHTML(&buf, &htmlOutput{})
+	if err != nil {
+		return summary, err
+	}
+	_, err = buf.WriteTo(writer)
+	return summary, err
+}
+
+// ExportHTMLDir 将整个文档导出为HTML目录
+// 目录中写出index.html, 页面背景写入pages子目录, 附件写入attachments子目录
+// 入参: dir 目标目录
+// 返回: *ExportSummary 导出结果摘要, error 错误信息
+func (r *Renderer) ExportHTMLDir(dir string) (*ExportSummary, error) {
+	var buf bytes.Buffer
+	summary, err := r.exportHTML(&buf, &htmlOutput{dir: dir})
+	if err != nil {
+		return summary, err
+	}
+	return summary, os.WriteFile(filepath.Join(dir, "index.html"), buf.Bytes(), 0o644)
+}
+
+// exportHTML 导出HTML文档
+// 入参: b 输出缓冲, out 资源输出
+// 返回: *ExportSummary 导出结果摘要, error 错误信息
+func (r *Renderer) exportHTML(b *bytes.Buffer, out *htmlOutput) (*ExportSummary, error) {
+	doc, err := r.Reader.Doc()
+	if err != nil {
+		return nil, err
+	}
+	if len(doc.Pages.Page) == 0 {
+		return nil, fmt.Errorf("no pages found")
+	}
+	summary := &ExportSummary{Total: len(doc.Pages.Page)}
+	pages, readErrs, err := r.readExportPages(doc)
+	if err != nil {
+		return summary, err
+	}
+	navigation := newPDFNavigation(r, doc, pages)
+	dests := make(map[string]string, len(navigation.Dest))
+	names := make([]string, 0, len(navigation.Dest))
+	for name := range navigation.Dest {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		dests[name] = fmt.Sprintf("dest-%d", i+1)
+	}
+	attachments, err := r.htmlAttachments(out)
+	if err != nil {
+		return summary, err
+	}
+	title := "OFD"
+	if info, err := r.Reader.DocInfo(); err == nil && info.Title != "" {
+		title = info.Title
+	} else if r.Reader.Path != "" {
+		title = filepath.Base(r.Reader.Path)
+	}
+	lang, labels := r.htmlLanguage()
+	b.WriteString("<!DOCTYPE html>\n<html lang=\"" + html.EscapeString(lang) + "\">\n<head>\n<meta charset=\"utf-8\">\n<meta name=\"viewport\" content=\"width=device-width,initial-scale=1\">\n")
+	b.WriteString("<meta name=\"generator\" content=\"xiaoqidun/ofdgo\">\n<title>" + html.EscapeString(title) + "</title>\n<style>\n" + htmlStyle + "</style>\n</head>\n<body>\n")
+	if len(navigation.Outline) > 0 || len(attachments.list) > 0 {
+		b.WriteString("<nav>\n")
+		if len(navigation.Outline) > 0 {
+			b.WriteString("<h2>" + html.EscapeString(labels.Outline) + "</h2>\n")
+			writeHTMLOutlines(b, navigation.Outline, dests, attachments.files)
+		}
+		if len(attachments.list) > 0 {
+			b.WriteString("<h2>" + html.EscapeString(labels.Attachments) + "</h2>\n<ul>\n")
+			for _, attachment := range attachments.list {
+				fmt.Fprintf(b, "<li><a href=\"%s\" download=\"%s\">%s</a></li>\n", html.EscapeString(attachment.URL), html.EscapeString(attachment.Name), html.EscapeString(attachment.Name))
+			}
+			b.WriteString("</ul>\n")
+		}
+		b.WriteString("</nav>\n")
+	}
+	b.WriteString("<main>\n")
+	rendered := make([]htmlPage, len(pages))
+	render := func(renderer *Renderer, i int) pageResult {
+		if readErrs[i] != nil {
+			return pageResult{index: i, err: readErrs[i]}
+		}
+		page, err := renderer.renderHTMLPage(pages[i].Content)
+		if err != nil {
+			return pageResult{index: i, err: &PageError{Index: i, Stage: ExportStageRender, Err: err}}
+		}
+		rendered[i] = page
+		return pageResult{index: i}
+	}
+	written := 0
+	err = r.renderPagesParallel(len(pages), true, render, func(result pageResult) error {
+		page := rendered[result.index]
+		if result.err != nil {
+			placeholder, err := r.pageFailed(summary, result.err)
+			if err != nil || !placeholder {
+				return err
+			}
+			page = r.htmlPageLayers(r.placeholderCanvas(pages[result.index].Box, result.err))
+		} else {
+			summary.Rendered++
+		}
+		rendered[result.index] = htmlPage{}
+		ext := ".svg"
+		if page.MediaType == "image/png" {
+			ext = ".png"
+		}
+		background, err := out.asset(fmt.Sprintf("pages/page-%d%s", result.index+1, ext), page.MediaType, page.Background)
+		if err != nil {
+			return err
+		}
+		writeHTMLPage(b, result.index, pages[result.index], page, background, navigation, dests, attachments.files)
+		written++
+		r.reportProgress(ExportStageWrite, result.index, written, len(pages))
+		return nil
+	})
+	summary.finish()
+	if err != nil {
+		return summary, err
+	}
+	if written == 0 {
+		return summary, fmt.Errorf("no pages rendered")
+	}
+	b.WriteString("</main>\n<script>\n" + htmlScript)
+	if navigation.Open != nil {
+		if href := htmlTargetHref(*navigation.Open, dests, attachments.files); strings.HasPrefix(href, "#") {
+			fmt.Fprintf(b, "if(!location.hash)location.hash=%q;\n", href)
+		}
+	}
+	b.WriteString("</script>\n</body>\n</html>\n")
+	return summary, nil
+}
+
+// htmlAttachments HTML导出附件集合
+type htmlAttachments struct {
+	list  []htmlAttachment
+	files map[string]htmlAttachment
+}
+
+// htmlAttachments 写出文档附件
+// 入参: out 资源输出
+// 返回: htmlAttachments 附件集合, error 错误信息
+func (r *Renderer) htmlAttachments(out *htmlOutput) (htmlAttachments, error) {
+	result := htmlAttachments{files: make(map[string]htmlAttachment)}
+	attachments, err := r.Reader.Attachments()
+	if err != nil {
+		return result, nil
+	}
+	for i, attachment := range attachments {
+		if attachment.FileLoc == "" {
+			continue
+		}
+		data, err := r.Reader.readFile(attachment.FileLoc)
+		if err != nil {
+			r.diagnose(DiagnosticWarning, attachment.ID, "attachment not exported: %v", err)
+			continue
+		}
+		fileName, mediaType := attachmentFileType(attachment)
+		name := path.Base(strings.ReplaceAll(fileName, "\\", "/"))
+		if name == "." || name == ".." || name == "/" {
+			name = "attachment"
+		}
+		href, err := out.asset(fmt.Sprintf("attachments/%d/%s", i+1, name), mediaType, data)
+		if err != nil {
+			return result, err
+		}
+		item := htmlAttachment{Name: name, URL: href}
+		result.list = append(result.list, item)
+		result.files[attachment.ID] = item
+	}
+	return result, nil
+}
+
+// renderHTMLPage 渲染用于HTML导出的页面
+// 入参: page 页面内容
+// 返回: htmlPage HTML页面内容, error 错误信息
+func (r *Renderer) renderHTMLPage(page *PageContent) (htmlPage, error) {
+	renderer := *r
+	renderer.textLayer = true
+	renderer.decodeImages = r.htmlBackground == HTMLBackgroundPNG
+	c, err := renderer.renderPage(page)
+	if err != nil {
+		return htmlPage{}, err
+	}
+	return r.htmlPageLayers(c), nil
+}
+
+// htmlPageLayers 拆分页面背景和文本层
+// 入参: c 页面画布
+// 返回: htmlPage HTML页面内容
+func (r *Renderer) htmlPageLayers(c *canvas.Canvas) htmlPage {
+	if r.htmlBackground == HTMLBackgroundPNG {
+		ras := rasterizer.New(c.W, c.H, canvas.DPMM(r.DPI/25.4), canvas.DefaultColorSpace)
+		layer := &htmlLayer{Renderer: ras}
+		c.RenderTo(layer)
+		ras.Close()
+		var buf bytes.Buffer
+		_ = png.Encode(&buf, ras)
+		return htmlPage{Background: buf.Bytes(), MediaType: "image/png", Texts: layer.texts}
+	}
+	var buf bytes.Buffer
+	opts := svg.DefaultOptions
+	opts.EmbedFonts = false
+	w := svg.New(&buf, c.W, c.H, &opts)
+	layer := &htmlLayer{Renderer: w}
+	c.RenderTo(layer)
+	_ = w.Close()
+	return htmlPage{Background: buf.Bytes(), MediaType: "image/svg+xml", Texts: layer.texts}
+}
+
+// writeHTMLPage 写出HTML页面
+// 入参: b 输出缓冲, index 页面索引, page 页面数据, content 页面内容, background 背景地址,
+// navigation 导航信息, dests 命名跳转目标对应的元素ID, files 附件
+func writeHTMLPage(b *bytes.Buffer, index int, page pdfPage, content htmlPage, background string, navigation *pdfNavigation, dests map[string]string, files map[string]htmlAttachment) {
+	pageH := page.Box.H
+	fmt.Fprintf(b, "<section class=\"ofd-page\" id=\"page-%d\" data-ofd-page-id=\"%s\" style=\"width:%spx;height:%spx\">\n",
+		index+1, html.EscapeString(page.Content.ID), formatOFDNumber(page.Box.W*htmlPxPerMM), formatOFDNumber(pageH*htmlPxPerMM))
+	fmt.Fprintf(b, "<img src=\"%s\" alt=\"\">\n", html.EscapeString(background))
+	names := make([]string, 0)
+	for name, dest := range navigation.Dest {
+		if dest.Page == index {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		dest := navigation.Dest[name].Dest
+		x, y := 0.0, 0.0
+		switch dest.Type {
+		case "XYZ", "FitR":
+			x, y = dest.Left, dest.Top
+		case "FitH", "FitBH":
+			y = dest.Top
+		case "FitV", "FitBV":
+			x = dest.Left
+		}
+		fmt.Fprintf(b, "<span class=\"ofd-dest\" id=\"%s\" style=\"left:%spx;top:%spx\"></span>\n", dests[name], formatOFDNumber(x*htmlPxPerMM), formatOFDNumber(y*htmlPxPerMM))
+	}
+	b.WriteString("<div class=\"ofd-text\">\n")
+	for _, text := range content.Texts {
+		m := text.M
+		asc := text.Ascent * htmlPxPerMM
+		fmt.Fprintf(b, "<span style=\"font-size:%spx;transform:matrix(%s,%s,%s,%s,%s,%s)\" data-w=\"%s\">%s</span>\n",
+			formatOFDNumber(text.Size*htmlPxPerMM),
+			formatOFDNumber(m[0][0]), formatOFDNumber(-m[1][0]), formatOFDNumber(-m[0][1]), formatOFDNumber(m[1][1]),
+			formatOFDNumber(m[0][1]*asc+m[0][2]*htmlPxPerMM), formatOFDNumber((pageH-m[1][2])*htmlPxPerMM-m[1][1]*asc),
+			formatOFDNumber(text.Width*htmlPxPerMM), html.EscapeString(text.Text))
+	}
+	b.WriteString("</div>\n")
+	links := navigation.Link[index]
+	for _, annotation := range navigation.Annot[index] {
+		if annotation.Target != (pdfTarget{}) {
+			links = append(links, pdfLink{Target: annotation.Target, Rect: annotation.Rect})
+		}
+	}
+	for _, link := range links {
+		href := htmlTargetHref(link.Target, dests, files)
+		if href == "" {
+			continue
+		}
+		rect := link.Rect
+		attrs := ""
+		switch {
+		case link.Target.URI != "":
+			attrs = " target=\"_blank\" rel=\"noopener noreferrer\""
+		case link.Target.AttachID != "":
+			attrs = " download=\"" + html.EscapeString(files[link.Target.AttachID].Name) + "\""
+		}
+		fmt.Fprintf(b, "<a class=\"ofd-link\" href=\"%s\"%s style=\"left:%spx;top:%spx;width:%spx;height:%spx\"></a>\n",
+			html.EscapeString(href), attrs, formatOFDNumber(rect.X0*htmlPxPerMM), formatOFDNumber((pageH-rect.Y1)*htmlPxPerMM),
+			formatOFDNumber((rect.X1-rect.X0)*htmlPxPerMM), formatOFDNumber((rect.Y1-rect.Y0)*htmlPxPerMM))
+	}
+	b.WriteString("</section>\n")
+}
+
+// writeHTMLOutlines 写出大纲
+// 带子节点的大纲项按Expanded设置展开状态
+// 入参: b 输出缓冲, outlines 大纲, dests 命名跳转目标对应的元素ID, files 附件
+func writeHTMLOutlines(b *bytes.Buffer, outlines []pdfOutline, dests map[string]string, files map[string]htmlAttachment) {
+	b.WriteString("<ul>\n")
+	for _, outline := range outlines {
+		title := html.EscapeString(outline.Title)
+		if href := htmlTargetHref(outline.Target, dests, files); href != "" {
+			title = "<a href=\"" + html.EscapeString(href) + "\">" + title + "</a>"
+		}
+		if len(outline.Children) == 0 {
+			b.WriteString("<li>" + title + "</li>\n")
+			continue
+		}
+		open := ""
+		if outline.Expanded {
+			open = " open"
+		}
+		b.WriteString("<li><details" + open + "><summary>" + title + "</summary>\n")
+		writeHTMLOutlines(b, outline.Children, dests, files)
+		b.WriteString("</details></li>\n")
+	}
+	b.WriteString("</ul>\n")
+}
+
+// htmlSafeURI 判断外部链接是否可写入导出页面
+// 仅允许相对地址和htmlURISchemes中的协议
+// 入参: uri 链接地址
+// 返回: bool 是否允许
+func htmlSafeURI(uri string) bool {
+	u, err := url.Parse(uri)
+	return err == nil && (u.Scheme == "" || htmlURISchemes[strings.ToLower(u.Scheme)])
+}
+
+// htmlTargetHref 获取动作目标对应的链接地址
+// 外部链接仅保留htmlURISchemes中的协议, 避免导出页面执行脚本
+// 入参: target 动作目标, dests 命名跳转目标对应的元素ID, files 附件
+// 返回: string 链接地址, 无可用地址时为空
+func htmlTargetHref(target pdfTarget, dests map[string]string, files map[string]htmlAttachment) string {
+	switch {
+	case target.Dest != "":
+		if id, ok := dests[target.Dest]; ok {
+			return "#" + id
+		}
+	case target.URI != "":
+		if htmlSafeURI(target.URI) {
+			return target.URI
+		}
+	case target.AttachID != "":
+		return files[target.AttachID].URL
+	}
+	return ""
+}
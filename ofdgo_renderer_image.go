@@ -32,19 +32,31 @@ func (r *Renderer) renderImage(ctx *canvas.Context, obj ImageObject, pageH float
 	if obj.Visible != nil && !*obj.Visible {
 		return
 	}
+	if !r.budget.object() {
+		return
+	}
+	defer r.svgObject("ImageObject", obj.ID, obj.Actions)()
 	resPath, ok := r.Reader.ResMap[obj.ResourceID]
 	if !ok {
+		r.diagnose(DiagnosticError, obj.ID, "image resource %s not found", obj.ResourceID)
 		return
 	}
 	img, err := r.decodeImageResource(resPath)
 	if err != nil {
+		r.budget.fail(err)
+		r.diagnose(DiagnosticError, obj.ID, "failed to decode image %s: %v", resPath, err)
 		return
 	}
 	box, _ := ParseBox(obj.Boundary)
 	if maskPath, ok := r.Reader.ResMap[obj.ImageMask]; ok {
 		if mask, err := r.decodeImageResource(maskPath); err == nil {
 			img = imageWithMask(img, mask)
+		} else {
+			r.budget.fail(err)
+			r.diagnose(DiagnosticWarning, obj.ID, "failed to decode image mask %s: %v", maskPath, err)
 		}
+	} else if obj.ImageMask != "" {
+		r.diagnose(DiagnosticWarning, obj.ID, "image mask resource %s not found", obj.ImageMask)
 	}
 	img = imageWithAlpha(img, obj.Alpha)
 	imgBounds := img.Bounds()
@@ -77,7 +89,7 @@ func (r *Renderer) renderImage(ctx *canvas.Context, obj ImageObject, pageH float
 			{-ctm.b / imgW, ctm.d / imgH, pageH - box.Y - ctm.d - ctm.f},
 		}
 	}
-	clipPath := intersectClipPath(parentClip, r.buildClipPath(obj.Clips, pageH, box.X, box.Y, objectCTM))
+	clipPath := r.objectClip(parentClip, r.buildClipPath(obj.Clips, pageH, box.X, box.Y, objectCTM))
 	img = imageWithClip(img, clipPath, m)
 	img, pad := imageWithTransparentEdge(img)
 	if pad > 0 {
@@ -193,13 +205,20 @@ func (r *Renderer) decodeImageResource(resPath string) (image.Image, error) {
 		return nil, err
 	}
 	defer rc.Close()
-	reader := bufio.NewReaderSize(rc, 8)
+	var src io.Reader = rc
+	if r.ctx != nil {
+		src = contextReader{ctx: r.ctx, r: rc}
+	}
+	reader := bufio.NewReaderSize(src, 8)
 	header, _ := reader.Peek(8)
 	if isJPEGData(header) {
 		img, err := canvasimage.NewJPEGImage(reader)
 		if err != nil {
 			return nil, err
 		}
+		if err := r.limits.checkImage(img.Bounds().Dx(), img.Bounds().Dy()); err != nil {
+			return nil, err
+		}
 		if r.decodeImages {
 			return img.Image()
 		}
@@ -210,6 +229,9 @@ func (r *Renderer) decodeImageResource(resPath string) (image.Image, error) {
 		if err != nil {
 			return nil, err
 		}
+		if err := r.limits.checkImage(img.Bounds().Dx(), img.Bounds().Dy()); err != nil {
+			return nil, err
+		}
 		if r.decodeImages {
 			return img.Image()
 		}
@@ -219,8 +241,7 @@ func (r *Renderer) decodeImageResource(resPath string) (image.Image, error) {
 	if err != nil {
 		return nil, err
 	}
-	img, _, err := decodeImageData(data)
-	return img, err
+	return decodeImageDataLimited(data, r.limits)
 }
 
 // imageWithAlpha 合并图片透明度
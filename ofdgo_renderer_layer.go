@@ -27,6 +27,9 @@ func (r *Renderer) renderAnnotations(ctx *canvas.Context, pageID string, pageH f
 		if len(annot.Appearance.Objects) == 0 {
 			continue
 		}
+		if _, ok := pdfNativeAnnotationBox(annot); ok && r.pdfAnnotations {
+			continue
+		}
 		box, _ := ParseBox(annot.Appearance.Boundary)
 		ctm := Matrix{a: 1, d: 1, e: box.X, f: box.Y}
 		for _, obj := range annot.Appearance.Objects {
@@ -41,25 +44,30 @@ func (r *Renderer) renderTemplate(ctx *canvas.Context, templateID string, pageH
 	tplContent := r.templatePageCache[templateID]
 	if tplContent == nil {
 		var tplPage *TemplatePage
-		for i := range r.Reader.doc.CommonData.TemplatePage {
-			if r.Reader.doc.CommonData.TemplatePage[i].ID == templateID {
-				tplPage = &r.Reader.doc.CommonData.TemplatePage[i]
+		doc := r.Reader.loadedDoc()
+		for i := range doc.CommonData.TemplatePage {
+			if doc.CommonData.TemplatePage[i].ID == templateID {
+				tplPage = &doc.CommonData.TemplatePage[i]
 				break
 			}
 		}
 		if tplPage == nil {
+			r.diagnose(DiagnosticWarning, templateID, "template not found")
 			return
 		}
 		var err error
 		tplContent, err = r.Reader.PageContent(Page{BaseLoc: tplPage.BaseLoc})
 		if err != nil {
+			r.diagnose(DiagnosticError, templateID, "failed to read template: %v", err)
 			return
 		}
 		r.templatePageCache[templateID] = tplContent
 	}
 	if tplContent.Content.Layer != nil {
+		renderer := *r
+		renderer.part = tplContent.Path
 		for _, layer := range tplContent.Content.Layer {
-			r.renderLayer(ctx, layer, pageH, nil, nil, 0, nil)
+			renderer.renderLayer(ctx, layer, pageH, nil, nil, 0, nil)
 		}
 	}
 }
@@ -67,6 +75,9 @@ func (r *Renderer) renderTemplate(ctx *canvas.Context, templateID string, pageH
 // renderLayer 渲染图层
 // 入参: ctx 画布上下文, layer 图层对象, pageH 页面高度, defaultFill 默认填充色, defaultStroke 默认描边色, defaultLW 默认线宽, parentCTM 父级CTM
 func (r *Renderer) renderLayer(ctx *canvas.Context, layer Layer, pageH float64, defaultFill, defaultStroke color.Color, defaultLW float64, parentCTM *Matrix) {
+	if !r.budget.check() {
+		return
+	}
 	defaultFill, defaultStroke, defaultLW = r.drawParamDefaults(layer.DrawParam, defaultFill, defaultStroke, defaultLW)
 	if len(layer.Objects) > 0 {
 		for _, obj := range layer.Objects {
@@ -94,18 +105,25 @@ func (r *Renderer) renderCompositeGraphicUnit(ctx *canvas.Context, cgu Composite
 	if cgu.Visible != nil && !*cgu.Visible {
 		return
 	}
+	if !r.budget.object() || !r.budget.enter() {
+		return
+	}
+	defer r.budget.leave()
+	defer r.svgObject("CompositeGraphicUnit", cgu.ID, cgu.Actions)()
 	ctx.Push()
 	currentCTM := NewMatrix(cgu.CTM)
 	if parentCTM != nil {
 		currentCTM = parentCTM.Multiply(currentCTM)
 	}
 	box, _ := ParseBox(cgu.Boundary)
-	clipPath := intersectClipPath(parentClip, r.buildClipPath(cgu.Clips, pageH, box.X, box.Y, currentCTM))
+	clipPath := r.objectClip(parentClip, r.buildClipPath(cgu.Clips, pageH, box.X, box.Y, currentCTM))
 	if cgu.ResourceID != "" {
 		if ref, ok := r.CompositeGraphicUnits[cgu.ResourceID]; ok {
 			refCopy := *ref
 			refCopy.Alpha = mergeAlpha(refCopy.Alpha, cgu.Alpha)
 			r.renderCompositeGraphicUnit(ctx, refCopy, pageH, defaultFill, defaultStroke, defaultLW, &currentCTM, true, clipPath)
+		} else {
+			r.diagnose(DiagnosticWarning, cgu.ID, "composite graphic unit resource %s not found", cgu.ResourceID)
 		}
 	}
 	defaultFill, defaultStroke, defaultLW = r.drawParamDefaults(cgu.DrawParam, defaultFill, defaultStroke, defaultLW)
@@ -204,6 +222,10 @@ func (r *Renderer) getDrawParam(id string, visited map[string]bool) *DrawParam {
 	if visited[id] {
 		return nil
 	}
+	if limit := r.limits.MaxNestingDepth; limit > 0 && len(visited) >= limit {
+		r.budget.fail(&LimitError{Kind: LimitNestingDepth, Name: id, Value: int64(len(visited) + 1), Max: int64(limit)})
+		return nil
+	}
 	visited[id] = true
 	if dp, ok := r.DrawParams[id]; ok {
 		if dp.Relative != "" {
@@ -250,5 +272,8 @@ func (r *Renderer) getDrawParam(id string, visited map[string]bool) *DrawParam {
 		}
 		return dp
 	}
+	if id != "" {
+		r.diagnose(DiagnosticWarning, id, "draw parameter not found")
+	}
 	return nil
 }
@@ -39,9 +39,13 @@ func (r *Renderer) RenderToImage(page *PageContent) (image.Image, error) {
 }
 
 // RenderToSVG 渲染为SVG
+// 设置WithVectorSVG时输出保留文本、对象ID和链接的矢量SVG
 // 入参: page 页面内容, writer 输出流
 // 返回: error 错误信息
 func (r *Renderer) RenderToSVG(page *PageContent, writer io.Writer) error {
+	if r.svgVector {
+		return r.renderVectorSVG(page, writer)
+	}
 	c, err := r.renderPage(page)
 	if err != nil {
 		return err
@@ -67,7 +71,7 @@ func replacePDFProducer(data []byte) []byte {
 // 入参: page 页面内容, writer 输出流
 // 返回: error 错误信息
 func (r *Renderer) RenderToPDF(page *PageContent, writer io.Writer) error {
-	c, err := r.renderPage(page)
+	c, err := r.renderPDFPage(page, newTextLayerGlyphFonts())
 	if err != nil {
 		return err
 	}
@@ -75,17 +79,28 @@ func (r *Renderer) RenderToPDF(page *PageContent, writer io.Writer) error {
 	if err != nil {
 		return err
 	}
+	overlay, err := r.newPDFSignatureOverlay()
+	if err != nil {
+		return err
+	}
+	defer overlay.close()
+	overlay.drawBadges(c, page.ID)
 	pages := []pdfPage{{Content: page, Box: box}}
-	navigation := newPDFNavigation(r, r.Reader.doc, pages)
+	navigation := newPDFNavigation(r, r.Reader.loadedDoc(), pages)
 	var buf bytes.Buffer
 	p := pdf.New(&buf, c.W, c.H, nil)
 	p.SetInfo("", "", "", "", "xiaoqidun/ofdgo")
 	navigation.apply(p, 0)
 	c.RenderTo(p)
+	overlay.appendReport(p, navigation, len(pages))
 	if err := p.Close(); err != nil {
 		return err
 	}
-	_, err = writer.Write(replacePDFProducer(buf.Bytes()))
+	data, err := r.finishPDF(buf.Bytes(), navigation)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(data)
 	return err
 }
 
@@ -101,46 +116,132 @@ func (r *Renderer) RenderToEPS(page *PageContent, writer io.Writer) error {
 }
 
 // RenderToMultiPagePDF 将整个文档导出为多页PDF
+// 页面按WithWorkers设置并发渲染, 按页面顺序写入PDF
 // 入参: writer 输出流
 // 返回: error 错误信息
 func (r *Renderer) RenderToMultiPagePDF(writer io.Writer) error {
+	_, err := r.ExportMultiPagePDF(writer)
+	return err
+}
+
+// ExportMultiPagePDF 将整个文档导出为多页PDF并返回导出结果摘要
+// 页面错误按WithPageErrorPolicy处理, 进度通过WithProgress报告
+// 入参: writer 输出流
+// 返回: *ExportSummary 导出结果摘要, error 错误信息
+func (r *Renderer) ExportMultiPagePDF(writer io.Writer) (*ExportSummary, error) {
 	doc, err := r.Reader.Doc()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(doc.Pages.Page) == 0 {
-		return fmt.Errorf("no pages found")
+		return nil, fmt.Errorf("no pages found")
 	}
-	pages := make([]pdfPage, len(doc.Pages.Page))
-	for i, pageRef := range doc.Pages.Page {
-		page, err := r.Reader.PageContent(pageRef)
-		if err != nil {
-			return fmt.Errorf("failed to read page %d: %w", i+1, err)
-		}
-		box, err := r.GetPageBox(page)
-		if err != nil {
-			return fmt.Errorf("failed to read page %d area: %w", i+1, err)
-		}
-		pages[i] = pdfPage{Content: page, Box: box}
+	summary := &ExportSummary{Total: len(doc.Pages.Page)}
+	pages, readErrs, err := r.readExportPages(doc)
+	if err != nil {
+		return summary, err
 	}
 	navigation := newPDFNavigation(r, doc, pages)
+	overlay, err := r.newPDFSignatureOverlay()
+	if err != nil {
+		return summary, err
+	}
+	defer overlay.close()
 	var buf bytes.Buffer
-	p := pdf.New(&buf, pages[0].Box.W, pages[0].Box.H, nil)
-	p.SetInfo("", "", "", "", "xiaoqidun/ofdgo")
-	for i, page := range pages {
-		c, err := r.renderPage(page.Content)
+	var p *pdf.PDF
+	glyphs := newTextLayerGlyphFonts()
+	render := func(renderer *Renderer, i int) pageResult {
+		if readErrs[i] != nil {
+			return pageResult{index: i, err: readErrs[i]}
+		}
+		c, err := renderer.renderPDFPage(pages[i].Content, glyphs)
 		if err != nil {
-			return fmt.Errorf("failed to render page %d: %w", i+1, err)
+			return pageResult{index: i, err: &PageError{Index: i, Stage: ExportStageRender, Err: err}}
+		}
+		return pageResult{index: i, canvas: c}
+	}
+	written := 0
+	err = r.renderPagesParallel(len(pages), true, render, func(result pageResult) error {
+		if result.err != nil {
+			placeholder, err := r.pageFailed(summary, result.err)
+			if err != nil || !placeholder {
+				return err
+			}
+			result.canvas = r.placeholderCanvas(pages[result.index].Box, result.err)
+		} else {
+			summary.Rendered++
 		}
-		if i > 0 {
-			p.NewPage(c.W, c.H)
+		if p == nil {
+			p = pdf.New(&buf, result.canvas.W, result.canvas.H, nil)
+			p.SetInfo("", "", "", "", "xiaoqidun/ofdgo")
+		} else {
+			p.NewPage(result.canvas.W, result.canvas.H)
 		}
-		navigation.apply(p, i)
-		c.RenderTo(p)
+		navigation.apply(p, result.index)
+		overlay.drawBadges(result.canvas, pages[result.index].Content.ID)
+		result.canvas.RenderTo(p)
+		written++
+		r.reportProgress(ExportStageWrite, result.index, written, len(pages))
+		return nil
+	})
+	summary.finish()
+	if err != nil {
+		return summary, err
+	}
+	if p == nil {
+		return summary, fmt.Errorf("no pages rendered")
 	}
+	overlay.appendReport(p, navigation, len(pages))
 	if err := p.Close(); err != nil {
-		return err
+		return summary, err
 	}
-	_, err = writer.Write(replacePDFProducer(buf.Bytes()))
-	return err
+	data, err := r.finishPDF(buf.Bytes(), navigation)
+	if err != nil {
+		return summary, err
+	}
+	_, err = writer.Write(data)
+	return summary, err
+}
+
+// exportPageBox 获取失败页面占位使用的页面区域
+// 优先使用页面自身的区域, 页面未读取或区域无效时依次使用文档默认区域和A4
+// 入参: page 页面内容, 未读取时为nil
+// 返回: Box 页面区域
+func (r *Renderer) exportPageBox(page *PageContent) Box {
+	if page != nil {
+		if box, err := r.GetPageBox(page); err == nil {
+			return box
+		}
+	}
+	if box, err := r.GetPageBox(&PageContent{}); err == nil {
+		return box
+	}
+	return Box{W: 210, H: 297}
+}
+
+// readExportPages 读取整文档导出的全部页面
+// 读取失败的页面以空白页面代替, 错误按页面记录, 错误处理策略为立即终止时直接返回错误
+// 入参: doc 文档结构
+// 返回: []pdfPage 页面数据, []error 各页面读取错误, error 需要终止导出时的错误
+func (r *Renderer) readExportPages(doc *Document) ([]pdfPage, []error, error) {
+	pages := make([]pdfPage, len(doc.Pages.Page))
+	readErrs := make([]error, len(doc.Pages.Page))
+	for i, pageRef := range doc.Pages.Page {
+		page, err := r.Reader.PageContent(pageRef)
+		if err == nil {
+			var box Box
+			if box, err = r.GetPageBox(page); err == nil {
+				pages[i] = pdfPage{Content: page, Box: box}
+			}
+		}
+		if err != nil {
+			readErrs[i] = &PageError{Index: i, Stage: ExportStageRead, Err: err}
+			if r.pageErrorPolicy == PageErrorFail {
+				return nil, nil, readErrs[i]
+			}
+			pages[i] = pdfPage{Content: &PageContent{ID: pageRef.ID}, Box: r.exportPageBox(page)}
+		}
+		r.reportProgress(ExportStageRead, i, i+1, len(pages))
+	}
+	return pages, readErrs, nil
 }
@@ -0,0 +1,210 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"runtime"
+	"sync"
+
+	"github.com/tdewolff/canvas"
+	"github.com/tdewolff/canvas/renderers/rasterizer"
+)
+
+// PageImageFunc 页面图像回调
+// 入参: index 页面索引, img 页面图像
+// 返回: error 错误信息, 非空时停止渲染
+type PageImageFunc func(index int, img image.Image) error
+
+// pageResult 页面渲染结果
+type pageResult struct {
+	task   int
+	index  int
+	canvas *canvas.Canvas
+	image  image.Image
+	box    Box
+	err    error
+}
+
+// WithWorkers 设置多页导出的并发渲染数量
+// 默认逐页顺序渲染, n小于等于0时使用可用CPU数量
+// 并发渲染时各工作协程使用独立的字体对象, 导出PDF中同一字体可能分多个子集嵌入
+// 入参: n 并发渲染数量
+// 返回: RendererOption 渲染选项
+func WithWorkers(n int) RendererOption {
+	return func(r *Renderer) {
+		if n <= 0 {
+			n = runtime.GOMAXPROCS(0)
+		}
+		r.workers = n
+	}
+}
+
+// RenderToImages 渲染多个页面为光栅图
+// 页面按WithWorkers设置并发渲染, 每完成一页在调用协程中回调一次, 回调顺序为完成顺序
+// 入参: indexes 页面索引列表, 为nil时渲染全部页面, fn 页面图像回调
+// 返回: error 错误信息
+func (r *Renderer) RenderToImages(indexes []int, fn PageImageFunc) error {
+	_, err := r.ExportImages(indexes, fn)
+	return err
+}
+
+// ExportImages 渲染多个页面为光栅图并返回导出结果摘要
+// 页面错误按WithPageErrorPolicy处理, 跳过的页面不回调, 占位页以光栅图回调
+// 入参: indexes 页面索引列表, 为nil时渲染全部页面, fn 页面图像回调
+// 返回: *ExportSummary 导出结果摘要, error 错误信息
+func (r *Renderer) ExportImages(indexes []int, fn PageImageFunc) (*ExportSummary, error) {
+	doc, err := r.Reader.Doc()
+	if err != nil {
+		return nil, err
+	}
+	if indexes == nil {
+		indexes = make([]int, len(doc.Pages.Page))
+		for i := range indexes {
+			indexes[i] = i
+		}
+	}
+	for _, index := range indexes {
+		if index < 0 || index >= len(doc.Pages.Page) {
+			return nil, fmt.Errorf("page index %d out of range", index)
+		}
+	}
+	summary := &ExportSummary{Total: len(indexes)}
+	render := func(renderer *Renderer, i int) pageResult {
+		index := indexes[i]
+		page, err := renderer.Reader.PageContent(doc.Pages.Page[index])
+		if err != nil {
+			return pageResult{index: index, box: renderer.exportPageBox(nil), err: &PageError{Index: index, Stage: ExportStageRead, Err: err}}
+		}
+		img, err := renderer.RenderToImage(page)
+		if err != nil {
+			return pageResult{index: index, box: renderer.exportPageBox(page), err: &PageError{Index: index, Stage: ExportStageRender, Err: err}}
+		}
+		return pageResult{index: index, image: img}
+	}
+	written := 0
+	err = r.renderPagesParallel(len(indexes), false, render, func(result pageResult) error {
+		if result.err != nil {
+			placeholder, err := r.pageFailed(summary, result.err)
+			if err != nil || !placeholder {
+				return err
+			}
+			c := r.placeholderCanvas(result.box, result.err)
+			result.image = rasterizer.Draw(c, canvas.DPMM(r.DPI/25.4), canvas.DefaultColorSpace)
+		} else {
+			summary.Rendered++
+		}
+		if err := fn(result.index, result.image); err != nil {
+			return err
+		}
+		written++
+		r.reportProgress(ExportStageWrite, result.index, written, len(indexes))
+		return nil
+	})
+	summary.finish()
+	return summary, err
+}
+
+// workerCount 获取并发渲染数量
+// 返回: int 并发渲染数量
+func (r *Renderer) workerCount() int {
+	return max(r.workers, 1)
+}
+
+// renderPagesParallel 使用工作池并发渲染页面
+// 已完成未回调的页面数量不超过并发数量的两倍, 以限制内存占用
+// 渲染失败的结果同样交给回调, 由回调决定是否终止
+// 入参: count 任务数量, ordered 是否按任务顺序回调, render 渲染函数, done 结果回调, 在调用协程中串行执行
+// 返回: error 错误信息
+func (r *Renderer) renderPagesParallel(count int, ordered bool, render func(renderer *Renderer, i int) pageResult, done func(result pageResult) error) error {
+	workers := min(r.workerCount(), count)
+	if workers <= 1 {
+		for i := 0; i < count; i++ {
+			if err := r.ctxErr(); err != nil {
+				return err
+			}
+			result := render(r, i)
+			r.reportProgress(ExportStageRender, result.index, i+1, count)
+			if err := done(result); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	ctx, cancel := context.WithCancel(r.Context())
+	renderer := r.WithContext(ctx)
+	window := workers * 2
+	jobs := make(chan int)
+	results := make(chan pageResult, window)
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				result := render(renderer, i)
+				result.task = i
+				results <- result
+			}
+		}()
+	}
+	defer func() {
+		cancel()
+		close(jobs)
+		wg.Wait()
+	}()
+	pending := make(map[int]pageResult)
+	sent, rendered, delivered, inflight := 0, 0, 0, 0
+	for delivered < count {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		var jobCh chan<- int
+		if sent < count && inflight+len(pending) < window {
+			jobCh = jobs
+		}
+		select {
+		case jobCh <- sent:
+			sent++
+			inflight++
+		case result := <-results:
+			inflight--
+			rendered++
+			r.reportProgress(ExportStageRender, result.index, rendered, count)
+			if !ordered {
+				if err := done(result); err != nil {
+					return err
+				}
+				delivered++
+				continue
+			}
+			pending[result.task] = result
+			for {
+				next, ok := pending[delivered]
+				if !ok {
+					break
+				}
+				delete(pending, delivered)
+				if err := done(next); err != nil {
+					return err
+				}
+				delivered++
+			}
+		}
+	}
+	return nil
+}
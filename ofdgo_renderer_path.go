@@ -15,6 +15,7 @@
 package ofdgo
 
 import (
+	"fmt"
 	"image/color"
 	"math"
 	"strconv"
@@ -168,6 +169,10 @@ func (r *Renderer) renderPath(ctx *canvas.Context, obj PathObject, pageH float64
 	if obj.Visible != nil && !*obj.Visible {
 		return
 	}
+	if !r.budget.object() {
+		return
+	}
+	defer r.svgObject("PathObject", obj.ID, obj.Actions)()
 	ctx.Push()
 	bx, by := 0.0, 0.0
 	if obj.Boundary != "" {
@@ -191,7 +196,7 @@ func (r *Renderer) renderPath(ctx *canvas.Context, obj PathObject, pageH float64
 	if rectPath := r.buildTinyFillRectPath(obj, pageH, ctm, bx, by); rectPath != nil {
 		p = rectPath
 	}
-	clipPath := intersectClipPath(parentClip, r.buildClipPath(obj.Clips, pageH, bx, by, ctm))
+	clipPath := r.objectClip(parentClip, r.buildClipPath(obj.Clips, pageH, bx, by, ctm))
 	shouldFill := false
 	if obj.Fill != nil {
 		shouldFill = *obj.Fill
@@ -260,6 +265,10 @@ func (r *Renderer) renderPattern(ctx *canvas.Context, pattern *Pattern, defaultC
 	if pattern == nil || clip == nil || len(pattern.CellContent.Objects) == 0 {
 		return
 	}
+	if !r.budget.enter() {
+		return
+	}
+	defer r.budget.leave()
 	xStep, yStep := pattern.XStep, pattern.YStep
 	if xStep == 0 {
 		xStep = pattern.Width
@@ -330,34 +339,58 @@ func (r *Renderer) buildPath(obj PathObject, pageH float64, ctm Matrix, boundary
 	}
 	p := &canvas.Path{}
 	tokens := strings.Fields(obj.AbbreviatedData)
+	invalid := ""
+	num := func(token string) float64 {
+		v, err := strconv.ParseFloat(token, 64)
+		if err != nil && invalid == "" {
+			invalid = fmt.Sprintf("invalid number %q", token)
+		}
+		return v
+	}
+	flag := func(token string) bool {
+		v, err := strconv.ParseBool(token)
+		if err != nil && invalid == "" {
+			invalid = fmt.Sprintf("invalid flag %q", token)
+		}
+		return v
+	}
+	args := func(cmd string, i, n int) bool {
+		if i+n <= len(tokens) {
+			return true
+		}
+		if invalid == "" {
+			invalid = fmt.Sprintf("missing arguments for %s", cmd)
+		}
+		return false
+	}
 	for i := 0; i < len(tokens); {
 		cmd := tokens[i]
 		i++
 		switch cmd {
 		case "M", "S":
-			if i+1 < len(tokens) {
-				x, _ := strconv.ParseFloat(tokens[i], 64)
-				y, _ := strconv.ParseFloat(tokens[i+1], 64)
+			if args(cmd, i, 2) {
+				x := num(tokens[i])
+				y := num(tokens[i+1])
 				tx, ty := point(x, y)
 				p.MoveTo(tx, ty)
 				i += 2
 			}
 		case "L":
-			if i+1 < len(tokens) {
-				x, _ := strconv.ParseFloat(tokens[i], 64)
-				y, _ := strconv.ParseFloat(tokens[i+1], 64)
+			if args(cmd, i, 2) {
+				x := num(tokens[i])
+				y := num(tokens[i+1])
 				tx, ty := point(x, y)
 				p.LineTo(tx, ty)
 				i += 2
 			}
 		case "B":
-			if i+5 < len(tokens) {
-				x1, _ := strconv.ParseFloat(tokens[i], 64)
-				y1, _ := strconv.ParseFloat(tokens[i+1], 64)
-				x2, _ := strconv.ParseFloat(tokens[i+2], 64)
-				y2, _ := strconv.ParseFloat(tokens[i+3], 64)
-				x3, _ := strconv.ParseFloat(tokens[i+4], 64)
-				y3, _ := strconv.ParseFloat(tokens[i+5], 64)
+			if args(cmd, i, 6) {
+				x1 := num(tokens[i])
+				y1 := num(tokens[i+1])
+				x2 := num(tokens[i+2])
+				y2 := num(tokens[i+3])
+				x3 := num(tokens[i+4])
+				y3 := num(tokens[i+5])
 				tx1, ty1 := point(x1, y1)
 				tx2, ty2 := point(x2, y2)
 				tx3, ty3 := point(x3, y3)
@@ -365,25 +398,25 @@ func (r *Renderer) buildPath(obj PathObject, pageH float64, ctm Matrix, boundary
 				i += 6
 			}
 		case "Q":
-			if i+3 < len(tokens) {
-				x1, _ := strconv.ParseFloat(tokens[i], 64)
-				y1, _ := strconv.ParseFloat(tokens[i+1], 64)
-				x2, _ := strconv.ParseFloat(tokens[i+2], 64)
-				y2, _ := strconv.ParseFloat(tokens[i+3], 64)
+			if args(cmd, i, 4) {
+				x1 := num(tokens[i])
+				y1 := num(tokens[i+1])
+				x2 := num(tokens[i+2])
+				y2 := num(tokens[i+3])
 				tx1, ty1 := point(x1, y1)
 				tx2, ty2 := point(x2, y2)
 				p.QuadTo(tx1, ty1, tx2, ty2)
 				i += 4
 			}
 		case "A":
-			if i+6 < len(tokens) {
-				rx, _ := strconv.ParseFloat(tokens[i], 64)
-				ry, _ := strconv.ParseFloat(tokens[i+1], 64)
-				rot, _ := strconv.ParseFloat(tokens[i+2], 64)
-				large, _ := strconv.ParseBool(tokens[i+3])
-				sweep, _ := strconv.ParseBool(tokens[i+4])
-				x, _ := strconv.ParseFloat(tokens[i+5], 64)
-				y, _ := strconv.ParseFloat(tokens[i+6], 64)
+			if args(cmd, i, 7) {
+				rx := num(tokens[i])
+				ry := num(tokens[i+1])
+				rot := num(tokens[i+2])
+				large := flag(tokens[i+3])
+				sweep := flag(tokens[i+4])
+				x := num(tokens[i+5])
+				y := num(tokens[i+6])
 				sx := math.Hypot(ctm.a, ctm.c)
 				sy := math.Hypot(ctm.b, ctm.d)
 				ctmRot := math.Atan2(ctm.b, ctm.a) * 180 / math.Pi
@@ -394,8 +427,15 @@ func (r *Renderer) buildPath(obj PathObject, pageH float64, ctm Matrix, boundary
 			}
 		case "C":
 			p.Close()
+		default:
+			if invalid == "" {
+				invalid = fmt.Sprintf("unknown command %q", cmd)
+			}
 		}
 	}
+	if invalid != "" {
+		r.diagnose(DiagnosticWarning, obj.ID, "invalid path data: %s", invalid)
+	}
 	return p
 }
 
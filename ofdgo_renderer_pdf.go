@@ -30,29 +30,41 @@ type pdfPage struct {
 
 // pdfNavigation PDF导航信息
 type pdfNavigation struct {
-	Anchor  map[int][]pdfAnchor
+	Dest    map[string]pdfDest
 	Link    map[int][]pdfLink
-	Outline map[int][]pdfOutline
+	Outline []pdfOutline
+	Annot   map[int][]pdfAnnotation
+	Open    *pdfTarget
+	written []int
 	nextID  int
 }
 
-// pdfAnchor PDF跳转目标
-type pdfAnchor struct {
-	Name string
-	Rect canvas.Rect
+// pdfDest PDF命名跳转目标
+type pdfDest struct {
+	Page  int
+	PageH float64
+	Dest  Dest
+}
+
+// pdfTarget PDF动作目标, 各字段互斥
+type pdfTarget struct {
+	Dest     string // 命名跳转目标
+	URI      string // 外部链接
+	AttachID string // 附件标识
 }
 
 // pdfLink PDF链接
 type pdfLink struct {
-	URI  string
-	Rect canvas.Rect
+	Target pdfTarget
+	Rect   canvas.Rect
 }
 
 // pdfOutline PDF大纲
 type pdfOutline struct {
-	Name  string
-	Level int
-	Y     float64
+	Title    string
+	Expanded bool
+	Target   pdfTarget
+	Children []pdfOutline
 }
 
 // pdfActionSource PDF动作来源
@@ -62,28 +74,40 @@ type pdfActionSource struct {
 }
 
 // newPDFNavigation 创建PDF导航信息
+// 书签导出为同名的命名跳转目标
 // 入参: renderer 渲染器, doc 文档结构, pages 页面数据
 // 返回: *pdfNavigation PDF导航信息
 func newPDFNavigation(renderer *Renderer, doc *Document, pages []pdfPage) *pdfNavigation {
 	navigation := &pdfNavigation{
-		Anchor:  make(map[int][]pdfAnchor),
-		Link:    make(map[int][]pdfLink),
-		Outline: make(map[int][]pdfOutline),
+		Dest:  make(map[string]pdfDest),
+		Link:  make(map[int][]pdfLink),
+		Annot: make(map[int][]pdfAnnotation),
 	}
 	pageIndex := make(map[string]int, len(pages))
 	for i, page := range pages {
 		pageIndex[page.Content.ID] = i
 	}
-	bookmarks := make(map[string]Dest)
 	if doc != nil {
 		for _, bookmark := range doc.Bookmarks.Bookmark {
-			bookmarks[bookmark.Name] = bookmark.Dest
+			if bookmark.Name != "" {
+				navigation.addDest(bookmark.Name, bookmark.Dest, pageIndex, pages)
+			}
 		}
 	}
 	for i, page := range pages {
 		sources := pageActionSources(page)
 		if renderer.RenderAnnotations {
-			sources = append(sources, annotationActionSources(renderer.Reader.Annots[page.Content.ID])...)
+			annotations := make([]Annotation, 0)
+			for _, annotation := range renderer.Reader.Annots[page.Content.ID] {
+				if box, ok := pdfNativeAnnotationBox(annotation); ok {
+					navigation.addAnnotation(renderer, i, annotation, pdfSourceRect(box, page.Box.H), pageIndex, pages)
+					if annotation.Type == "Link" {
+						continue
+					}
+				}
+				annotations = append(annotations, annotation)
+			}
+			sources = append(sources, annotationActionSources(annotations)...)
 		}
 		for _, source := range sources {
 			rect := pdfSourceRect(source.Box, page.Box.H)
@@ -91,72 +115,189 @@ func newPDFNavigation(renderer *Renderer, doc *Document, pages []pdfPage) *pdfNa
 				if action.Event != "CLICK" {
 					continue
 				}
-				navigation.addAction(i, rect, action, bookmarks, pageIndex, pages)
+				if target, ok := navigation.actionTarget(renderer, action, pageIndex, pages); ok {
+					navigation.Link[i] = append(navigation.Link[i], pdfLink{Target: target, Rect: rect})
+				}
 			}
 		}
 	}
 	if doc != nil {
-		navigation.addOutlines(doc.Outlines.OutlineElem, 0, bookmarks, pageIndex, pages)
+		navigation.Outline = navigation.outlines(renderer, doc.Outlines.OutlineElem, pageIndex, pages)
+		for _, action := range doc.Actions {
+			if action.Event != "DO" {
+				continue
+			}
+			if target, ok := navigation.actionTarget(renderer, action, pageIndex, pages); ok {
+				navigation.Open = &target
+				break
+			}
+		}
 	}
 	return navigation
 }
 
-// addAction 添加PDF动作
-// 入参: page 页面索引, rect 动作区域, action 动作, bookmarks 书签, pageIndex 页面索引表, pages 页面数据
-func (n *pdfNavigation) addAction(page int, rect canvas.Rect, action Action, bookmarks map[string]Dest, pageIndex map[string]int, pages []pdfPage) {
-	if action.Goto != nil {
-		dest := gotoDest(action.Goto, bookmarks)
-		if dest == nil {
-			return
-		}
-		target, ok := pageIndex[dest.PageID]
-		if !ok {
-			return
-		}
-		targetRect, ok := pdfDestRect(*dest, pages[target].Box.H)
-		if !ok {
-			return
-		}
-		name := fmt.Sprintf("ofdgo-dest-%d", n.nextID)
-		n.nextID++
-		n.Anchor[target] = append(n.Anchor[target], pdfAnchor{Name: name, Rect: targetRect})
-		n.Link[page] = append(n.Link[page], pdfLink{URI: "#" + name, Rect: rect})
-		return
+// addDest 添加命名跳转目标
+// 入参: name 目标名称, dest OFD跳转目标, pageIndex 页面索引表, pages 页面数据
+// 返回: bool 是否添加
+func (n *pdfNavigation) addDest(name string, dest Dest, pageIndex map[string]int, pages []pdfPage) bool {
+	page, ok := pageIndex[dest.PageID]
+	if !ok || !pdfDestSupported(dest.Type) {
+		return false
 	}
-	if action.URI != nil && action.URI.URI != "" {
-		n.Link[page] = append(n.Link[page], pdfLink{URI: resolveActionURI(*action.URI), Rect: rect})
+	n.Dest[name] = pdfDest{Page: page, PageH: pages[page].Box.H, Dest: dest}
+	return true
+}
+
+// actionTarget 获取动作的PDF目标
+// 动作映射如下, 不支持的动作记录提示诊断后忽略:
+// Goto 映射为命名跳转目标, 书签跳转直接使用书签名称;
+// URI 映射为URI动作;
+// GotoA 映射为嵌入文件, PDF附件使用GoToE动作, 其他附件使用文件附件注释, PDF/A-2b不嵌入附件因而不导出;
+// Sound和Movie不导出, PDF多媒体需要另行封装的媒体数据且PDF/A禁止此类动作
+// 入参: renderer 渲染器, action 动作, pageIndex 页面索引表, pages 页面数据
+// 返回: pdfTarget 动作目标, bool 是否支持
+func (n *pdfNavigation) actionTarget(renderer *Renderer, action Action, pageIndex map[string]int, pages []pdfPage) (pdfTarget, bool) {
+	switch {
+	case action.Goto != nil:
+		if action.Goto.Dest != nil {
+			name := fmt.Sprintf("ofdgo-dest-%d", n.nextID)
+			if n.addDest(name, *action.Goto.Dest, pageIndex, pages) {
+				n.nextID++
+				return pdfTarget{Dest: name}, true
+			}
+		} else if action.Goto.Bookmark != nil {
+			if _, ok := n.Dest[action.Goto.Bookmark.Name]; ok {
+				return pdfTarget{Dest: action.Goto.Bookmark.Name}, true
+			}
+		}
+	case action.URI != nil && action.URI.URI != "":
+		return pdfTarget{URI: resolveActionURI(*action.URI)}, true
+	case action.GotoA != nil && action.GotoA.AttachID != "":
+		return pdfTarget{AttachID: action.GotoA.AttachID}, true
+	case action.Sound != nil:
+		renderer.diagnose(DiagnosticInfo, action.Sound.ResourceID, "sound action is not exported to pdf")
+	case action.Movie != nil:
+		renderer.diagnose(DiagnosticInfo, action.Movie.ResourceID, "movie action is not exported to pdf")
 	}
+	return pdfTarget{}, false
 }
 
-// addOutlines 添加PDF大纲
-// 入参: outlines 大纲节点, level 节点层级, bookmarks 书签, pageIndex 页面索引表, pages 页面数据
-func (n *pdfNavigation) addOutlines(outlines []OutlineElem, level int, bookmarks map[string]Dest, pageIndex map[string]int, pages []pdfPage) {
-	for _, outline := range outlines {
-		if dest := outlineDest(outline, bookmarks); dest != nil {
-			if page, ok := pageIndex[dest.PageID]; ok {
-				n.Outline[page] = append(n.Outline[page], pdfOutline{
-					Name:  outline.Title,
-					Level: level,
-					Y:     pdfDestY(*dest, pages[page].Box.H),
-				})
+// outlines 转换大纲节点
+// 节点的展开状态取自Expanded, 子节点数以实际出现的子节点为准,
+// 节点无可导出的动作时跳转到首个带跳转目标的子节点
+// 入参: renderer 渲染器, elems 大纲节点, pageIndex 页面索引表, pages 页面数据
+// 返回: []pdfOutline PDF大纲
+func (n *pdfNavigation) outlines(renderer *Renderer, elems []OutlineElem, pageIndex map[string]int, pages []pdfPage) []pdfOutline {
+	outlines := make([]pdfOutline, 0, len(elems))
+	for _, elem := range elems {
+		outline := pdfOutline{
+			Title:    elem.Title,
+			Expanded: elem.Expanded,
+			Children: n.outlines(renderer, elem.OutlineElem, pageIndex, pages),
+		}
+		found := false
+		for _, action := range elem.Actions {
+			if action.Event != "" && action.Event != "CLICK" {
+				continue
+			}
+			if outline.Target, found = n.actionTarget(renderer, action, pageIndex, pages); found {
+				break
 			}
 		}
-		n.addOutlines(outline.OutlineElem, level+1, bookmarks, pageIndex, pages)
+		if !found {
+			for _, child := range outline.Children {
+				if child.Target.Dest != "" {
+					outline.Target = pdfTarget{Dest: child.Target.Dest}
+					break
+				}
+			}
+		}
+		outlines = append(outlines, outline)
 	}
+	return outlines
 }
 
 // apply 应用PDF导航信息
+// 跳转和外部链接由PDF渲染器写出, 附件链接、命名目标和大纲在后处理中写出,
+// 按调用顺序记录写出的页面, 供后处理时定位页面
 // 入参: renderer PDF渲染器, page 页面索引
 func (n *pdfNavigation) apply(renderer *pdf.PDF, page int) {
-	for _, anchor := range n.Anchor[page] {
-		renderer.AddAnchor(anchor.Name, anchor.Rect)
-	}
+	n.written = append(n.written, page)
 	for _, link := range n.Link[page] {
-		renderer.AddLink(link.URI, link.Rect)
+		switch {
+		case link.Target.Dest != "":
+			renderer.AddLink("#"+link.Target.Dest, link.Rect)
+		case link.Target.URI != "":
+			renderer.AddLink(link.Target.URI, link.Rect)
+		}
 	}
-	for _, outline := range n.Outline[page] {
-		renderer.AddOutline(outline.Name, outline.Level, outline.Y)
+}
+
+// finishPDF 完成PDF导出的后处理
+// 写入文档元数据、附件、原生注释和导航信息, 按设置转换为PDF/A
+// 无需改写时直接使用canvas写出的PDF, 非PDF/A导出解析失败时同样退回原PDF并记录诊断
+// 入参: data canvas写出的PDF数据, navigation PDF导航信息
+// 返回: []byte PDF数据, error 错误信息
+func (r *Renderer) finishPDF(data []byte, navigation *pdfNavigation) ([]byte, error) {
+	part := r.pdfa.part()
+	if part == 0 && !r.pdfRewriteNeeded(navigation) {
+		return replacePDFProducer(data), nil
 	}
+	f, err := parsePDFFile(data)
+	if err != nil {
+		if part > 0 {
+			return nil, err
+		}
+		r.diagnose(DiagnosticWarning, "", "pdf post-processing skipped: %v", err)
+		return replacePDFProducer(data), nil
+	}
+	f.hoistAnnots()
+	r.writePDFMetadata(f, part)
+	files, err := r.embedPDFFiles(f, part)
+	if err != nil {
+		return nil, err
+	}
+	pages := make(map[int]int, len(navigation.written))
+	pageObjects := f.pages()
+	for i, page := range navigation.written {
+		if i < len(pageObjects) {
+			pages[page] = pageObjects[i]
+		}
+	}
+	if err := r.writePDFAnnotations(f, navigation, pages, files); err != nil {
+		return nil, err
+	}
+	r.writePDFNavigation(f, navigation, pages, files)
+	if r.pdfTextLayer {
+		pdfInvisibleTextLayer(f)
+	}
+	if part > 0 {
+		if err := convertPDFA(f); err != nil {
+			return nil, err
+		}
+	}
+	return f.bytes(), nil
+}
+
+// pdfRewriteNeeded 判断PDF是否需要后处理改写
+// 入参: navigation PDF导航信息
+// 返回: bool 需写入文档元数据、附件、原生注释、导航信息或文字层时为true
+func (r *Renderer) pdfRewriteNeeded(navigation *pdfNavigation) bool {
+	if r.pdfTextLayer || len(navigation.Dest) > 0 || len(navigation.Outline) > 0 || navigation.Open != nil {
+		return true
+	}
+	for _, page := range navigation.written {
+		if len(navigation.Annot[page]) > 0 {
+			return true
+		}
+	}
+	if info, err := r.Reader.DocInfo(); err == nil && info != nil {
+		if info.Title != "" || info.Author != "" || info.Subject != "" || info.DocID != "" || info.CreationDate != "" || info.ModDate != "" || info.Keywords != nil {
+			return true
+		}
+	}
+	attachments, err := r.Reader.Attachments()
+	return err == nil && len(attachments) > 0
 }
 
 // pageActionSources 获取页面动作来源
@@ -247,25 +388,6 @@ func gotoDest(action *Goto, bookmarks map[string]Dest) *Dest {
 	return nil
 }
 
-// outlineDest 获取大纲跳转目标
-// 入参: outline 大纲节点, bookmarks 书签
-// 返回: *Dest 跳转目标
-func outlineDest(outline OutlineElem, bookmarks map[string]Dest) *Dest {
-	for _, action := range outline.Actions {
-		if action.Goto != nil {
-			if dest := gotoDest(action.Goto, bookmarks); dest != nil {
-				return dest
-			}
-		}
-	}
-	for _, child := range outline.OutlineElem {
-		if dest := outlineDest(child, bookmarks); dest != nil {
-			return dest
-		}
-	}
-	return nil
-}
-
 // resolveActionURI 解析URI动作地址
 // 入参: action URI动作
 // 返回: string URI地址
@@ -291,32 +413,39 @@ func pdfSourceRect(box Box, pageH float64) canvas.Rect {
 	return canvas.RectFromSize(box.X, pageH-box.Y-box.H, box.W, box.H)
 }
 
-// pdfDestRect 转换PDF跳转目标
-// 入参: dest OFD跳转目标, pageH 页面高度
-// 返回: canvas.Rect PDF目标区域, bool 是否支持
-func pdfDestRect(dest Dest, pageH float64) (canvas.Rect, bool) {
-	switch dest.Type {
-	case "XYZ":
-		return canvas.Rect{X0: dest.Left, Y0: pageH - dest.Top, X1: dest.Left, Y1: pageH - dest.Top}, true
-	case "Fit":
-		return canvas.Rect{}, true
-	case "FitH":
-		return canvas.Rect{Y0: pageH - dest.Top, Y1: pageH - dest.Top}, true
-	case "FitV":
-		return canvas.Rect{X0: dest.Left, X1: dest.Left}, true
-	case "FitR":
-		return canvas.Rect{X0: dest.Left, Y0: pageH - dest.Bottom, X1: dest.Right, Y1: pageH - dest.Top}, true
+// pdfDestSupported 判断跳转目标类型是否支持
+// 入参: destType 跳转目标类型
+// 返回: bool 是否支持
+func pdfDestSupported(destType string) bool {
+	switch destType {
+	case "XYZ", "Fit", "FitH", "FitV", "FitR", "FitB", "FitBH", "FitBV":
+		return true
 	}
-	return canvas.Rect{}, false
+	return false
 }
 
-// pdfDestY 获取PDF大纲目标位置
-// 入参: dest OFD跳转目标, pageH 页面高度
-// 返回: float64 PDF纵坐标
-func pdfDestY(dest Dest, pageH float64) float64 {
+// pdfDestArray 生成PDF显式跳转目标
+// XYZ的缩放为0时保持当前缩放, FitB系列按页面内容边界适配
+// 入参: dest OFD跳转目标, pageH 页面高度, page 页面对象号
+// 返回: string PDF跳转目标数组
+func pdfDestArray(dest Dest, pageH float64, page int) string {
+	left := pdfNumber(dest.Left * ptPerMM)
+	top := pdfNumber((pageH - dest.Top) * ptPerMM)
 	switch dest.Type {
-	case "XYZ", "FitH", "FitR":
-		return pageH - dest.Top
+	case "XYZ":
+		zoom := "null"
+		if dest.Zoom > 0 {
+			zoom = pdfNumber(dest.Zoom)
+		}
+		return fmt.Sprintf("[%s/XYZ %s %s %s]", pdfRef(page), left, top, zoom)
+	case "FitH", "FitBH":
+		return fmt.Sprintf("[%s/%s %s]", pdfRef(page), dest.Type, top)
+	case "FitV", "FitBV":
+		return fmt.Sprintf("[%s/%s %s]", pdfRef(page), dest.Type, left)
+	case "FitR":
+		return fmt.Sprintf("[%s/FitR %s %s %s %s]", pdfRef(page), left, pdfNumber((pageH-dest.Bottom)*ptPerMM), pdfNumber(dest.Right*ptPerMM), top)
+	case "FitB":
+		return fmt.Sprintf("[%s/FitB]", pdfRef(page))
 	}
-	return 0
+	return fmt.Sprintf("[%s/Fit]", pdfRef(page))
 }
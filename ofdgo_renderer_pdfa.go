@@ -0,0 +1,207 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PDFAConformance PDF/A归档格式
+type PDFAConformance int
+
+const (
+	PDFANone PDFAConformance = iota // 普通PDF
+	PDFA2B                          // PDF/A-2b
+	PDFA3B                          // PDF/A-3b, 嵌入原始OFD及其附件
+)
+
+// WithPDFA 设置PDF导出的PDF/A归档格式
+// PDF/A模式下附加sRGB输出意图和由DocInfo生成的XMP元数据, PDF/A-3b还将原始OFD及其附件作为关联文件嵌入,
+// 文本须使用可嵌入的字体, 落到未嵌入的PDF标准字体时导出返回错误, 归档前建议使用veraPDF等工具校验
+// 入参: conformance 归档格式, 默认PDFANone
+// 返回: RendererOption 渲染选项
+func WithPDFA(conformance PDFAConformance) RendererOption {
+	return func(r *Renderer) {
+		r.pdfa = conformance
+	}
+}
+
+// part 获取PDF/A部分号
+// 返回: int 部分号, 普通PDF为0
+func (c PDFAConformance) part() int {
+	switch c {
+	case PDFA2B:
+		return 2
+	case PDFA3B:
+		return 3
+	}
+	return 0
+}
+
+// convertPDFA 补全PDF/A必需项
+// 字体须嵌入, 未设置标志的注释补充打印标志, 隐藏或不打印的注释从页面移除, 图像不得插值, 目录添加sRGB输出意图
+// 入参: f PDF改写器
+// 返回: error 错误信息
+func convertPDFA(f *pdfFile) error {
+	hidden := make(map[int]bool)
+	for num := 1; num < len(f.objects); num++ {
+		dict := f.dict(num)
+		if dict == "" {
+			continue
+		}
+		switch {
+		case pdfDictGet(dict, "Subtype") == "/Type1" && pdfDictGet(dict, "FontDescriptor") == "":
+			return fmt.Errorf("pdf/a requires embedded fonts: %s", strings.TrimPrefix(pdfDictGet(dict, "BaseFont"), "/"))
+		case pdfDictGet(dict, "Subtype") == "/Type0":
+			f.setDict(num, pdfAFontDict(dict, num))
+		case pdfDictGet(dict, "Type") == "/Annot":
+			value := pdfDictGet(dict, "F")
+			if value == "" {
+				f.setDict(num, pdfDictSet(dict, "F", strconv.Itoa(pdfAnnotPrint)))
+				continue
+			}
+			if flags, err := strconv.Atoi(value); err != nil || !pdfAAnnotationFlags(flags) {
+				hidden[num] = true
+			}
+		case pdfDictGet(dict, "Interpolate") == "true":
+			f.setDict(num, pdfDictSet(dict, "Interpolate", "false"))
+		}
+	}
+	if len(hidden) > 0 {
+		for _, page := range f.pages() {
+			f.removeAnnots(page, hidden)
+		}
+	}
+	profile := f.addStream("<</N 3>>", srgbICCProfile())
+	intent := f.add(fmt.Sprintf("<</Type/OutputIntent/S/GTS_PDFA1/OutputConditionIdentifier(sRGB IEC61966-2.1)/RegistryName(http://www.color.org)/Info(sRGB IEC61966-2.1)/DestOutputProfile %s>>", pdfRef(profile)))
+	f.setDict(f.root, pdfDictSet(f.dict(f.root), "OutputIntents", "["+pdfRef(intent)+"]"))
+	return nil
+}
+
+// pdfAFontDict 补全复合字体的PDF/A必需项
+// 子集字体名使用六位大写字母前缀, TrueType字形字体补充CIDToGIDMap
+// 入参: dict 复合字体字典, num 字体对象号
+// 返回: string 新字典
+func pdfAFontDict(dict string, num int) string {
+	tag := make([]byte, 6)
+	for i, n := len(tag)-1, num; i >= 0; i-- {
+		tag[i] = byte('A' + n%26)
+		n /= 26
+	}
+	dict = strings.ReplaceAll(dict, "/SUBSET+", "/"+string(tag)+"+")
+	descendants := pdfDictGet(dict, "DescendantFonts")
+	if !strings.HasPrefix(descendants, "[") || !strings.HasSuffix(descendants, "]") {
+		return dict
+	}
+	descendant := strings.TrimSpace(descendants[1 : len(descendants)-1])
+	if pdfDictGet(descendant, "Subtype") == "/CIDFontType2" && pdfDictGet(descendant, "CIDToGIDMap") == "" {
+		descendant = pdfDictSet(descendant, "CIDToGIDMap", "/Identity")
+		dict = pdfDictSet(dict, "DescendantFonts", "["+descendant+"]")
+	}
+	return dict
+}
+
+var (
+	srgbICCOnce sync.Once
+	srgbICCData []byte
+)
+
+// srgbICCProfile 获取sRGB ICC配置文件
+// 按IEC 61966-2.1生成ICC v2显示设备配置文件, 用作PDF/A输出意图
+// 返回: []byte ICC配置文件数据
+func srgbICCProfile() []byte {
+	srgbICCOnce.Do(func() {
+		text := func(sig string, s string) []byte {
+			data := append([]byte(sig), 0, 0, 0, 0)
+			return append(append(data, s...), 0)
+		}
+		desc := func(s string) []byte {
+			data := append([]byte("desc"), 0, 0, 0, 0)
+			data = binary.BigEndian.AppendUint32(data, uint32(len(s)+1))
+			data = append(append(data, s...), 0)
+			return append(data, make([]byte, 4+4+2+1+67)...)
+		}
+		xyz := func(x, y, z float64) []byte {
+			data := append([]byte("XYZ "), 0, 0, 0, 0)
+			for _, v := range []float64{x, y, z} {
+				data = binary.BigEndian.AppendUint32(data, uint32(int32(math.Round(v*65536))))
+			}
+			return data
+		}
+		curve := append([]byte("curv"), 0, 0, 0, 0)
+		curve = binary.BigEndian.AppendUint32(curve, 1024)
+		for i := 0; i < 1024; i++ {
+			v := float64(i) / 1023
+			if v <= 0.04045 {
+				v /= 12.92
+			} else {
+				v = math.Pow((v+0.055)/1.055, 2.4)
+			}
+			curve = binary.BigEndian.AppendUint16(curve, uint16(math.Round(v*65535)))
+		}
+		tags := []struct {
+			sig  string
+			data []byte
+		}{
+			{"desc", desc("sRGB IEC61966-2.1")},
+			{"cprt", text("text", "No copyright, use freely")},
+			{"wtpt", xyz(0.9505, 1.0, 1.0890)},
+			{"rXYZ", xyz(0.4360747, 0.2225045, 0.0139322)},
+			{"gXYZ", xyz(0.3850649, 0.7168786, 0.0971045)},
+			{"bXYZ", xyz(0.1430804, 0.0606169, 0.7141733)},
+			{"rTRC", curve},
+			{"gTRC", curve},
+			{"bTRC", curve},
+		}
+		table := make([]byte, 0, 4+12*len(tags))
+		table = binary.BigEndian.AppendUint32(table, uint32(len(tags)))
+		var data []byte
+		offsets := make(map[string]int)
+		base := 128 + 4 + 12*len(tags)
+		for _, tag := range tags {
+			key := string(tag.data)
+			offset, ok := offsets[key]
+			if !ok {
+				for len(data)%4 != 0 {
+					data = append(data, 0)
+				}
+				offset = base + len(data)
+				offsets[key] = offset
+				data = append(data, tag.data...)
+			}
+			table = append(table, tag.sig...)
+			table = binary.BigEndian.AppendUint32(table, uint32(offset))
+			table = binary.BigEndian.AppendUint32(table, uint32(len(tag.data)))
+		}
+		header := make([]byte, 128)
+		binary.BigEndian.PutUint32(header[0:], uint32(base+len(data)))
+		binary.BigEndian.PutUint32(header[8:], 0x02100000)
+		copy(header[12:], "mntrRGB XYZ ")
+		for i, v := range []uint16{2025, 1, 1, 0, 0, 0} {
+			binary.BigEndian.PutUint16(header[24+2*i:], v)
+		}
+		copy(header[36:], "acsp")
+		binary.BigEndian.PutUint32(header[68:], 0x0000F6D6)
+		binary.BigEndian.PutUint32(header[72:], 0x00010000)
+		binary.BigEndian.PutUint32(header[76:], 0x0000D32D)
+		srgbICCData = append(append(header, table...), data...)
+	})
+	return srgbICCData
+}
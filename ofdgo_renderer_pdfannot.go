@@ -0,0 +1,225 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/tdewolff/canvas"
+	"github.com/tdewolff/canvas/renderers/pdf"
+)
+
+// PDF注释标志位
+const (
+	pdfAnnotInvisible    = 1 << 0
+	pdfAnnotHidden       = 1 << 1
+	pdfAnnotPrint        = 1 << 2
+	pdfAnnotNoZoom       = 1 << 3
+	pdfAnnotNoRotate     = 1 << 4
+	pdfAnnotNoView       = 1 << 5
+	pdfAnnotReadOnly     = 1 << 6
+	pdfAnnotToggleNoView = 1 << 8
+)
+
+// pdfAnnotation PDF原生注释
+type pdfAnnotation struct {
+	Annotation Annotation
+	Rect       canvas.Rect
+	Target     pdfTarget
+}
+
+// pdfNativeAnnotationBox 判断注释能否导出为PDF原生注释
+// Link、Highlight和Stamp类型且外观有效的注释导出为原生注释, 其余注释绘制到页面内容
+// 入参: annotation 页面注释
+// 返回: Box 注释外观区域, bool 是否导出为原生注释
+func pdfNativeAnnotationBox(annotation Annotation) (Box, bool) {
+	switch annotation.Type {
+	case "Link", "Highlight", "Stamp":
+	default:
+		return Box{}, false
+	}
+	if len(annotation.Appearance.Objects) == 0 {
+		return Box{}, false
+	}
+	box, err := ParseBox(annotation.Appearance.Boundary)
+	if err != nil || box.W <= 0 || box.H <= 0 {
+		return Box{}, false
+	}
+	return box, true
+}
+
+// addAnnotation 添加PDF原生注释
+// 链接注释取外观对象上首个可导出的单击动作
+// 入参: renderer 渲染器, page 页面索引, annotation 页面注释, rect 注释区域, pageIndex 页面索引表, pages 页面数据
+func (n *pdfNavigation) addAnnotation(renderer *Renderer, page int, annotation Annotation, rect canvas.Rect, pageIndex map[string]int, pages []pdfPage) {
+	native := pdfAnnotation{Annotation: annotation, Rect: rect}
+	if annotation.Type == "Link" {
+	sources:
+		for _, source := range annotationActionSources([]Annotation{annotation}) {
+			for _, action := range source.Actions {
+				if action.Event != "CLICK" {
+					continue
+				}
+				if target, ok := n.actionTarget(renderer, action, pageIndex, pages); ok {
+					native.Target = target
+					break sources
+				}
+			}
+		}
+	}
+	n.Annot[page] = append(n.Annot[page], native)
+}
+
+// writePDFAnnotations 写入PDF原生注释
+// 注释外观单独渲染为表单XObject并作为正常外观流, PDF/A要求注释可见且可打印, 隐藏或不打印的注释不写入
+// 入参: f PDF改写器, navigation PDF导航信息, pages 页面索引到页面对象号的映射, files 嵌入文件
+// 返回: error 错误信息
+func (r *Renderer) writePDFAnnotations(f *pdfFile, navigation *pdfNavigation, pages map[int]int, files map[string]pdfEmbeddedFile) error {
+	for _, page := range navigation.written {
+		if _, ok := pages[page]; !ok || len(navigation.Annot[page]) == 0 {
+			continue
+		}
+		refs := make([]int, 0, len(navigation.Annot[page]))
+		for _, annotation := range navigation.Annot[page] {
+			if r.pdfa.part() > 0 && !pdfAAnnotationFlags(pdfAnnotationFlags(annotation.Annotation)) {
+				r.diagnose(DiagnosticInfo, annotation.Annotation.ID, "hidden or non-printing annotation omitted from pdf/a")
+				continue
+			}
+			num, err := r.writePDFAnnotation(f, annotation, pages[page], files)
+			if err != nil {
+				return err
+			}
+			refs = append(refs, num)
+		}
+		if len(refs) > 0 {
+			f.appendAnnots(pages[page], refs)
+		}
+	}
+	return nil
+}
+
+// writePDFAnnotation 写入单个PDF原生注释
+// 入参: f PDF改写器, annotation 原生注释, page 页面对象号, files 嵌入文件
+// 返回: int 注释对象号, error 错误信息
+func (r *Renderer) writePDFAnnotation(f *pdfFile, annotation pdfAnnotation, page int, files map[string]pdfEmbeddedFile) (int, error) {
+	box, _ := ParseBox(annotation.Annotation.Appearance.Boundary)
+	c := r.renderAnnotationAppearance(annotation.Annotation, box)
+	var buf bytes.Buffer
+	p := pdf.New(&buf, c.W, c.H, nil)
+	c.RenderTo(p)
+	if err := p.Close(); err != nil {
+		return 0, err
+	}
+	src, err := parsePDFFile(buf.Bytes())
+	if err != nil {
+		return 0, err
+	}
+	form, err := f.importPageForm(src)
+	if err != nil {
+		return 0, err
+	}
+	rect := annotation.Rect
+	x0, y0, x1, y1 := pdfNumber(rect.X0*ptPerMM), pdfNumber(rect.Y0*ptPerMM), pdfNumber(rect.X1*ptPerMM), pdfNumber(rect.Y1*ptPerMM)
+	a := annotation.Annotation
+	dict := fmt.Sprintf("<</Type/Annot/Subtype/%s/Rect[%s %s %s %s]/F %d/P %s/AP<</N %s>>>>", a.Type, x0, y0, x1, y1, pdfAnnotationFlags(a), pdfRef(page), pdfRef(form))
+	if a.ID != "" {
+		dict = pdfDictSet(dict, "NM", pdfTextString(a.ID))
+	}
+	if a.Creator != "" {
+		dict = pdfDictSet(dict, "T", pdfTextString(a.Creator))
+	}
+	if a.Remark != "" {
+		dict = pdfDictSet(dict, "Contents", pdfTextString(a.Remark))
+	}
+	if t := parseOFDDate(a.LastModDate); !t.IsZero() {
+		dict = pdfDictSet(dict, "M", "("+formatPDFDate(t)+")")
+	}
+	switch a.Type {
+	case "Link":
+		dict = pdfLinkDict(dict, annotation.Target, files)
+	case "Highlight":
+		dict = pdfDictSet(dict, "QuadPoints", "["+strings.Join([]string{x0, y1, x1, y1, x0, y0, x1, y0}, " ")+"]")
+	}
+	return f.add(dict), nil
+}
+
+// pdfLinkDict 补全链接注释的跳转动作
+// 非PDF附件无法通过动作打开, 链接注释改为带相同外观的文件附件注释
+// 入参: dict 注释字典, target 动作目标, files 嵌入文件
+// 返回: string 新字典
+func pdfLinkDict(dict string, target pdfTarget, files map[string]pdfEmbeddedFile) string {
+	if file, ok := files[target.AttachID]; ok && target.AttachID != "" && !file.PDF {
+		dict = pdfDictSet(dict, "Subtype", "/FileAttachment")
+		return pdfDictSet(pdfDictSet(dict, "FS", pdfRef(file.Spec)), "Name", "/Paperclip")
+	}
+	dict = pdfDictSet(dict, "Border", "[0 0 0]")
+	if target.Dest != "" {
+		return pdfDictSet(dict, "Dest", pdfByteString(target.Dest))
+	}
+	if action := pdfTargetAction(target, nil, files); action != "" {
+		return pdfDictSet(dict, "A", action)
+	}
+	return dict
+}
+
+// pdfAnnotationFlags 转换注释标志
+// 入参: annotation 页面注释
+// 返回: int PDF注释标志
+func pdfAnnotationFlags(annotation Annotation) int {
+	flags := 0
+	if annotation.Visible != nil && !*annotation.Visible {
+		flags |= pdfAnnotHidden
+	}
+	if annotation.Print == nil || *annotation.Print {
+		flags |= pdfAnnotPrint
+	}
+	if annotation.NoZoom {
+		flags |= pdfAnnotNoZoom
+	}
+	if annotation.NoRotate {
+		flags |= pdfAnnotNoRotate
+	}
+	if annotation.Type != "Link" && (annotation.ReadOnly == nil || *annotation.ReadOnly) {
+		flags |= pdfAnnotReadOnly
+	}
+	return flags
+}
+
+// pdfAAnnotationFlags 判断注释标志是否符合PDF/A要求
+// 入参: flags PDF注释标志
+// 返回: bool 可打印且未设置任何隐藏标志时为true
+func pdfAAnnotationFlags(flags int) bool {
+	return flags&pdfAnnotPrint != 0 && flags&(pdfAnnotInvisible|pdfAnnotHidden|pdfAnnotNoView|pdfAnnotToggleNoView) == 0
+}
+
+// renderAnnotationAppearance 渲染注释外观
+// 入参: annotation 页面注释, box 注释外观区域
+// 返回: *canvas.Canvas 注释外观画布
+func (r *Renderer) renderAnnotationAppearance(annotation Annotation, box Box) *canvas.Canvas {
+	renderer, release := r.acquireState()
+	defer release()
+	renderer.budget = newRenderBudget(r.ctx, r.limits)
+	renderer.textLayer = r.pdfTextLayer
+	renderer.textLayerGlyphs = newTextLayerGlyphFonts()
+	c := canvas.New(box.W, box.H)
+	ctx := canvas.NewContext(c)
+	ctm := Matrix{a: 1, d: 1}
+	for _, obj := range annotation.Appearance.Objects {
+		renderer.renderObject(ctx, obj, box.H, nil, nil, 0, &ctm, false, nil)
+	}
+	return c
+}
@@ -0,0 +1,353 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// pdfMetadata PDF文档元数据
+type pdfMetadata struct {
+	Title    string
+	Author   string
+	Subject  string
+	Keywords []string
+	DocID    string
+	Created  time.Time
+	Modified time.Time
+}
+
+// pdfMetadata 由DocInfo生成PDF文档元数据
+// 未记录创建时间时沿用PDF写出时间
+// 入参: f PDF改写器
+// 返回: *pdfMetadata 文档元数据
+func (r *Renderer) pdfMetadata(f *pdfFile) *pdfMetadata {
+	meta := &pdfMetadata{}
+	if info, err := r.Reader.DocInfo(); err == nil {
+		meta.Title = strings.TrimSpace(info.Title)
+		meta.Author = strings.TrimSpace(info.Author)
+		meta.Subject = strings.TrimSpace(info.Subject)
+		meta.DocID = strings.TrimSpace(info.DocID)
+		if info.Keywords != nil {
+			for _, keyword := range info.Keywords.Keyword {
+				if keyword = strings.TrimSpace(keyword); keyword != "" {
+					meta.Keywords = append(meta.Keywords, keyword)
+				}
+			}
+		}
+		meta.Created = parseOFDDate(info.CreationDate)
+		meta.Modified = parseOFDDate(info.ModDate)
+	}
+	if meta.Created.IsZero() {
+		meta.Created = parsePDFDate(strings.Trim(pdfDictGet(f.dict(f.info), "CreationDate"), "()"))
+	}
+	if meta.Created.IsZero() {
+		meta.Created = time.Now()
+	}
+	if meta.Modified.IsZero() {
+		meta.Modified = meta.Created
+	}
+	meta.Created = meta.Created.Truncate(time.Second)
+	meta.Modified = meta.Modified.Truncate(time.Second)
+	return meta
+}
+
+// infoDict 生成文档信息字典
+// 返回: string 文档信息字典
+func (m *pdfMetadata) infoDict() string {
+	var sb strings.Builder
+	sb.WriteString("<<")
+	for _, entry := range [][2]string{{"Title", m.Title}, {"Author", m.Author}, {"Subject", m.Subject}, {"Keywords", strings.Join(m.Keywords, ", ")}} {
+		if entry[1] != "" {
+			sb.WriteString("/" + entry[0] + " " + pdfTextString(entry[1]))
+		}
+	}
+	sb.WriteString("/Creator(xiaoqidun/ofdgo)/Producer(xiaoqidun/ofdgo)")
+	sb.WriteString("/CreationDate(" + formatPDFDate(m.Created) + ")")
+	sb.WriteString("/ModDate(" + formatPDFDate(m.Modified) + ")")
+	sb.WriteString(">>")
+	return sb.String()
+}
+
+// xmp 生成XMP元数据包
+// 入参: part PDF/A部分号, 为0时不写入PDF/A标识
+// 返回: []byte XMP元数据包
+func (m *pdfMetadata) xmp(part int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("<?xpacket begin=\"\uFEFF\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>\n")
+	buf.WriteString("<x:xmpmeta xmlns:x=\"adobe:ns:meta/\">\n")
+	buf.WriteString("<rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">\n")
+	buf.WriteString("<rdf:Description rdf:about=\"\"")
+	buf.WriteString(" xmlns:pdfaid=\"http://www.aiim.org/pdfa/ns/id/\"")
+	buf.WriteString(" xmlns:dc=\"http://purl.org/dc/elements/1.1/\"")
+	buf.WriteString(" xmlns:pdf=\"http://ns.adobe.com/pdf/1.3/\"")
+	buf.WriteString(" xmlns:xmp=\"http://ns.adobe.com/xap/1.0/\"")
+	buf.WriteString(" xmlns:xmpMM=\"http://ns.adobe.com/xap/1.0/mm/\">\n")
+	if part > 0 {
+		fmt.Fprintf(&buf, "<pdfaid:part>%d</pdfaid:part>\n<pdfaid:conformance>B</pdfaid:conformance>\n", part)
+	}
+	buf.WriteString("<dc:format>application/pdf</dc:format>\n")
+	if m.Title != "" {
+		buf.WriteString("<dc:title><rdf:Alt><rdf:li xml:lang=\"x-default\">" + xmlEscapeString(m.Title) + "</rdf:li></rdf:Alt></dc:title>\n")
+	}
+	if m.Author != "" {
+		buf.WriteString("<dc:creator><rdf:Seq><rdf:li>" + xmlEscapeString(m.Author) + "</rdf:li></rdf:Seq></dc:creator>\n")
+	}
+	if m.Subject != "" {
+		buf.WriteString("<dc:description><rdf:Alt><rdf:li xml:lang=\"x-default\">" + xmlEscapeString(m.Subject) + "</rdf:li></rdf:Alt></dc:description>\n")
+	}
+	if len(m.Keywords) > 0 {
+		buf.WriteString("<pdf:Keywords>" + xmlEscapeString(strings.Join(m.Keywords, ", ")) + "</pdf:Keywords>\n")
+	}
+	buf.WriteString("<pdf:Producer>xiaoqidun/ofdgo</pdf:Producer>\n")
+	buf.WriteString("<xmp:CreatorTool>xiaoqidun/ofdgo</xmp:CreatorTool>\n")
+	buf.WriteString("<xmp:CreateDate>" + m.Created.Format(time.RFC3339) + "</xmp:CreateDate>\n")
+	buf.WriteString("<xmp:ModifyDate>" + m.Modified.Format(time.RFC3339) + "</xmp:ModifyDate>\n")
+	if m.DocID != "" {
+		buf.WriteString("<xmpMM:DocumentID>" + xmlEscapeString(m.DocID) + "</xmpMM:DocumentID>\n")
+	}
+	buf.WriteString("</rdf:Description>\n</rdf:RDF>\n</x:xmpmeta>\n")
+	buf.WriteString("<?xpacket end=\"w\"?>")
+	return buf.Bytes()
+}
+
+// writePDFMetadata 写入文档信息字典和XMP元数据
+// 入参: f PDF改写器, part PDF/A部分号, 普通PDF为0
+func (r *Renderer) writePDFMetadata(f *pdfFile, part int) {
+	meta := r.pdfMetadata(f)
+	info := meta.infoDict()
+	if f.info > 0 {
+		f.objects[f.info] = []byte(info)
+	} else {
+		f.info = f.add(info)
+	}
+	metadata := f.addRawStream("<</Type/Metadata/Subtype/XML>>", meta.xmp(part))
+	f.setDict(f.root, pdfDictSet(f.dict(f.root), "Metadata", pdfRef(metadata)))
+}
+
+// embedPDFFiles 嵌入附件
+// PDF/A-3b额外嵌入原始OFD并作为关联文件, PDF/A-2b不允许嵌入非PDF/A文件, 附件不嵌入
+// 入参: f PDF改写器, part PDF/A部分号, 普通PDF为0
+// 返回: map[string]pdfEmbeddedFile 按附件标识索引的嵌入文件, error 错误信息
+func (r *Renderer) embedPDFFiles(f *pdfFile, part int) (map[string]pdfEmbeddedFile, error) {
+	attachments, err := r.Reader.Attachments()
+	if err != nil {
+		attachments = nil
+	}
+	if part == 2 {
+		if len(attachments) > 0 {
+			r.diagnose(DiagnosticWarning, "", "attachments are not embedded in pdf/a-2b")
+		}
+		return nil, nil
+	}
+	modified := time.Now()
+	if info, err := r.Reader.DocInfo(); err == nil {
+		if t := parseOFDDate(info.ModDate); !t.IsZero() {
+			modified = t
+		} else if t := parseOFDDate(info.CreationDate); !t.IsZero() {
+			modified = t
+		}
+	}
+	relationship := func(value string) string {
+		if part == 3 {
+			return value
+		}
+		return ""
+	}
+	var files []int
+	embedded := make(map[string]pdfEmbeddedFile)
+	if part == 3 {
+		source, err := r.Reader.sourcePackage()
+		if err != nil {
+			return nil, err
+		}
+		name := "document.ofd"
+		if r.Reader.Path != "" {
+			name = filepath.Base(r.Reader.Path)
+		}
+		files = append(files, addPDFEmbeddedFile(f, name, "application/ofd", relationship("Source"), "OFD source document", source, modified))
+	}
+	for _, attachment := range attachments {
+		if attachment.FileLoc == "" {
+			continue
+		}
+		data, err := r.Reader.readFile(attachment.FileLoc)
+		if err != nil {
+			r.diagnose(DiagnosticWarning, attachment.ID, "attachment not embedded: %v", err)
+			continue
+		}
+		fileName, mediaType := attachmentFileType(attachment)
+		date := parseOFDDate(attachment.ModDate)
+		if date.IsZero() {
+			date = parseOFDDate(attachment.CreationDate)
+		}
+		if date.IsZero() {
+			date = modified
+		}
+		spec := addPDFEmbeddedFile(f, fileName, mediaType, relationship("Unspecified"), attachment.Name, data, date)
+		embedded[attachment.ID] = pdfEmbeddedFile{Spec: spec, Key: fmt.Sprintf("%04d", len(files)), PDF: mediaType == "application/pdf"}
+		files = append(files, spec)
+	}
+	if len(files) == 0 {
+		return nil, nil
+	}
+	refs := make([]string, len(files))
+	names := make([]string, len(files))
+	for i, num := range files {
+		refs[i] = pdfRef(num)
+		names[i] = fmt.Sprintf("(%04d) %s", i, refs[i])
+	}
+	catalog := f.dict(f.root)
+	if part == 3 {
+		catalog = pdfDictSet(catalog, "AF", "["+strings.Join(refs, " ")+"]")
+	}
+	nameTree := pdfDictGet(catalog, "Names")
+	if nameTree == "" {
+		nameTree = "<<>>"
+	}
+	nameTree = pdfDictSet(nameTree, "EmbeddedFiles", "<</Names["+strings.Join(names, " ")+"]>>")
+	f.setDict(f.root, pdfDictSet(catalog, "Names", nameTree))
+	return embedded, nil
+}
+
+// pdfEmbeddedFile PDF嵌入文件
+type pdfEmbeddedFile struct {
+	Spec int    // 文件规范对象号
+	Key  string // 嵌入文件名称树中的键
+	PDF  bool   // 是否为PDF文件
+}
+
+// addPDFEmbeddedFile 添加嵌入文件及其文件规范
+// 入参: f PDF改写器, name 文件名, mediaType 媒体类型, relationship 关联关系, 为空时不写入, desc 描述, data 文件数据, modified 修改时间
+// 返回: int 文件规范对象号
+func addPDFEmbeddedFile(f *pdfFile, name, mediaType, relationship, desc string, data []byte, modified time.Time) int {
+	stream := f.addStream(fmt.Sprintf("<</Type/EmbeddedFile/Subtype%s/Params<</Size %d/ModDate(%s)>>>>", pdfNameString(mediaType), len(data), formatPDFDate(modified)), data)
+	spec := fmt.Sprintf("<</Type/Filespec/F %s/UF %s/EF<</F %s/UF %s>>>>", pdfTextString(name), pdfTextString(name), pdfRef(stream), pdfRef(stream))
+	if relationship != "" {
+		spec = pdfDictSet(spec, "AFRelationship", "/"+relationship)
+	}
+	if desc != "" {
+		spec = pdfDictSet(spec, "Desc", pdfTextString(desc))
+	}
+	return f.add(spec)
+}
+
+// sourcePackage 获取原始OFD包数据
+// 优先使用原文件或原始数据, 由文件系统创建时按原始数据重新打包全部包内文件
+// 返回: []byte OFD包数据, error 错误信息
+func (r *Reader) sourcePackage() ([]byte, error) {
+	if r.Path != "" {
+		if data, err := os.ReadFile(r.Path); err == nil {
+			return data, nil
+		}
+	}
+	if r.source != nil {
+		data := make([]byte, r.source.Size())
+		if _, err := r.source.ReadAt(data, 0); err != nil && err != io.EOF {
+			return nil, err
+		}
+		return data, nil
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, name := range r.packageEntries() {
+		if err := copyPackageEntry(zw, r.fileIndex[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// parseOFDDate 解析OFD日期
+// 不带时区的日期按本地时区解析
+// 入参: value 日期文本
+// 返回: time.Time 日期, 无法解析时为零值
+func parseOFDDate(value string) time.Time {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// parsePDFDate 解析PDF日期
+// 入参: value 日期文本, 如D:20060102150405+08'00'
+// 返回: time.Time 日期, 无法解析时为零值
+func parsePDFDate(value string) time.Time {
+	value = strings.ReplaceAll(strings.TrimPrefix(value, "D:"), "'", "")
+	for _, layout := range []string{"20060102150405Z0700", "20060102150405Z07", "20060102150405"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// formatPDFDate 格式化PDF日期
+// 入参: t 时间
+// 返回: string PDF日期文本
+func formatPDFDate(t time.Time) string {
+	_, offset := t.Zone()
+	if offset == 0 {
+		return t.Format("D:20060102150405Z")
+	}
+	sign := byte('+')
+	if offset < 0 {
+		sign, offset = '-', -offset
+	}
+	return fmt.Sprintf("%s%c%02d'%02d'", t.Format("D:20060102150405"), sign, offset/3600, offset%3600/60)
+}
+
+// attachmentFileType 获取附件文件名和媒体类型
+// 附件名称缺少扩展名时按Format补全, 媒体类型按扩展名推断
+// 入参: attachment 附件
+// 返回: string 文件名, string 媒体类型
+func attachmentFileType(attachment Attachment) (string, string) {
+	fileName := attachment.Name
+	if fileName == "" {
+		fileName = path.Base(attachment.FileLoc)
+	}
+	if path.Ext(fileName) == "" && attachment.Format != "" {
+		fileName += "." + strings.ToLower(attachment.Format)
+	}
+	mediaType := mime.TypeByExtension(path.Ext(fileName))
+	if i := strings.IndexByte(mediaType, ';'); i >= 0 {
+		mediaType = mediaType[:i]
+	}
+	if mediaType == "" {
+		mediaType = "application/octet-stream"
+	}
+	return fileName, mediaType
+}
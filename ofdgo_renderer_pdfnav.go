@@ -0,0 +1,169 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// writePDFNavigation 写入PDF导航信息
+// 包括命名跳转目标、大纲、附件链接、打开动作和页码标签
+// 入参: f PDF改写器, navigation PDF导航信息, pages 页面索引到页面对象号的映射, files 嵌入文件
+func (r *Renderer) writePDFNavigation(f *pdfFile, navigation *pdfNavigation, pages map[int]int, files map[string]pdfEmbeddedFile) {
+	dests := make(map[string]string, len(navigation.Dest))
+	for name, dest := range navigation.Dest {
+		if page, ok := pages[dest.Page]; ok {
+			dests[name] = pdfDestArray(dest.Dest, dest.PageH, page)
+		}
+	}
+	catalog := f.dict(f.root)
+	if len(dests) > 0 {
+		names := make([]string, 0, len(dests))
+		for name := range dests {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		items := make([]string, 0, len(names))
+		for _, name := range names {
+			items = append(items, pdfByteString(name)+" "+dests[name])
+		}
+		nameTree := pdfDictGet(catalog, "Names")
+		if nameTree == "" {
+			nameTree = "<<>>"
+		}
+		nameTree = pdfDictSet(nameTree, "Dests", "<</Names["+strings.Join(items, " ")+"]>>")
+		catalog = pdfDictSet(catalog, "Names", nameTree)
+	}
+	if len(navigation.Outline) > 0 {
+		root := f.add("<</Type/Outlines>>")
+		first, last, count := writePDFOutlines(f, navigation.Outline, root, dests, files)
+		if first > 0 {
+			f.setDict(root, fmt.Sprintf("<</Type/Outlines/First %s/Last %s/Count %d>>", pdfRef(first), pdfRef(last), count))
+			catalog = pdfDictSet(pdfDictSet(catalog, "Outlines", pdfRef(root)), "PageMode", "/UseOutlines")
+		}
+	}
+	if navigation.Open != nil {
+		if open := pdfTargetAction(*navigation.Open, dests, files); open != "" {
+			catalog = pdfDictSet(catalog, "OpenAction", open)
+		}
+	}
+	if labels := pdfPageLabels(navigation.written); labels != "" {
+		catalog = pdfDictSet(catalog, "PageLabels", labels)
+	}
+	f.setDict(f.root, catalog)
+	for _, index := range navigation.written {
+		page, ok := pages[index]
+		if !ok {
+			continue
+		}
+		var refs []int
+		for _, link := range navigation.Link[index] {
+			if _, ok := files[link.Target.AttachID]; !ok || link.Target.AttachID == "" {
+				continue
+			}
+			rect := link.Rect
+			w, h := (rect.X1-rect.X0)*ptPerMM, (rect.Y1-rect.Y0)*ptPerMM
+			form := f.addStream(fmt.Sprintf("<</Type/XObject/Subtype/Form/BBox[0 0 %s %s]>>", pdfNumber(w), pdfNumber(h)), nil)
+			dict := fmt.Sprintf("<</Type/Annot/Subtype/Link/Rect[%s %s %s %s]/F %d/P %s/AP<</N %s>>>>",
+				pdfNumber(rect.X0*ptPerMM), pdfNumber(rect.Y0*ptPerMM), pdfNumber(rect.X1*ptPerMM), pdfNumber(rect.Y1*ptPerMM), pdfAnnotPrint, pdfRef(page), pdfRef(form))
+			refs = append(refs, f.add(pdfLinkDict(dict, link.Target, files)))
+		}
+		if len(refs) > 0 {
+			f.appendAnnots(page, refs)
+		}
+	}
+}
+
+// writePDFOutlines 写入同级大纲项
+// 展开的大纲项计数为可见后代数, 收起的大纲项计数取其相反数
+// 入参: f PDF改写器, outlines 同级大纲, parent 父节点对象号, dests 命名跳转目标, files 嵌入文件
+// 返回: int 首项对象号, int 末项对象号, int 可见大纲项数
+func writePDFOutlines(f *pdfFile, outlines []pdfOutline, parent int, dests map[string]string, files map[string]pdfEmbeddedFile) (int, int, int) {
+	nums := make([]int, len(outlines))
+	for i := range outlines {
+		nums[i] = f.add("<<>>")
+	}
+	count := 0
+	for i, outline := range outlines {
+		dict := fmt.Sprintf("<</Title %s/Parent %s>>", pdfTextString(outline.Title), pdfRef(parent))
+		if i > 0 {
+			dict = pdfDictSet(dict, "Prev", pdfRef(nums[i-1]))
+		}
+		if i+1 < len(nums) {
+			dict = pdfDictSet(dict, "Next", pdfRef(nums[i+1]))
+		}
+		if _, ok := dests[outline.Target.Dest]; ok {
+			dict = pdfDictSet(dict, "Dest", pdfByteString(outline.Target.Dest))
+		} else if action := pdfTargetAction(outline.Target, dests, files); action != "" {
+			dict = pdfDictSet(dict, "A", action)
+		}
+		count++
+		if first, last, descendants := writePDFOutlines(f, outline.Children, nums[i], dests, files); first > 0 {
+			dict = pdfDictSet(pdfDictSet(dict, "First", pdfRef(first)), "Last", pdfRef(last))
+			if outline.Expanded {
+				dict = pdfDictSet(dict, "Count", fmt.Sprint(descendants))
+				count += descendants
+			} else {
+				dict = pdfDictSet(dict, "Count", fmt.Sprint(-descendants))
+			}
+		}
+		f.setDict(nums[i], dict)
+	}
+	if len(nums) == 0 {
+		return 0, 0, 0
+	}
+	return nums[0], nums[len(nums)-1], count
+}
+
+// pdfTargetAction 生成动作目标对应的PDF动作
+// 非PDF附件无法由动作打开, 返回空
+// 入参: target 动作目标, dests 命名跳转目标, files 嵌入文件
+// 返回: string PDF动作或显式跳转目标, 无可用动作时为空
+func pdfTargetAction(target pdfTarget, dests map[string]string, files map[string]pdfEmbeddedFile) string {
+	switch {
+	case target.Dest != "":
+		return dests[target.Dest]
+	case target.URI != "":
+		return "<</S/URI/URI " + pdfByteString(target.URI) + ">>"
+	case target.AttachID != "":
+		if file, ok := files[target.AttachID]; ok && file.PDF {
+			return "<</S/GoToE/D[0/Fit]/T<</R/C/N " + pdfTextString(file.Key) + ">>>>"
+		}
+	}
+	return ""
+}
+
+// pdfPageLabels 生成页码标签
+// 导出部分页面时页码标签沿用原文档页码, 连续导出全部页面时无需页码标签
+// 入参: written 按写出顺序排列的原页面索引
+// 返回: string 页码标签数字树, 无需页码标签时为空
+func pdfPageLabels(written []int) string {
+	var nums []string
+	for i, page := range written {
+		if i == 0 && page == 0 || i > 0 && page == written[i-1]+1 {
+			if i == 0 {
+				nums = append(nums, "0<</S/D>>")
+			}
+			continue
+		}
+		nums = append(nums, fmt.Sprintf("%d<</S/D/St %d>>", i, page+1))
+	}
+	if len(nums) <= 1 && (len(written) == 0 || written[0] == 0) {
+		return ""
+	}
+	return "<</Nums[" + strings.Join(nums, " ") + "]>>"
+}
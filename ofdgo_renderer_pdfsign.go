@@ -0,0 +1,238 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/tdewolff/canvas"
+	"github.com/tdewolff/canvas/renderers/pdf"
+)
+
+const (
+	pdfSignatureBadgeSize  = 2.5
+	pdfSignatureNoticeSize = 2.5
+	pdfSignatureReportDest = "ofdgo-signature-report"
+)
+
+// pdfSignatureTexts PDF签章状态标记文本
+var pdfSignatureTexts = map[string][2]string{
+	"valid":      {"签章验证有效", "Signature valid"},
+	"untrusted":  {"签章完整但证书未获信任", "Intact, certificate not trusted"},
+	"unverified": {"签章完整但证书信任未验证", "Intact, certificate trust not verified"},
+	"policy":     {"签章完整但时间或吊销检查未通过", "Intact, time or revocation check failed"},
+	"invalid":    {"签章验证失败", "Signature invalid"},
+	"notice":     {"本PDF文件未经数字签名, 签章状态标记仅为导出时OFD签名验证结果的可视化表示", "This PDF is not digitally signed. Seal status marks only visualize the OFD signature verification performed at export time."},
+}
+
+// pdfSignatureStatus PDF签章状态标记设置
+type pdfSignatureStatus struct {
+	lang SignatureReportLang
+	opts []SignatureVerifyOption
+}
+
+// WithPDFSignatureStatus 设置PDF导出时标记签章验证状态
+// 导出前验证文档签名, 在各签章外观位置绘制验证状态标记并在文末追加签名验证报告页,
+// 标记所在页面和报告页均注明PDF本身未经签名, 标记仅为验证结果的可视化表示
+// 入参: lang 标记和报告语言, opts 签名验证选项
+// 返回: RendererOption 渲染选项
+func WithPDFSignatureStatus(lang SignatureReportLang, opts ...SignatureVerifyOption) RendererOption {
+	return func(r *Renderer) {
+		r.pdfSignature = &pdfSignatureStatus{lang: lang, opts: opts}
+	}
+}
+
+// pdfSignatureBadge 签章状态标记
+type pdfSignatureBadge struct {
+	Box    Box
+	Entry  int
+	Status int
+	Key    string
+}
+
+// pdfSignatureOverlay PDF签章状态标记
+type pdfSignatureOverlay struct {
+	renderer *Renderer
+	lang     SignatureReportLang
+	audit    SignatureAuditReport
+	badges   map[string][]pdfSignatureBadge
+	font     *canvas.FontFamily
+	release  func()
+}
+
+// newPDFSignatureOverlay 验证文档签名并创建签章状态标记
+// 返回: *pdfSignatureOverlay 签章状态标记, 未设置时为nil, error 错误信息
+func (r *Renderer) newPDFSignatureOverlay() (*pdfSignatureOverlay, error) {
+	if r.pdfSignature == nil {
+		return nil, nil
+	}
+	reports, err := r.Reader.VerifySignaturesContext(r.Context(), r.pdfSignature.opts...)
+	if err != nil {
+		return nil, err
+	}
+	renderer, release := r.acquireState()
+	font := renderer.signatureReportFont()
+	if font == nil {
+		release()
+		return nil, fmt.Errorf("no font available for signature report")
+	}
+	overlay := &pdfSignatureOverlay{
+		renderer: renderer,
+		lang:     r.pdfSignature.lang,
+		audit:    NewSignatureAuditReport(reports),
+		badges:   make(map[string][]pdfSignatureBadge),
+		font:     font,
+		release:  release,
+	}
+	for i, entry := range overlay.audit.Signatures {
+		status, key := pdfSignatureBadgeStatus(entry)
+		for _, stamp := range entry.Stamps {
+			badge := pdfSignatureBadge{Box: Box{X: stamp.X, Y: stamp.Y, W: stamp.Width, H: stamp.Height}, Entry: i, Status: status, Key: key}
+			overlay.badges[stamp.PageID] = append(overlay.badges[stamp.PageID], badge)
+		}
+	}
+	return overlay, nil
+}
+
+// close 释放签章状态标记占用的渲染状态
+func (o *pdfSignatureOverlay) close() {
+	if o != nil {
+		o.release()
+	}
+}
+
+// text 获取签章状态标记本地化文本
+// 入参: key 文本键
+// 返回: string 本地化文本
+func (o *pdfSignatureOverlay) text(key string) string {
+	return signatureReportText(pdfSignatureTexts, key, o.lang)
+}
+
+// drawBadges 在页面画布上绘制签章状态标记
+// 标记包括外观位置边框和状态标签, 有标记的页面底部注明标记仅为可视化表示
+// 入参: c 页面画布, pageID 页面标识
+func (o *pdfSignatureOverlay) drawBadges(c *canvas.Canvas, pageID string) {
+	if o == nil || len(o.badges[pageID]) == 0 {
+		return
+	}
+	ctx := canvas.NewContext(c)
+	pageH := c.H
+	for _, badge := range o.badges[pageID] {
+		clr := pdfSignatureColor(badge.Status)
+		box := badge.Box
+		ctx.Push()
+		ctx.SetFillColor(canvas.Transparent)
+		ctx.SetStrokeColor(clr)
+		ctx.SetStrokeWidth(0.5)
+		ctx.DrawPath(box.X-1, pageH-(box.Y+box.H+1), canvas.Rectangle(box.W+2, box.H+2))
+		ctx.Pop()
+		label := fmt.Sprintf("[%d] %s", badge.Entry+1, o.text(badge.Key))
+		w := signatureReportTextWidth(label, pdfSignatureBadgeSize) + 2
+		h := pdfSignatureBadgeSize * 1.6
+		top := box.Y - 1 - h
+		if top < 0 {
+			top = box.Y + box.H + 1
+		}
+		ctx.Push()
+		ctx.SetFillColor(clr)
+		ctx.SetStrokeColor(canvas.Transparent)
+		ctx.DrawPath(box.X-1, pageH-(top+h), canvas.Rectangle(w, h))
+		ctx.Pop()
+		face := o.font.Face(pdfSignatureBadgeSize*ptPerMM, canvas.White, canvas.FontRegular, canvas.FontNormal)
+		ctx.DrawText(box.X, pageH-(top+pdfSignatureBadgeSize*1.2), canvas.NewTextLine(face, label, canvas.Left))
+	}
+	o.drawNotice(ctx, c.W)
+}
+
+// drawNotice 在页面底部绘制可视化表示声明
+// 入参: ctx 画布上下文, pageW 页面宽度
+func (o *pdfSignatureOverlay) drawNotice(ctx *canvas.Context, pageW float64) {
+	face := o.font.Face(pdfSignatureNoticeSize*ptPerMM, color.RGBA{R: 200, A: 255}, canvas.FontRegular, canvas.FontNormal)
+	lines := wrapSignatureReportText(o.text("notice"), pdfSignatureNoticeSize, pageW-10)
+	for i, line := range lines {
+		y := pdfSignatureNoticeSize * 1.6 * float64(len(lines)-i)
+		ctx.DrawText(5, y, canvas.NewTextLine(face, line, canvas.Left))
+	}
+}
+
+// appendReport 在PDF末尾追加签名验证报告页
+// 报告页作为命名跳转目标加入大纲
+// 入参: p PDF渲染器, navigation PDF导航信息, index 报告首页的页面索引
+func (o *pdfSignatureOverlay) appendReport(p *pdf.PDF, navigation *pdfNavigation, index int) {
+	if o == nil {
+		return
+	}
+	for i, items := range o.renderer.signatureReportLayout(o.audit, o.lang) {
+		p.NewPage(signatureReportPageW, signatureReportPageH)
+		c := canvas.New(signatureReportPageW, signatureReportPageH)
+		ctx := canvas.NewContext(c)
+		ctx.SetFillColor(canvas.White)
+		ctx.DrawPath(0, 0, canvas.Rectangle(signatureReportPageW, signatureReportPageH))
+		for _, item := range items {
+			drawSignatureReportItem(ctx, o.font, item)
+		}
+		o.drawNotice(ctx, signatureReportPageW)
+		navigation.apply(p, index+i)
+		c.RenderTo(p)
+	}
+	navigation.Dest[pdfSignatureReportDest] = pdfDest{Page: index, PageH: signatureReportPageH, Dest: Dest{Type: "Fit"}}
+	navigation.Outline = append(navigation.Outline, pdfOutline{
+		Title:  signatureReportText(signatureReportTexts, "title", o.lang),
+		Target: pdfTarget{Dest: pdfSignatureReportDest},
+	})
+}
+
+// pdfSignatureBadgeStatus 获取签章状态标记的状态和文本键
+// 仅可信有效的签名标记为有效, 完整但未获信任、信任未验证或时间、吊销检查未通过的签名分别标记
+// 入参: entry 单个签名审计信息
+// 返回: int 状态, 1为有效, 0为完整但未获信任, -1为无效, string 文本键
+func pdfSignatureBadgeStatus(entry SignatureAuditEntry) (int, string) {
+	if entry.TrustedValid {
+		return 1, "valid"
+	}
+	if !entry.IntegrityValid {
+		return -1, "invalid"
+	}
+	key := "unverified"
+	for _, check := range entry.Checks {
+		if !check.Checked || check.OK {
+			continue
+		}
+		switch check.Name {
+		case "certTrust":
+			if key == "unverified" {
+				key = "untrusted"
+			}
+		case "signatureTime", "sealTime", "sealCertTime", "certTime", "revocation":
+			key = "policy"
+		}
+	}
+	return 0, key
+}
+
+// pdfSignatureColor 获取签章状态标记颜色
+// 入参: status 状态, 1有效, 0完整但不可信, -1无效
+// 返回: color.RGBA 颜色
+func pdfSignatureColor(status int) color.RGBA {
+	switch status {
+	case 1:
+		return color.RGBA{G: 128, A: 255}
+	case 0:
+		return color.RGBA{R: 200, G: 120, A: 255}
+	default:
+		return color.RGBA{R: 200, A: 255}
+	}
+}
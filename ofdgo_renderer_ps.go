@@ -0,0 +1,752 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/ascii85"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/tdewolff/canvas"
+	"github.com/tdewolff/font"
+)
+
+// psProlog PostScript文档公共过程
+// Type 3字体的BuildGlyph和BuildChar过程由各页面字体共用
+const psProlog = `%%BeginProlog
+%%BeginResource: procset OFDGo 1.0 0
+/OFDGoDict 8 dict def
+OFDGoDict begin
+/BuildGlyph {exch /CharProcs get exch 2 copy known not {pop /.notdef} if get exec} bind def
+/BuildChar {1 index /Encoding get exch get 1 index /BuildGlyph get exec} bind def
+end
+%%EndResource
+%%EndProlog
+`
+
+// postScriptOptions PostScript导出选项
+type postScriptOptions struct {
+	Copies            int
+	IgnorePermissions bool
+}
+
+// PostScriptOption PostScript导出配置选项
+type PostScriptOption func(*postScriptOptions)
+
+// WithPostScriptCopies 设置打印份数
+// 份数写入文档设置的NumCopies, 超过文档权限允许的份数时导出失败
+// 入参: n 打印份数, 默认1
+// 返回: PostScriptOption PostScript选项
+func WithPostScriptCopies(n int) PostScriptOption {
+	return func(o *postScriptOptions) {
+		o.Copies = n
+	}
+}
+
+// WithPostScriptIgnorePermissions 设置是否忽略文档打印权限
+// 入参: ignore 是否忽略Permissions.Print和Permissions.Copies
+// 返回: PostScriptOption PostScript选项
+func WithPostScriptIgnorePermissions(ignore bool) PostScriptOption {
+	return func(o *postScriptOptions) {
+		o.IgnorePermissions = ignore
+	}
+}
+
+// RenderToPostScript 将整个文档导出为多页PostScript
+// 输出符合DSC 3.0的PostScript Level 3文件, 可直接提交打印服务
+// 入参: writer 输出流, opts PostScript选项
+// 返回: error 错误信息
+func (r *Renderer) RenderToPostScript(writer io.Writer, opts ...PostScriptOption) error {
+	_, err := r.ExportPostScript(writer, opts...)
+	return err
+}
+
+// ExportPostScript 将整个文档导出为多页PostScript并返回导出结果摘要
+// 每页按页面物理区域设置纸张大小, 文本以页内Type 3字体嵌入, 页面之间相互独立
+// 页面错误按WithPageErrorPolicy处理, 进度通过WithProgress报告
+// 入参: writer 输出流, opts PostScript选项
+// 返回: *ExportSummary 导出结果摘要, error 错误信息
+func (r *Renderer) ExportPostScript(writer io.Writer, opts ...PostScriptOption) (*ExportSummary, error) {
+	o := &postScriptOptions{Copies: 1}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.Copies < 1 {
+		return nil, fmt.Errorf("invalid copies %d", o.Copies)
+	}
+	doc, err := r.Reader.Doc()
+	if err != nil {
+		return nil, err
+	}
+	if !o.IgnorePermissions {
+		if !doc.Permissions.Print || doc.Permissions.Copies == 0 {
+			return nil, fmt.Errorf("document does not permit printing")
+		}
+		if doc.Permissions.Copies > 0 && o.Copies > doc.Permissions.Copies {
+			return nil, fmt.Errorf("document permits at most %d copies", doc.Permissions.Copies)
+		}
+	}
+	if len(doc.Pages.Page) == 0 {
+		return nil, fmt.Errorf("no pages found")
+	}
+	summary := &ExportSummary{Total: len(doc.Pages.Page)}
+	pages, readErrs, err := r.readExportPages(doc)
+	if err != nil {
+		return summary, err
+	}
+	title := ""
+	if info, err := r.Reader.DocInfo(); err == nil {
+		title = info.Title
+	}
+	header := &bytes.Buffer{}
+	header.WriteString("%!PS-Adobe-3.0\n")
+	fmt.Fprintf(header, "%%%%Creator: xiaoqidun/ofdgo\n")
+	if title != "" {
+		fmt.Fprintf(header, "%%%%Title: %s\n", psString(title))
+	}
+	fmt.Fprintf(header, "%%%%CreationDate: %s\n", time.Now().Format(time.ANSIC))
+	header.WriteString("%%LanguageLevel: 3\n%%DocumentData: Clean7Bit\n%%Pages: (atend)\n%%BoundingBox: (atend)\n%%PageOrder: Ascend\n")
+	header.WriteString("%%DocumentSuppliedResources: (atend)\n")
+	if o.Copies > 1 {
+		fmt.Fprintf(header, "%%%%Requirements: numcopies(%d)\n", o.Copies)
+	}
+	header.WriteString("%%EndComments\n")
+	header.WriteString(psProlog)
+	if o.Copies > 1 {
+		fmt.Fprintf(header, "%%%%BeginSetup\n[{<< /NumCopies %d >> setpagedevice} stopped cleartomark\n%%%%EndSetup\n", o.Copies)
+	}
+	if _, err := header.WriteTo(writer); err != nil {
+		return summary, err
+	}
+	rendered := make([]*psPage, len(pages))
+	render := func(renderer *Renderer, i int) pageResult {
+		if readErrs[i] != nil {
+			return pageResult{index: i, err: readErrs[i]}
+		}
+		page, err := renderer.renderPostScriptPage(pages[i].Content, i)
+		if err != nil {
+			return pageResult{index: i, err: &PageError{Index: i, Stage: ExportStageRender, Err: err}}
+		}
+		rendered[i] = page
+		return pageResult{index: i}
+	}
+	var fonts []string
+	var bboxW, bboxH int
+	written := 0
+	err = r.renderPagesParallel(len(pages), true, render, func(result pageResult) error {
+		page := rendered[result.index]
+		rendered[result.index] = nil
+		if result.err != nil {
+			placeholder, err := r.pageFailed(summary, result.err)
+			if err != nil || !placeholder {
+				return err
+			}
+			c := r.placeholderCanvas(pages[result.index].Box, result.err)
+			w := newPSPage(c.W, c.H, result.index)
+			c.RenderTo(w)
+			page = w
+		} else {
+			summary.Rendered++
+		}
+		written++
+		w, h := page.bbox()
+		bboxW, bboxH = max(bboxW, w), max(bboxH, h)
+		fonts = append(fonts, page.fontNames()...)
+		if err := page.writeTo(writer, written); err != nil {
+			return err
+		}
+		r.reportProgress(ExportStageWrite, result.index, written, len(pages))
+		return nil
+	})
+	summary.finish()
+	if err != nil {
+		return summary, err
+	}
+	if written == 0 {
+		return summary, fmt.Errorf("no pages rendered")
+	}
+	trailer := &bytes.Buffer{}
+	trailer.WriteString("%%Trailer\n")
+	fmt.Fprintf(trailer, "%%%%Pages: %d\n%%%%BoundingBox: 0 0 %d %d\n", written, bboxW, bboxH)
+	trailer.WriteString("%%DocumentSuppliedResources: procset OFDGo 1.0 0\n")
+	for _, name := range fonts {
+		fmt.Fprintf(trailer, "%%%%+ font %s\n", name)
+	}
+	trailer.WriteString("%%EOF\n")
+	_, err = trailer.WriteTo(writer)
+	return summary, err
+}
+
+// renderPostScriptPage 渲染PostScript页面
+// 入参: page 页面内容, index 页面索引
+// 返回: *psPage PostScript页面, error 错误信息
+func (r *Renderer) renderPostScriptPage(page *PageContent, index int) (*psPage, error) {
+	renderer := *r
+	renderer.decodeImages = true
+	renderer.fontText = true
+	c, err := renderer.renderPage(page)
+	if err != nil {
+		return nil, err
+	}
+	w := newPSPage(c.W, c.H, index)
+	c.RenderTo(w)
+	return w, nil
+}
+
+// psFontKey PostScript字体键
+type psFontKey struct {
+	font   *canvas.Font
+	bold   float64
+	italic float64
+}
+
+// psFont 页面内嵌的Type 3字体
+// 每个Type 3字体最多编码256个字形, 超出时拆分为多个字体
+type psFont struct {
+	key    psFontKey
+	index  int
+	glyphs map[uint16]int
+	order  []uint16
+}
+
+// psPage PostScript页面写入器
+// 实现canvas.Renderer, 以毫米为用户空间单位写出页面内容
+type psPage struct {
+	w      *bytes.Buffer
+	width  float64
+	height float64
+	index  int
+	list   []*psFont
+	color  string
+	font   string
+}
+
+// newPSPage 创建PostScript页面写入器
+// 入参: width 页面宽度, height 页面高度, index 页面索引
+// 返回: *psPage 页面写入器
+func newPSPage(width, height float64, index int) *psPage {
+	return &psPage{w: &bytes.Buffer{}, width: width, height: height, index: index}
+}
+
+// Size 获取页面尺寸
+// 返回: float64 宽度, float64 高度
+func (w *psPage) Size() (float64, float64) {
+	return w.width, w.height
+}
+
+// RenderPath 写出路径
+// PostScript不支持透明度, 半透明颜色与白色混合后输出
+// 入参: path 路径, style 样式, m 变换矩阵
+func (w *psPage) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix) {
+	if path.Empty() {
+		return
+	}
+	if style.HasFill() {
+		w.fill(path.Copy().Transform(m), style.Fill, style.FillRule, m)
+	}
+	if !style.HasStroke() {
+		return
+	}
+	capName, capOK := psLineCap(style.StrokeCapper)
+	joinName, miter, joinOK := psLineJoin(style.StrokeJoiner)
+	if !capOK || !joinOK || !m.IsSimilarity() || !style.Stroke.IsColor() {
+		stroke := path
+		if style.IsDashed() {
+			stroke = stroke.Dash(style.DashOffset, style.Dashes...)
+		}
+		stroke = stroke.Stroke(style.StrokeWidth, style.StrokeCapper, style.StrokeJoiner, canvas.Tolerance)
+		w.fill(stroke.Transform(m), style.Stroke, canvas.NonZero, m)
+		return
+	}
+	if !w.setColor(style.Stroke.Color) {
+		return
+	}
+	scale := math.Sqrt(math.Abs(m.Det()))
+	width := style.StrokeWidth * scale
+	offset, dashes := canvas.ScaleDash(scale, style.DashOffset, style.Dashes)
+	fmt.Fprintf(w.w, "newpath %s %s setlinewidth %s setlinecap %s setlinejoin", psPath(path.Copy().Transform(m)), formatOFDNumber(width), capName, joinName)
+	if miter > 0 {
+		fmt.Fprintf(w.w, " %s setmiterlimit", formatOFDNumber(miter))
+	}
+	w.w.WriteString(" [")
+	for i, dash := range dashes {
+		if i > 0 {
+			w.w.WriteByte(' ')
+		}
+		w.w.WriteString(formatOFDNumber(dash))
+	}
+	fmt.Fprintf(w.w, "] %s setdash stroke\n", formatOFDNumber(offset))
+}
+
+// fill 填充已变换到页面坐标的路径
+// 入参: path 路径, paint 填充, rule 填充规则, m 渐变变换矩阵
+func (w *psPage) fill(path *canvas.Path, paint canvas.Paint, rule canvas.FillRule, m canvas.Matrix) {
+	op := "fill"
+	if rule == canvas.EvenOdd {
+		op = "eofill"
+	}
+	if paint.IsGradient() {
+		shading := psShading(paint.Gradient)
+		if shading == "" {
+			return
+		}
+		fmt.Fprintf(w.w, "gsave newpath %s %s newpath %s concat %s shfill grestore\n", psPath(path), strings.Replace(op, "fill", "clip", 1), psMatrix(m), shading)
+		w.reset()
+		return
+	}
+	if !w.setColor(paint.Color) {
+		return
+	}
+	fmt.Fprintf(w.w, "newpath %s %s\n", psPath(path), op)
+}
+
+// setColor 设置当前颜色
+// 入参: c 预乘透明度的颜色
+// 返回: bool 颜色是否可见
+func (w *psPage) setColor(c color.RGBA) bool {
+	if c.A == 0 {
+		return false
+	}
+	r, g, b := psBlend(c)
+	value := fmt.Sprintf("%s %s %s setrgbcolor ", formatOFDNumber(float64(r)/255), formatOFDNumber(float64(g)/255), formatOFDNumber(float64(b)/255))
+	if r == g && g == b {
+		value = fmt.Sprintf("%s setgray ", formatOFDNumber(float64(r)/255))
+	}
+	if value != w.color {
+		w.w.WriteString(value)
+		w.color = value
+	}
+	return true
+}
+
+// reset 清除已记录的颜色和字体状态
+// 图形状态在gsave和grestore之间变化后调用
+func (w *psPage) reset() {
+	w.color, w.font = "", ""
+}
+
+// RenderText 写出文本
+// 横排纯色文本以Type 3字体输出, 其余文本按字形轮廓输出
+// 入参: text 文本, m 变换矩阵
+func (w *psPage) RenderText(text *canvas.Text, m canvas.Matrix) {
+	if text.Empty() {
+		return
+	}
+	asPath := text.WritingMode != canvas.HorizontalTB
+	text.WalkSpans(func(x, y float64, span canvas.TextSpan) {
+		if !span.IsText() || !span.Face.Fill.IsColor() || span.Rotation != 0 {
+			asPath = true
+		}
+	})
+	if asPath {
+		text.RenderTo(w, m, 0)
+		return
+	}
+	text.WalkSpans(func(x, y float64, span canvas.TextSpan) {
+		face := span.Face
+		if len(span.Glyphs) == 0 || face.Fill.Color.A == 0 {
+			return
+		}
+		ox, oy := 0.0, 0.0
+		translated := m.IsTranslation()
+		if translated {
+			p := m.Dot(canvas.Point{X: x, Y: y})
+			ox, oy = p.X, p.Y
+		} else {
+			fmt.Fprintf(w.w, "gsave %s concat ", psMatrix(m.Translate(x, y)))
+			w.reset()
+		}
+		w.setColor(face.Fill.Color)
+		key := psFontKey{font: face.Font, bold: face.FauxBold, italic: face.FauxItalic}
+		var ax, ay int32
+		var run *psFont
+		var codes []byte
+		var deltas []float64
+		flush := func() {
+			if run == nil {
+				return
+			}
+			fmt.Fprintf(w.w, "<%x> [", codes)
+			for i, d := range deltas {
+				if i > 0 {
+					w.w.WriteByte(' ')
+				}
+				w.w.WriteString(formatOFDNumber(d))
+			}
+			w.w.WriteString("] xyshow\n")
+			run, codes, deltas = nil, nil, nil
+		}
+		var px, py float64
+		for _, glyph := range span.Glyphs {
+			gx := ox + face.MmPerEm*float64(ax+glyph.XOffset)
+			gy := oy + face.MmPerEm*float64(ay+glyph.YOffset)
+			ax += glyph.XAdvance
+			ay += glyph.YAdvance
+			f, code := w.glyph(key, glyph.ID)
+			if f != run {
+				flush()
+				if selected := fmt.Sprintf("/%s %s selectfont ", w.fontName(f), formatOFDNumber(face.Size)); selected != w.font {
+					w.w.WriteString(selected)
+					w.font = selected
+				}
+				fmt.Fprintf(w.w, "%s %s moveto ", formatOFDNumber(gx), formatOFDNumber(gy))
+				run = f
+			} else {
+				deltas[len(deltas)-2] = gx - px
+				deltas[len(deltas)-1] = gy - py
+			}
+			px, py = gx, gy
+			codes = append(codes, code)
+			deltas = append(deltas, 0, 0)
+		}
+		flush()
+		if !translated {
+			w.w.WriteString("grestore\n")
+			w.reset()
+		}
+	})
+	text.RenderDecorationsTo(w, m, 0)
+}
+
+// glyph 登记字形并获取所属字体和编码
+// 入参: key 字体键, id 字形ID
+// 返回: *psFont 所属字体, byte 字形编码
+func (w *psPage) glyph(key psFontKey, id uint16) (*psFont, byte) {
+	var last *psFont
+	for _, f := range w.list {
+		if f.key != key {
+			continue
+		}
+		if code, ok := f.glyphs[id]; ok {
+			return f, byte(code)
+		}
+		last = f
+	}
+	if last == nil || len(last.order) == 256 {
+		last = &psFont{key: key, index: len(w.list), glyphs: make(map[uint16]int)}
+		w.list = append(w.list, last)
+	}
+	code := len(last.order)
+	last.glyphs[id] = code
+	last.order = append(last.order, id)
+	return last, byte(code)
+}
+
+// fontName 获取字体资源名称
+// 入参: f 字体
+// 返回: string 字体名称
+func (w *psPage) fontName(f *psFont) string {
+	return fmt.Sprintf("OFDGo%dF%d", w.index+1, f.index)
+}
+
+// fontNames 获取页面提供的字体资源名称
+// 返回: []string 字体名称列表
+func (w *psPage) fontNames() []string {
+	names := make([]string, len(w.list))
+	for i, f := range w.list {
+		names[i] = w.fontName(f)
+	}
+	return names
+}
+
+// RenderImage 写出图像
+// 透明度低于一半的像素以色键遮罩排除, 其余像素与白色混合
+// 入参: img 图像, m 变换矩阵
+func (w *psPage) RenderImage(img image.Image, m canvas.Matrix) {
+	b := img.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width == 0 || height == 0 {
+		return
+	}
+	data := make([]byte, 0, width*height*3)
+	var transparent []bool
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c := color.RGBAModel.Convert(img.At(x, y)).(color.RGBA)
+			if c.A < 128 {
+				if transparent == nil {
+					transparent = make([]bool, width*height)
+				}
+				transparent[(y-b.Min.Y)*width+x-b.Min.X] = true
+			}
+			r, g, bl := psBlend(c)
+			data = append(data, r, g, bl)
+		}
+	}
+	mask := ""
+	if transparent != nil {
+		used := make([]uint64, 1<<24/64)
+		for i, t := range transparent {
+			if !t {
+				rgb := uint32(data[i*3])<<16 | uint32(data[i*3+1])<<8 | uint32(data[i*3+2])
+				used[rgb/64] |= 1 << (rgb % 64)
+			}
+		}
+		key := -1
+		for i, bits := range used {
+			if bits != math.MaxUint64 {
+				for j := 0; j < 64; j++ {
+					if bits&(1<<j) == 0 {
+						key = i*64 + j
+						break
+					}
+				}
+				break
+			}
+		}
+		if key >= 0 {
+			kr, kg, kb := byte(key>>16), byte(key>>8), byte(key)
+			for i, t := range transparent {
+				if t {
+					data[i*3], data[i*3+1], data[i*3+2] = kr, kg, kb
+				}
+			}
+			mask = fmt.Sprintf(" /ImageType 4 /MaskColor [%d %d %d %d %d %d]", kr, kr, kg, kg, kb, kb)
+		}
+	}
+	if mask == "" {
+		mask = " /ImageType 1"
+	}
+	m = m.Scale(float64(width), float64(height))
+	fmt.Fprintf(w.w, "gsave %s concat /DeviceRGB setcolorspace\n", psMatrix(m))
+	fmt.Fprintf(w.w, "<<%s /Width %d /Height %d /BitsPerComponent 8 /Decode [0 1 0 1 0 1] /Interpolate true", mask, width, height)
+	fmt.Fprintf(w.w, " /ImageMatrix [%d 0 0 %d 0 %d] /DataSource currentfile /ASCII85Decode filter /FlateDecode filter>> image\n", width, -height, height)
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write(data)
+	zw.Close()
+	psASCII85(w.w, compressed.Bytes())
+	w.w.WriteString("grestore\n")
+	w.reset()
+}
+
+// bbox 获取页面边界框
+// 返回: int 宽度, int 高度, 单位为点
+func (w *psPage) bbox() (int, int) {
+	return int(math.Ceil(w.width * ptPerMM)), int(math.Ceil(w.height * ptPerMM))
+}
+
+// writeTo 写出页面
+// 页面纸张大小和字体在页面设置中定义, 页面内容在save和restore之间执行
+// 入参: writer 输出流, ordinal 页面序号
+// 返回: error 错误信息
+func (w *psPage) writeTo(writer io.Writer, ordinal int) error {
+	b := &bytes.Buffer{}
+	bw, bh := w.bbox()
+	fmt.Fprintf(b, "%%%%Page: %d %d\n%%%%PageBoundingBox: 0 0 %d %d\n%%%%BeginPageSetup\n", w.index+1, ordinal, bw, bh)
+	fmt.Fprintf(b, "[{<< /PageSize [%s %s] >> setpagedevice} stopped cleartomark\n", formatOFDNumber(w.width*ptPerMM), formatOFDNumber(w.height*ptPerMM))
+	b.WriteString("/OFDGoPage save def\nOFDGoDict begin\n")
+	for _, f := range w.list {
+		w.writeFont(b, f)
+	}
+	b.WriteString("%%EndPageSetup\n")
+	b.WriteString("72 25.4 div dup scale\n")
+	if _, err := b.WriteTo(writer); err != nil {
+		return err
+	}
+	if _, err := w.w.WriteTo(writer); err != nil {
+		return err
+	}
+	_, err := io.WriteString(writer, "end\nOFDGoPage restore\nshowpage\n%%PageTrailer\n")
+	return err
+}
+
+// writeFont 写出Type 3字体定义
+// 字形轮廓按1000单位每em生成, 仿粗体和仿斜体计入字形轮廓和字体矩阵
+// 入参: b 输出缓冲, f 字体
+func (w *psPage) writeFont(b *bytes.Buffer, f *psFont) {
+	name := w.fontName(f)
+	sfnt := f.key.font.SFNT
+	scale := 1000 / float64(sfnt.Head.UnitsPerEm)
+	procs := &bytes.Buffer{}
+	bbox := canvas.Rect{}
+	for _, id := range f.order {
+		p := &canvas.Path{}
+		_ = sfnt.GlyphPath(p, id, 0, 0, 0, scale, font.NoHinting)
+		if f.key.bold != 0 && !p.Empty() {
+			d := f.key.bold * 1000
+			if f.key.font.IsTrueType {
+				d = -d
+			}
+			p = p.Offset(d, canvas.Tolerance)
+		}
+		fmt.Fprintf(procs, "/g%d {", id)
+		if p.Empty() {
+			procs.WriteString("0 0 0 0 0 0 setcachedevice}")
+		} else {
+			r := p.Bounds()
+			bbox = bbox.Add(r)
+			fmt.Fprintf(procs, "0 0 %d %d %d %d setcachedevice newpath %s fill}", int(math.Floor(r.X0)), int(math.Floor(r.Y0)), int(math.Ceil(r.X1)), int(math.Ceil(r.Y1)), psPath(p))
+		}
+		procs.WriteString(" bind def\n")
+	}
+	fmt.Fprintf(b, "%%%%BeginResource: font %s\n/%s 9 dict begin\n/FontType 3 def\n", name, name)
+	fmt.Fprintf(b, "/FontMatrix [0.001 0 %s 0.001 0 0] def\n", formatOFDNumber(0.001*f.key.italic))
+	fmt.Fprintf(b, "/FontBBox [%d %d %d %d] def\n", int(math.Floor(bbox.X0)), int(math.Floor(bbox.Y0)), int(math.Ceil(bbox.X1)), int(math.Ceil(bbox.Y1)))
+	b.WriteString("/Encoding 256 array def\n0 1 255 {Encoding exch /.notdef put} for\n")
+	for code, id := range f.order {
+		fmt.Fprintf(b, "Encoding %d /g%d put\n", code, id)
+	}
+	fmt.Fprintf(b, "/CharProcs %d dict def\nCharProcs begin\n/.notdef {0 0 0 0 0 0 setcachedevice} bind def\n", len(f.order)+1)
+	procs.WriteTo(b)
+	b.WriteString("end\n/BuildGlyph OFDGoDict /BuildGlyph get def\n/BuildChar OFDGoDict /BuildChar get def\n")
+	fmt.Fprintf(b, "currentdict end definefont pop\n%%%%EndResource\n")
+}
+
+// psLineCap 获取PostScript线帽
+// 入参: capper 线帽
+// 返回: string 线帽编号, bool 是否支持
+func psLineCap(capper canvas.Capper) (string, bool) {
+	switch capper.(type) {
+	case canvas.ButtCapper:
+		return "0", true
+	case canvas.RoundCapper:
+		return "1", true
+	case canvas.SquareCapper:
+		return "2", true
+	}
+	return "", false
+}
+
+// psLineJoin 获取PostScript线连接
+// 入参: joiner 线连接
+// 返回: string 线连接编号, float64 斜接限制, bool 是否支持
+func psLineJoin(joiner canvas.Joiner) (string, float64, bool) {
+	switch j := joiner.(type) {
+	case canvas.BevelJoiner:
+		return "2", 0, true
+	case canvas.RoundJoiner:
+		return "1", 0, true
+	case canvas.MiterJoiner:
+		if _, ok := j.GapJoiner.(canvas.BevelJoiner); ok && !math.IsNaN(j.Limit) {
+			return "0", j.Limit, true
+		}
+	}
+	return "", 0, false
+}
+
+// psPath 转换PostScript路径
+// 入参: path 路径
+// 返回: string 路径构造操作
+func psPath(path *canvas.Path) string {
+	return path.ReplaceArcs().ToPS()
+}
+
+// psMatrix 转换PostScript矩阵
+// 入参: m 变换矩阵
+// 返回: string 矩阵数组
+func psMatrix(m canvas.Matrix) string {
+	return fmt.Sprintf("[%s %s %s %s %s %s]", formatOFDNumber(m[0][0]), formatOFDNumber(m[1][0]), formatOFDNumber(m[0][1]), formatOFDNumber(m[1][1]), formatOFDNumber(m[0][2]), formatOFDNumber(m[1][2]))
+}
+
+// psBlend 将预乘透明度的颜色与白色混合
+// 入参: c 预乘透明度的颜色
+// 返回: byte 红, byte 绿, byte 蓝
+func psBlend(c color.RGBA) (byte, byte, byte) {
+	bg := 255 - c.A
+	return c.R + bg, c.G + bg, c.B + bg
+}
+
+// psShading 转换渐变为PostScript Level 3着色字典
+// 入参: gradient 渐变
+// 返回: string 着色字典, 不支持的渐变为空
+func psShading(gradient canvas.Gradient) string {
+	var typ int
+	var coords []float64
+	var grad canvas.Grad
+	switch g := gradient.(type) {
+	case *canvas.LinearGradient:
+		typ, coords, grad = 2, []float64{g.Start.X, g.Start.Y, g.End.X, g.End.Y}, g.Grad
+	case *canvas.RadialGradient:
+		typ, coords, grad = 3, []float64{g.C0.X, g.C0.Y, g.R0, g.C1.X, g.C1.Y, g.R1}, g.Grad
+	default:
+		return ""
+	}
+	if len(grad) == 0 {
+		return ""
+	}
+	if len(grad) == 1 {
+		grad = append(grad, grad[0])
+	}
+	stop := func(s canvas.Stop) string {
+		r, g, b := psBlend(s.Color)
+		return fmt.Sprintf("%s %s %s", formatOFDNumber(float64(r)/255), formatOFDNumber(float64(g)/255), formatOFDNumber(float64(b)/255))
+	}
+	var fn strings.Builder
+	var bounds, encode []string
+	fn.WriteString("<< /FunctionType 3 /Domain [")
+	fmt.Fprintf(&fn, "%s %s] /Functions [", formatOFDNumber(grad[0].Offset), formatOFDNumber(grad[len(grad)-1].Offset))
+	for i := 0; i < len(grad)-1; i++ {
+		fmt.Fprintf(&fn, "<< /FunctionType 2 /Domain [0 1] /N 1 /C0 [%s] /C1 [%s] >> ", stop(grad[i]), stop(grad[i+1]))
+		if i > 0 {
+			bounds = append(bounds, formatOFDNumber(grad[i].Offset))
+		}
+		encode = append(encode, "0 1")
+	}
+	fmt.Fprintf(&fn, "] /Bounds [%s] /Encode [%s] >>", strings.Join(bounds, " "), strings.Join(encode, " "))
+	values := make([]string, len(coords))
+	for i, v := range coords {
+		values[i] = formatOFDNumber(v)
+	}
+	return fmt.Sprintf("<< /ShadingType %d /ColorSpace /DeviceRGB /Coords [%s] /Function %s /Extend [true true] >>", typ, strings.Join(values, " "), fn.String())
+}
+
+// psASCII85 写出ASCII85编码数据
+// 数据按行折行, 行首为%时前置空格, 避免被DSC解析器识别为注释
+// 入参: w 输出缓冲, data 数据
+func psASCII85(w *bytes.Buffer, data []byte) {
+	encoded := make([]byte, ascii85.MaxEncodedLen(len(data)))
+	encoded = encoded[:ascii85.Encode(encoded, data)]
+	for len(encoded) > 0 {
+		n := min(len(encoded), 75)
+		if encoded[0] == '%' {
+			w.WriteByte(' ')
+		}
+		w.Write(encoded[:n])
+		w.WriteByte('\n')
+		encoded = encoded[n:]
+	}
+	w.WriteString("~>\n")
+}
+
+// psString 转换DSC注释中的文本
+// 非ASCII字节按八进制转义
+// 入参: s 文本
+// 返回: string PostScript字符串
+func psString(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '(' || c == ')' || c == '\\':
+			b.WriteByte('\\')
+			b.WriteByte(c)
+		case c < 32 || c > 126:
+			fmt.Fprintf(&b, "\\%03o", c)
+		default:
+			b.WriteByte(c)
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
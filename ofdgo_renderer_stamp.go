@@ -28,17 +28,27 @@ import (
 // renderStamp 渲染印章
 // 入参: ctx 画布上下文, s 印章对象, pageH 页面高度
 func (r *Renderer) renderStamp(ctx *canvas.Context, s Stamp, pageH float64) {
+	if !r.budget.object() || !r.budget.enter() {
+		return
+	}
+	defer r.budget.leave()
+	var sealErr error
 	if s.Type == "ofd" && len(s.Data) > 0 {
 		if s.Clip != nil {
 			if img := r.renderOFDStampImage(s.Data); img != nil {
 				r.renderStampImage(ctx, stampImageWithTransparentWhite(img), s, pageH)
+			} else {
+				r.diagnose(DiagnosticWarning, "", "failed to render ofd seal image")
 			}
 			return
 		}
-		reader, err := NewReader(bytes.NewReader(s.Data), int64(len(s.Data)))
+		reader, err := NewReader(bytes.NewReader(s.Data), int64(len(s.Data)), WithLimits(r.limits))
+		r.budget.fail(err)
+		sealErr = err
 		if err == nil {
 			defer reader.Close()
 			doc, err := reader.Doc()
+			sealErr = err
 			if err == nil {
 				renderer := r.childRenderer(reader)
 				for _, pageRef := range doc.Pages.Page {
@@ -53,7 +63,7 @@ func (r *Renderer) renderStamp(ctx *canvas.Context, s Stamp, pageH float64) {
 					ctx.Push()
 					ctx.Translate(s.Box.X, pageH-(s.Box.Y+s.Box.H))
 					ctx.Scale(s.Box.W/sealBox.W, s.Box.H/sealBox.H)
-					renderer.renderPageToContext(ctx, content, false)
+					r.budget.fail(renderer.renderPageToContext(ctx, content, false))
 					ctx.Pop()
 				}
 				return
@@ -61,7 +71,8 @@ func (r *Renderer) renderStamp(ctx *canvas.Context, s Stamp, pageH float64) {
 		}
 	}
 	if len(s.Data) > 0 {
-		img, _, err := decodeImageData(s.Data)
+		img, err := decodeImageDataLimited(s.Data, r.limits)
+		r.budget.fail(err)
 		if err == nil {
 			if r.decodeImages {
 				img = imagePixelSource(img)
@@ -69,15 +80,26 @@ func (r *Renderer) renderStamp(ctx *canvas.Context, s Stamp, pageH float64) {
 			r.renderStampImage(ctx, stampImageWithTransparentWhite(img), s, pageH)
 			return
 		}
+		if sealErr == nil {
+			sealErr = err
+		}
+	}
+	if sealErr != nil {
+		r.diagnose(DiagnosticWarning, "", "failed to render %s seal: %v", s.Type, sealErr)
 	}
 }
 
 // renderOFDStampImage 渲染OFD印章图像
+// 与页面图片使用相同的资源计数, 光栅化前按像素数限制检查输出尺寸
 // 入参: data OFD印章数据
 // 返回: image.Image 印章图像
 func (r *Renderer) renderOFDStampImage(data []byte) image.Image {
-	reader, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if !r.budget.check() {
+		return nil
+	}
+	reader, err := NewReader(bytes.NewReader(data), int64(len(data)), WithLimits(r.limits))
 	if err != nil {
+		r.budget.fail(err)
 		return nil
 	}
 	defer reader.Close()
@@ -96,11 +118,20 @@ func (r *Renderer) renderOFDStampImage(data []byte) image.Image {
 		if err != nil {
 			continue
 		}
+		dpmm := r.DPI / 25.4
+		if err := r.limits.checkImage(int(math.Ceil(sealBox.W*dpmm)), int(math.Ceil(sealBox.H*dpmm))); err != nil {
+			r.budget.fail(err)
+			return nil
+		}
 		c := canvas.New(sealBox.W, sealBox.H)
 		if err := renderer.renderPageToContext(canvas.NewContext(c), content, false); err != nil {
+			r.budget.fail(err)
 			continue
 		}
-		return rasterizer.Draw(c, canvas.DPMM(r.DPI/25.4), canvas.DefaultColorSpace)
+		if !r.budget.check() {
+			return nil
+		}
+		return rasterizer.Draw(c, canvas.DPMM(dpmm), canvas.DefaultColorSpace)
 	}
 	return nil
 }
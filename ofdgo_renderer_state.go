@@ -0,0 +1,130 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"sync"
+
+	"github.com/tdewolff/canvas"
+)
+
+// renderState 渲染器缓存状态
+// 字体整形时会写入字体内部缓存, 因此同一状态同一时刻只能由一个渲染使用
+type renderState struct {
+	fontFamily         *canvas.FontFamily
+	defaultFontLoaded  bool
+	fontMap            map[string]*canvas.FontFamily
+	fontGIDMap         map[string]map[uint16]rune
+	fontCIDMap         map[string]map[uint16]rune
+	fontCache          map[fontCacheKey]*canvas.FontFamily
+	fontSourceCache    map[string][]fontSource
+	fontSourceUsed     map[string]fontSource
+	textGlyphPathCache map[textGlyphPathCacheKey]textGlyphPathCacheValue
+	templatePageCache  map[string]*PageContent
+	textLayerFonts     map[*canvas.Font]bool
+}
+
+// renderStatePool 渲染器缓存状态池
+// 顺序渲染始终复用渲染器自身的缓存, 并发渲染时按需创建新的缓存状态
+type renderStatePool struct {
+	mu   sync.Mutex
+	idle []*renderState
+}
+
+// get 取出空闲的缓存状态
+// 返回: *renderState 缓存状态, 无空闲时为nil
+func (p *renderStatePool) get() *renderState {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.idle) == 0 {
+		return nil
+	}
+	state := p.idle[len(p.idle)-1]
+	p.idle = p.idle[:len(p.idle)-1]
+	return state
+}
+
+// put 归还缓存状态
+// 入参: state 缓存状态
+func (p *renderStatePool) put(state *renderState) {
+	p.mu.Lock()
+	p.idle = append(p.idle, state)
+	p.mu.Unlock()
+}
+
+// acquireState 获取独占缓存状态的渲染器副本
+// 返回: *Renderer 渲染器副本, func() 归还缓存状态的函数
+func (r *Renderer) acquireState() (*Renderer, func()) {
+	renderer := *r
+	if r.states == nil {
+		return &renderer, func() {}
+	}
+	if state := r.states.get(); state != nil {
+		renderer.loadState(state)
+	} else {
+		renderer.resetState()
+	}
+	return &renderer, func() {
+		r.states.put(renderer.saveState())
+	}
+}
+
+// saveState 保存渲染器缓存状态
+// 返回: *renderState 缓存状态
+func (r *Renderer) saveState() *renderState {
+	return &renderState{
+		fontFamily:         r.fontFamily,
+		defaultFontLoaded:  r.defaultFontLoaded,
+		fontMap:            r.FontMap,
+		fontGIDMap:         r.FontGIDMap,
+		fontCIDMap:         r.FontCIDMap,
+		fontCache:          r.fontCache,
+		fontSourceCache:    r.fontSourceCache,
+		fontSourceUsed:     r.fontSourceUsed,
+		textGlyphPathCache: r.textGlyphPathCache,
+		templatePageCache:  r.templatePageCache,
+		textLayerFonts:     r.textLayerFonts,
+	}
+}
+
+// loadState 载入渲染器缓存状态
+// 入参: state 缓存状态
+func (r *Renderer) loadState(state *renderState) {
+	r.fontFamily = state.fontFamily
+	r.defaultFontLoaded = state.defaultFontLoaded
+	r.FontMap = state.fontMap
+	r.FontGIDMap = state.fontGIDMap
+	r.FontCIDMap = state.fontCIDMap
+	r.fontCache = state.fontCache
+	r.fontSourceCache = state.fontSourceCache
+	r.fontSourceUsed = state.fontSourceUsed
+	r.textGlyphPathCache = state.textGlyphPathCache
+	r.templatePageCache = state.templatePageCache
+	r.textLayerFonts = state.textLayerFonts
+}
+
+// resetState 创建新的渲染器缓存状态并重新加载默认字体
+func (r *Renderer) resetState() {
+	r.FontMap = make(map[string]*canvas.FontFamily)
+	r.FontGIDMap = make(map[string]map[uint16]rune)
+	r.FontCIDMap = make(map[string]map[uint16]rune)
+	r.fontCache = make(map[fontCacheKey]*canvas.FontFamily)
+	r.fontSourceCache = make(map[string][]fontSource)
+	r.fontSourceUsed = make(map[string]fontSource)
+	r.textGlyphPathCache = make(map[textGlyphPathCacheKey]textGlyphPathCacheValue)
+	r.templatePageCache = make(map[string]*PageContent)
+	r.textLayerFonts = make(map[*canvas.Font]bool)
+	r.initCommon()
+}
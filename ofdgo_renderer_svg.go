@@ -0,0 +1,469 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"image/color"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/tdewolff/canvas"
+	"github.com/tdewolff/canvas/renderers/svg"
+	"github.com/tdewolff/font"
+)
+
+// SVGFontHandler SVG字体处理函数
+// 入参: name 建议的字体文件名, 由字体内容摘要生成, data WOFF2子集字体数据
+// 返回: string 字体引用地址, error 错误信息
+type SVGFontHandler func(name string, data []byte) (string, error)
+
+// WithVectorSVG 设置RenderToSVG输出保留文本和结构的矢量SVG
+// 文本输出为text元素并附带WOFF2子集字体, 对象ID写入元素id和data-ofd-id属性,
+// 单击触发的URI和Goto动作输出为a元素, 裁剪路径和渐变在defs中按内容去重;
+// Goto动作链接到#ofd-page-页面ID并以data-ofd-goto属性注明目标页面ID, 页面根元素使用该ID;
+// 以字形索引定位的文本仍输出为路径
+// 入参: fonts 字体处理函数, 为nil时字体以data URI内嵌, 否则按返回的地址引用
+// 返回: RendererOption 渲染选项
+func WithVectorSVG(fonts SVGFontHandler) RendererOption {
+	return func(r *Renderer) {
+		r.svgVector = true
+		r.svgFonts = fonts
+	}
+}
+
+// svgWriter 矢量SVG写出器
+// 路径和图像交由canvas的SVG渲染器写出, 文本、分组、链接、裁剪和字体由本写出器处理
+type svgWriter struct {
+	w         *bytes.Buffer
+	svg       *svg.SVG
+	width     float64
+	height    float64
+	fonts     map[*canvas.Font]*svgFont
+	fontList  []*svgFont
+	clips     map[string]string
+	clipList  []string
+	gradients map[string]canvas.Gradient
+	ids       map[string]int
+	groups    [][]string
+	run       *svgTextRun
+}
+
+// svgFont SVG字体
+type svgFont struct {
+	font   *canvas.Font
+	family string
+	glyphs map[uint16]bool
+}
+
+// svgTextRun 同一基线上待合并写出的文本
+type svgTextRun struct {
+	style string
+	y     float64
+	xs    []float64
+	text  []rune
+}
+
+// newSVGWriter 创建矢量SVG写出器
+// 入参: w 输出缓冲, width 页面宽度, height 页面高度
+// 返回: *svgWriter 矢量SVG写出器
+func newSVGWriter(w *bytes.Buffer, width, height float64) *svgWriter {
+	opts := svg.DefaultOptions
+	opts.EmbedFonts = false
+	return &svgWriter{
+		w:         w,
+		svg:       svg.New(w, width, height, &opts),
+		width:     width,
+		height:    height,
+		fonts:     make(map[*canvas.Font]*svgFont),
+		clips:     make(map[string]string),
+		gradients: make(map[string]canvas.Gradient),
+		ids:       make(map[string]int),
+	}
+}
+
+// Size 获取画布尺寸
+// 返回: float64 宽度, float64 高度
+func (w *svgWriter) Size() (float64, float64) {
+	return w.width, w.height
+}
+
+// RenderPath 写出路径
+// 相同内容的渐变复用同一定义
+// 入参: path 路径, style 样式, m 变换矩阵
+func (w *svgWriter) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix) {
+	w.flush()
+	style.Fill = w.paint(style.Fill)
+	style.Stroke = w.paint(style.Stroke)
+	w.svg.RenderPath(path, style, m)
+}
+
+// RenderImage 写出图像
+// 入参: img 图像, m 变换矩阵
+func (w *svgWriter) RenderImage(img image.Image, m canvas.Matrix) {
+	w.flush()
+	w.svg.RenderImage(img, m)
+}
+
+// RenderText 写出文本
+// 平移变换下的单字形与同基线同样式的前文合并为一个text元素, 渐变填充的文本输出为路径
+// 入参: text 文本, m 变换矩阵
+func (w *svgWriter) RenderText(text *canvas.Text, m canvas.Matrix) {
+	if text.Empty() {
+		return
+	}
+	asPath := false
+	text.WalkSpans(func(x, y float64, span canvas.TextSpan) {
+		if !span.IsText() || !span.Face.Fill.IsColor() {
+			asPath = true
+		}
+	})
+	if asPath {
+		w.flush()
+		text.RenderTo(w, m, canvas.DefaultResolution)
+		return
+	}
+	text.WalkSpans(func(x, y float64, span canvas.TextSpan) {
+		style := w.textStyle(span)
+		runes := []rune(span.Text)
+		if m.IsTranslation() && len(runes) == 1 {
+			p := m.Dot(canvas.Point{X: x, Y: y})
+			sx, sy := p.X, w.height-p.Y
+			if w.run == nil || w.run.style != style || math.Abs(w.run.y-sy) > 1e-6 {
+				w.flush()
+				w.run = &svgTextRun{style: style, y: sy}
+			}
+			w.run.xs = append(w.run.xs, sx)
+			w.run.text = append(w.run.text, runes...)
+			return
+		}
+		w.flush()
+		fmt.Fprintf(w.w, `<text xml:space="preserve" transform="%s" x="%s" y="%s" style="%s">`, m.ToSVG(w.height), formatOFDNumber(x), formatOFDNumber(-y), style)
+		svgEscape(w.w, span.Text)
+		io.WriteString(w.w, "</text>")
+	})
+	text.RenderDecorationsTo(w, m, canvas.DefaultResolution)
+}
+
+// textStyle 获取文本片段样式并登记所用字形
+// 入参: span 文本片段
+// 返回: string 样式
+func (w *svgWriter) textStyle(span canvas.TextSpan) string {
+	face := span.Face
+	f := w.fonts[face.Font]
+	if f == nil {
+		f = &svgFont{font: face.Font, family: fmt.Sprintf("ofd-font-%d", len(w.fontList)), glyphs: make(map[uint16]bool)}
+		w.fonts[face.Font] = f
+		w.fontList = append(w.fontList, f)
+	}
+	for _, glyph := range span.Glyphs {
+		f.glyphs[glyph.ID] = true
+	}
+	for _, ch := range span.Text {
+		f.glyphs[face.Font.SFNT.GlyphIndex(ch)] = true
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "font-family:'%s';font-size:%spx", f.family, formatOFDNumber(face.Size))
+	if face.Style.Italic() {
+		b.WriteString(";font-style:italic")
+	}
+	if weight := face.Style.CSS(); weight != 400 {
+		fmt.Fprintf(&b, ";font-weight:%d", weight)
+	}
+	if fill, opacity := svgColor(face.Fill.Color); fill != "#000000" || opacity != "" {
+		b.WriteString(";fill:" + fill)
+		if opacity != "" {
+			b.WriteString(";fill-opacity:" + opacity)
+		}
+	}
+	return b.String()
+}
+
+// flush 写出待合并的文本
+func (w *svgWriter) flush() {
+	if w.run == nil {
+		return
+	}
+	xs := make([]string, len(w.run.xs))
+	for i, x := range w.run.xs {
+		xs[i] = formatOFDNumber(x)
+	}
+	fmt.Fprintf(w.w, `<text xml:space="preserve" x="%s" y="%s" style="%s">`, strings.Join(xs, " "), formatOFDNumber(w.run.y), w.run.style)
+	svgEscape(w.w, string(w.run.text))
+	io.WriteString(w.w, "</text>")
+	w.run = nil
+}
+
+// paint 获取去重后的绘制方式
+// 入参: paint 绘制方式
+// 返回: canvas.Paint 内容相同的渐变返回首次出现的实例
+func (w *svgWriter) paint(paint canvas.Paint) canvas.Paint {
+	if !paint.IsGradient() {
+		return paint
+	}
+	key := fmt.Sprintf("%T%v", paint.Gradient, paint.Gradient)
+	if gradient, ok := w.gradients[key]; ok {
+		paint.Gradient = gradient
+	} else {
+		w.gradients[key] = paint.Gradient
+	}
+	return paint
+}
+
+// begin 开始写出对象分组
+// 入参: kind 对象类型, id 对象ID, href 链接地址, 为空时不输出链接, page 链接目标页面ID, 非页面跳转时为空
+func (w *svgWriter) begin(kind, id, href, page string) {
+	w.flush()
+	closers := []string{"</g>"}
+	if href != "" {
+		io.WriteString(w.w, `<a href="`)
+		svgEscape(w.w, href)
+		io.WriteString(w.w, `" xlink:href="`)
+		svgEscape(w.w, href)
+		if page != "" {
+			io.WriteString(w.w, `" data-ofd-goto="`)
+			svgEscape(w.w, page)
+		}
+		io.WriteString(w.w, `">`)
+		closers = append(closers, "</a>")
+	}
+	io.WriteString(w.w, "<g")
+	if id != "" {
+		elementID := "ofd-" + id
+		if n := w.ids[id]; n > 0 {
+			elementID = fmt.Sprintf("ofd-%s-%d", id, n+1)
+		}
+		w.ids[id]++
+		io.WriteString(w.w, ` id="`)
+		svgEscape(w.w, elementID)
+		io.WriteString(w.w, `" data-ofd-id="`)
+		svgEscape(w.w, id)
+		io.WriteString(w.w, `"`)
+	}
+	fmt.Fprintf(w.w, ` data-ofd-type="%s">`, kind)
+	w.groups = append(w.groups, closers)
+}
+
+// page 开始写出页面分组
+// 入参: id 页面ID
+func (w *svgWriter) page(id string) {
+	io.WriteString(w.w, `<g id="ofd-page-`)
+	svgEscape(w.w, id)
+	io.WriteString(w.w, `" data-ofd-page-id="`)
+	svgEscape(w.w, id)
+	io.WriteString(w.w, `">`)
+	w.groups = append(w.groups, []string{"</g>"})
+}
+
+// clip 在当前对象分组内开始裁剪分组
+// 入参: path 裁剪路径
+func (w *svgWriter) clip(path *canvas.Path) {
+	w.flush()
+	d := path.Copy().Transform(canvas.Identity.ReflectYAbout(w.height / 2)).ToSVG()
+	id, ok := w.clips[d]
+	if !ok {
+		id = fmt.Sprintf("ofd-clip-%d", len(w.clipList))
+		w.clips[d] = id
+		w.clipList = append(w.clipList, fmt.Sprintf(`<clipPath id="%s" clipPathUnits="userSpaceOnUse"><path d="%s"/></clipPath>`, id, d))
+	}
+	fmt.Fprintf(w.w, `<g clip-path="url(#%s)">`, id)
+	if n := len(w.groups); n > 0 {
+		w.groups[n-1] = append([]string{"</g>"}, w.groups[n-1]...)
+	} else {
+		w.groups = append(w.groups, []string{"</g>"})
+	}
+}
+
+// end 结束当前对象分组
+func (w *svgWriter) end() {
+	w.flush()
+	if len(w.groups) == 0 {
+		return
+	}
+	io.WriteString(w.w, strings.Join(w.groups[len(w.groups)-1], ""))
+	w.groups = w.groups[:len(w.groups)-1]
+}
+
+// close 写出定义和字体并结束SVG
+// 字体族名按子集字体内容摘要确定, 多个SVG内联到同一页面时不会相互覆盖
+// 入参: fonts 字体处理函数
+// 返回: error 错误信息
+func (w *svgWriter) close(fonts SVGFontHandler) error {
+	w.flush()
+	for len(w.groups) > 0 {
+		w.end()
+	}
+	if len(w.clipList) > 0 {
+		io.WriteString(w.w, "<defs>"+strings.Join(w.clipList, "")+"</defs>")
+	}
+	if len(w.fontList) > 0 {
+		faces := make([]string, 0, len(w.fontList))
+		for _, f := range w.fontList {
+			family, src, err := f.source(fonts)
+			if err != nil {
+				return err
+			}
+			data := bytes.ReplaceAll(w.w.Bytes(), []byte("font-family:'"+f.family+"';"), []byte("font-family:'"+family+"';"))
+			w.w.Reset()
+			w.w.Write(data)
+			faces = append(faces, fmt.Sprintf("\n@font-face{font-family:'%s';src:%s;}", family, src))
+		}
+		io.WriteString(w.w, "<style>"+strings.Join(faces, "")+"\n</style>")
+	}
+	return w.svg.Close()
+}
+
+// source 生成子集字体
+// 入参: fonts 字体处理函数
+// 返回: string 字体族名, string CSS来源, error 错误信息
+func (f *svgFont) source(fonts SVGFontHandler) (string, string, error) {
+	sfnt := f.font.SFNT
+	glyphIDs := make([]uint16, 0, len(f.glyphs)+1)
+	glyphIDs = append(glyphIDs, 0)
+	for id := range f.glyphs {
+		if id != 0 {
+			glyphIDs = append(glyphIDs, id)
+		}
+	}
+	sort.Slice(glyphIDs, func(i, j int) bool { return glyphIDs[i] < glyphIDs[j] })
+	if subset, err := sfnt.Subset(glyphIDs, font.SubsetOptions{Tables: font.KeepMinTables}); err == nil {
+		sfnt = subset
+	}
+	data, err := sfnt.WriteWOFF2()
+	format, mediaType, ext := "woff2", "font/woff2", ".woff2"
+	if err != nil {
+		data, format, mediaType, ext = sfnt.Write(), "opentype", "font/otf", ".otf"
+	}
+	sum := sha256.Sum256(data)
+	name := hex.EncodeToString(sum[:8])
+	family := "ofd-" + name
+	if fonts == nil {
+		return family, fmt.Sprintf("url('data:%s;base64,%s') format('%s')", mediaType, base64.StdEncoding.EncodeToString(data), format), nil
+	}
+	url, err := fonts(name+ext, data)
+	if err != nil {
+		return "", "", err
+	}
+	var b strings.Builder
+	svgEscape(&b, url)
+	return family, fmt.Sprintf("url('%s') format('%s')", strings.ReplaceAll(b.String(), "'", "%27"), format), nil
+}
+
+// renderVectorSVG 渲染为矢量SVG
+// 入参: page 页面内容, writer 输出流
+// 返回: error 错误信息
+func (r *Renderer) renderVectorSVG(page *PageContent, writer io.Writer) error {
+	box, err := r.GetPageBox(page)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	w := newSVGWriter(&buf, box.W, box.H)
+	renderer := *r
+	renderer.svg = w
+	renderer.fontText = true
+	renderer.svgBookmarks = make(map[string]Dest)
+	if doc, err := r.Reader.Doc(); err == nil {
+		for _, bookmark := range doc.Bookmarks.Bookmark {
+			renderer.svgBookmarks[bookmark.Name] = bookmark.Dest
+		}
+	}
+	w.page(page.ID)
+	if err := renderer.renderPageToContext(canvas.NewContext(w), page, true); err != nil {
+		return err
+	}
+	if err := w.close(r.svgFonts); err != nil {
+		return err
+	}
+	_, err = buf.WriteTo(writer)
+	return err
+}
+
+// svgObject 开始写出对象分组
+// 非矢量SVG渲染时不做处理
+// 入参: kind 对象类型, id 对象ID, actions 对象动作
+// 返回: func() 结束对象分组的函数
+func (r *Renderer) svgObject(kind, id string, actions []Action) func() {
+	if r.svg == nil {
+		return func() {}
+	}
+	href, page := r.svgHref(actions)
+	r.svg.begin(kind, id, href, page)
+	return r.svg.end
+}
+
+// svgHref 获取对象动作对应的链接地址
+// 取首个单击触发的URI或Goto动作, Goto动作链接到目标页面分组, 协议不在htmlURISchemes中的URI动作被忽略
+// 入参: actions 对象动作
+// 返回: string 链接地址, 无可用动作时为空, string 跳转目标页面ID
+func (r *Renderer) svgHref(actions []Action) (string, string) {
+	for _, action := range actions {
+		if action.Event != "" && action.Event != "CLICK" {
+			continue
+		}
+		if action.URI != nil && action.URI.URI != "" {
+			if uri := resolveActionURI(*action.URI); htmlSafeURI(uri) {
+				return uri, ""
+			}
+			continue
+		}
+		if action.Goto != nil {
+			if dest := gotoDest(action.Goto, r.svgBookmarks); dest != nil && dest.PageID != "" {
+				return "#ofd-page-" + dest.PageID, dest.PageID
+			}
+		}
+	}
+	return "", ""
+}
+
+// objectClip 应用对象裁剪路径
+// 矢量SVG渲染时裁剪路径写入defs并以裁剪分组应用, 不再向下传递
+// 入参: parent 父级裁剪路径, clip 对象裁剪路径
+// 返回: *canvas.Path 需要对象自行应用的裁剪路径
+func (r *Renderer) objectClip(parent, clip *canvas.Path) *canvas.Path {
+	if r.svg == nil || clip == nil {
+		return intersectClipPath(parent, clip)
+	}
+	r.svg.clip(clip)
+	return parent
+}
+
+// svgColor 转换SVG颜色
+// 入参: c 预乘透明度的颜色
+// 返回: string 十六进制颜色, string 不透明度, 不透明时为空
+func svgColor(c color.RGBA) (string, string) {
+	if c.A == 0 {
+		return "none", ""
+	}
+	r, g, b := uint32(c.R)*255/uint32(c.A), uint32(c.G)*255/uint32(c.A), uint32(c.B)*255/uint32(c.A)
+	fill := fmt.Sprintf("#%02x%02x%02x", min(r, 255), min(g, 255), min(b, 255))
+	if c.A == 255 {
+		return fill, ""
+	}
+	return fill, formatOFDNumber(float64(c.A) / 255)
+}
+
+// svgEscape 写出转义后的XML文本
+// 入参: w 输出流, s 文本
+func svgEscape(w io.Writer, s string) {
+	io.WriteString(w, xmlEscapeString(s))
+}
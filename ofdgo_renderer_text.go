@@ -29,6 +29,10 @@ func (r *Renderer) renderText(ctx *canvas.Context, obj TextObject, pageH float64
 	if obj.Visible != nil && !*obj.Visible {
 		return
 	}
+	if !r.budget.object() {
+		return
+	}
+	defer r.svgObject("TextObject", obj.ID, obj.Actions)()
 	ctx.Push()
 	bx, by := 0.0, 0.0
 	if obj.Boundary != "" {
@@ -41,7 +45,7 @@ func (r *Renderer) renderText(ctx *canvas.Context, obj TextObject, pageH float64
 	if parentCTM != nil {
 		ctm = parentCTM.Multiply(ctm)
 	}
-	clipPath := intersectClipPath(parentClip, r.buildClipPath(obj.Clips, pageH, bx, by, ctm))
+	clipPath := r.objectClip(parentClip, r.buildClipPath(obj.Clips, pageH, bx, by, ctm))
 	var dp *DrawParam
 	if obj.DrawParam != "" {
 		dp = r.getDrawParam(obj.DrawParam, nil)
@@ -116,6 +120,7 @@ func (r *Renderer) renderText(ctx *canvas.Context, obj TextObject, pageH float64
 	}
 	ff := r.loadFont(fontID)
 	if ff == nil {
+		r.diagnose(DiagnosticError, obj.ID, "no font available for text")
 		ctx.Pop()
 		return
 	}
@@ -123,20 +128,26 @@ func (r *Renderer) renderText(ctx *canvas.Context, obj TextObject, pageH float64
 	glyphTransforms := r.textObjectGlyphTransforms(fontID, obj)
 	hasUnderline := strings.Contains(obj.Decoration, "Underline")
 	useGlyphFillPaint := fillColorNode != nil && fillColorNode.AxialShd != nil
+	var layerFaces []*canvas.FontFace
+	if r.textLayer {
+		layerFaces = r.textLayerFaces(ff, sizePt, fontStyle)
+	}
 	codePos := 0
 	for _, tc := range obj.TextCode {
 		var runes []rune
 		var glyphs []textGlyph
+		var sources []string
 		if tc.Index != "" {
 			runes = r.parseIndexRunes(tc.Index, fontID)
 			glyphs = textRuneGlyphs(runes)
 		} else {
 			runes = textCodeRunes(tc.Value)
 			glyphs = textCodeGlyphs(runes, glyphTransforms, codePos)
+			sources = textCodeGlyphSources(runes, glyphTransforms, codePos)
 		}
 		dxs, dys := parseFloats(tc.DeltaX), parseFloats(tc.DeltaY)
 		xs, ys := parseFloats(tc.X), parseFloats(tc.Y)
-		drawAsPath := embeddedFont || textCodePositioned(tc, xs, ys) || clipPath != nil
+		drawAsPath := clipPath != nil || !r.fontText && (embeddedFont || textCodePositioned(tc, xs, ys))
 		cx, cy := 0.0, 0.0
 		if len(xs) > 0 {
 			cx = xs[0]
@@ -199,6 +210,9 @@ func (r *Renderer) renderText(ctx *canvas.Context, obj TextObject, pageH float64
 					}
 					glyphPath = applyClipPath(glyphPath.Copy().Transform(textTransform.Scale(scaleX, 1)), clipPath)
 					ctx.DrawPath(0, 0, glyphPath)
+					if r.textLayer {
+						r.drawTextLayerGlyph(ctx, face, layerFaces, glyph, sources, i, textTransform, textWidth)
+					}
 					if hasUnderline {
 						uw := sizeMM * 0.05
 						off := sizeMM * 0.1
@@ -229,6 +243,9 @@ func (r *Renderer) renderText(ctx *canvas.Context, obj TextObject, pageH float64
 						} else {
 							ctx.DrawPath(x, y, glyphPath)
 						}
+						if r.textLayer {
+							r.drawTextLayerGlyph(ctx, face, layerFaces, glyph, sources, i, canvas.Identity.Translate(x, y), textWidth)
+						}
 					} else {
 						scaled := hScale != 1
 						if scaled {
@@ -0,0 +1,477 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"bytes"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/tdewolff/canvas"
+	"github.com/tdewolff/font"
+)
+
+// textLayerFill 文本层填充色
+// PDF写出器对完全透明的填充色会输出无效的颜色值, 因此使用最低的非零透明度,
+// PDF写出后按此透明度识别文本层并改为不可见渲染模式
+var textLayerFill = color.RGBA{A: 1}
+
+// WithPDFTextLayer 设置PDF导出是否叠加文本层
+// 嵌入字体、逐字定位和带裁剪的文本按字形轮廓绘制, 开启后在字形位置叠加不可见文本,
+// 文本层使用与字形轮廓相同的字体和字形ID, ToUnicode由TextCode及字体的字形到字符映射生成,
+// 使导出的PDF可搜索和复制, 默认开启
+// 入参: enable 是否叠加
+// 返回: RendererOption 渲染选项
+func WithPDFTextLayer(enable bool) RendererOption {
+	return func(r *Renderer) {
+		r.pdfTextLayer = enable
+	}
+}
+
+// renderPDFPage 渲染用于PDF导出的页面
+// 可导出为PDF原生注释的注释不绘制到页面内容
+// 入参: page 页面内容, glyphs 同一PDF共用的文本层字形字体
+// 返回: *canvas.Canvas 画布实例, error 错误信息
+func (r *Renderer) renderPDFPage(page *PageContent, glyphs *textLayerGlyphFonts) (*canvas.Canvas, error) {
+	renderer := *r
+	renderer.textLayer = r.pdfTextLayer
+	renderer.textLayerGlyphs = glyphs
+	renderer.pdfAnnotations = true
+	return renderer.renderPage(page)
+}
+
+// textLayerGlyphFonts 文本层字形字体集合
+// 每次PDF写出单独创建, 为每个文本对象字体复制一份以私有字符映射全部字形的字体,
+// 文本层按字形轮廓的字形ID绘制, PDF写出器按渲染中记录的字符生成ToUnicode
+type textLayerGlyphFonts struct {
+	mu    sync.Mutex
+	fonts map[*canvas.Font]*textLayerGlyphFont
+}
+
+// textLayerGlyphFont 文本层字形字体
+type textLayerGlyphFont struct {
+	font    *canvas.Font
+	unicode textLayerCmap
+}
+
+// textLayerCmap 文本层字形到字符映射
+// 作为文本层字形字体cmap的首个子表, 只参与字形到字符的反查
+type textLayerCmap map[uint16]rune
+
+// Get 获取字符对应的字形ID
+// 入参: c 字符
+// 返回: uint16 字形ID, bool 是否存在
+func (m textLayerCmap) Get(c rune) (uint16, bool) {
+	return 0, false
+}
+
+// ToUnicode 获取字形ID对应的字符
+// 入参: glyphID 字形ID
+// 返回: []rune 字符
+func (m textLayerCmap) ToUnicode(glyphID uint16) []rune {
+	if c, ok := m[glyphID]; ok {
+		return []rune{c}
+	}
+	return nil
+}
+
+// newTextLayerGlyphFonts 创建文本层字形字体集合
+// 返回: *textLayerGlyphFonts 文本层字形字体集合
+func newTextLayerGlyphFonts() *textLayerGlyphFonts {
+	return &textLayerGlyphFonts{fonts: make(map[*canvas.Font]*textLayerGlyphFont)}
+}
+
+// get 获取文本对象字体对应的文本层字形字体
+// 文本对象字体属于渲染独占的缓存状态, 其字形字体同一时刻只由一个渲染记录字符
+// 入参: f 文本对象字体
+// 返回: *textLayerGlyphFont 文本层字形字体, 无法复制字体时为nil
+func (g *textLayerGlyphFonts) get(f *canvas.Font) *textLayerGlyphFont {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if layer, ok := g.fonts[f]; ok {
+		return layer
+	}
+	layer := newTextLayerGlyphFont(f)
+	g.fonts[f] = layer
+	return layer
+}
+
+// newTextLayerGlyphFont 复制文本对象字体为文本层字形字体
+// 入参: f 文本对象字体
+// 返回: *textLayerGlyphFont 文本层字形字体, 无法复制或子集化时为nil
+func newTextLayerGlyphFont(f *canvas.Font) *textLayerGlyphFont {
+	numGlyphs := f.SFNT.NumGlyphs()
+	if numGlyphs == 0 || len(f.SFNT.Tables) == 0 {
+		return nil
+	}
+	mapping := make(map[rune]uint16, numGlyphs)
+	addPackedGlyphMapping(mapping, numGlyphs)
+	tables := make(map[string][]byte, len(f.SFNT.Tables))
+	for tag, data := range f.SFNT.Tables {
+		tables[tag] = data
+	}
+	tables["cmap"] = buildCmapTable(numGlyphs, mapping)
+	data, err := serializeOTF(tables)
+	if err != nil {
+		return nil
+	}
+	layerFont, err := canvas.LoadFont(data, 0, canvas.FontRegular)
+	if err != nil || layerFont.SFNT.Cmap == nil || !fontSubsettable(layerFont) {
+		return nil
+	}
+	unicode := make(textLayerCmap)
+	layerFont.SFNT.Cmap.Subtables = append(append(layerFont.SFNT.Cmap.Subtables[:0:0], unicode), layerFont.SFNT.Cmap.Subtables...)
+	return &textLayerGlyphFont{font: layerFont, unicode: unicode}
+}
+
+// record 记录字形对应的字符
+// 入参: glyphID 字形ID, text 字形对应的文本
+// 返回: bool 文本为单个字符且与已记录字符一致时为true
+func (l *textLayerGlyphFont) record(glyphID uint16, text string) bool {
+	runes := []rune(text)
+	if len(runes) != 1 || isPackedGlyphRune(runes[0]) {
+		return false
+	}
+	if c, ok := l.unicode[glyphID]; ok {
+		return c == runes[0]
+	}
+	l.unicode[glyphID] = runes[0]
+	return true
+}
+
+// textLayerFaces 获取文本层候选字体
+// 优先使用文本对象字体, 使文本层宽度与字形一致, 其次使用默认字体
+// 入参: ff 文本对象字体族, sizePt 字号, style 字体样式
+// 返回: []*canvas.FontFace 候选字体
+func (r *Renderer) textLayerFaces(ff *canvas.FontFamily, sizePt float64, style canvas.FontStyle) []*canvas.FontFace {
+	faces := make([]*canvas.FontFace, 0, 2)
+	if face := ff.Face(sizePt, textLayerFill, style, canvas.FontNormal); r.textLayerFontUsable(face.Font) {
+		faces = append(faces, face)
+	}
+	if r.defaultFontLoaded && ff != r.fontFamily {
+		if face := r.fontFamily.Face(sizePt, textLayerFill, canvas.FontRegular, canvas.FontNormal); r.textLayerFontUsable(face.Font) {
+			faces = append(faces, face)
+		}
+	}
+	return faces
+}
+
+// textLayerFontUsable 判断字体能否用于文本层
+// PDF写出器在字体子集化失败时会panic, 因此预先对全部字形做一次子集化检查
+// 入参: f 字体
+// 返回: bool 是否可用
+func (r *Renderer) textLayerFontUsable(f *canvas.Font) bool {
+	if usable, ok := r.textLayerFonts[f]; ok {
+		return usable
+	}
+	usable := fontSubsettable(f)
+	r.textLayerFonts[f] = usable
+	return usable
+}
+
+// fontSubsettable 判断字体能否按PDF需要子集化
+// 入参: f 字体
+// 返回: bool 全部字形均可子集化时为true
+func fontSubsettable(f *canvas.Font) bool {
+	glyphIDs := make([]uint16, f.SFNT.NumGlyphs())
+	for i := range glyphIDs {
+		glyphIDs[i] = uint16(i)
+	}
+	_, err := f.SFNT.Subset(glyphIDs, font.SubsetOptions{Tables: font.KeepPDFTables})
+	return err == nil
+}
+
+// drawTextLayerGlyph 在字形位置绘制文本层
+// PDF导出时按字形轮廓的字形ID以文本层字形字体绘制并记录字形对应的字符,
+// 字形对应多个字符、字符无法确定或与已记录字符冲突时以候选字体绘制文本
+// 入参: ctx 画布上下文, face 文本对象字体, faces 候选字体, glyph 绘制字形, sources 字形对应的TextCode原文, index 字形索引, m 字形原点变换, width 字形绘制宽度
+func (r *Renderer) drawTextLayerGlyph(ctx *canvas.Context, face *canvas.FontFace, faces []*canvas.FontFace, glyph textGlyph, sources []string, index int, m canvas.Matrix, width float64) {
+	text := textLayerText(face, glyph, sources, index)
+	if r.textLayerGlyphs != nil {
+		if glyphID, ok := textLayerGlyphID(face, glyph); ok {
+			if layer := r.textLayerGlyphs.get(face.Font); layer != nil && layer.record(glyphID, text) {
+				layerFace := layer.font.Face(face.Size*ptPerMM, textLayerFill)
+				drawTextLayer(ctx, []*canvas.FontFace{layerFace}, string(packedGlyphRune(glyphID)), m, width)
+				return
+			}
+		}
+	}
+	drawTextLayer(ctx, faces, text, m, width)
+}
+
+// textLayerGlyphID 获取字形轮廓使用的字形ID
+// 入参: face 文本对象字体, glyph 绘制字形
+// 返回: uint16 字形ID, bool 是否存在
+func textLayerGlyphID(face *canvas.FontFace, glyph textGlyph) (uint16, bool) {
+	if glyph.GlyphID >= 0 {
+		if glyph.GlyphID == 0 || glyph.GlyphID > 0xFFFF {
+			return 0, false
+		}
+		return uint16(glyph.GlyphID), true
+	}
+	runes := []rune(glyph.Text)
+	if len(runes) != 1 {
+		return 0, false
+	}
+	glyphID := face.Font.SFNT.GlyphIndex(runes[0])
+	return glyphID, glyphID != 0
+}
+
+// drawTextLayer 在字形位置绘制文本层
+// 入参: ctx 画布上下文, faces 候选字体, text 文本, m 字形原点变换, width 字形绘制宽度
+func drawTextLayer(ctx *canvas.Context, faces []*canvas.FontFace, text string, m canvas.Matrix, width float64) {
+	if text == "" {
+		return
+	}
+	face := textLayerFace(faces, text)
+	if face == nil {
+		return
+	}
+	ctx.Push()
+	ctx.ComposeView(m)
+	if natural := face.TextWidth(text); natural > 0 && width > 0 {
+		ctx.Scale(width/natural, 1)
+	}
+	ctx.DrawText(0, 0, canvas.NewTextLine(face, text, canvas.Left))
+	ctx.Pop()
+}
+
+// textLayerFace 选择包含全部字符的文本层字体
+// 入参: faces 候选字体, text 文本
+// 返回: *canvas.FontFace 字体, 均不包含时为nil
+func textLayerFace(faces []*canvas.FontFace, text string) *canvas.FontFace {
+	for _, face := range faces {
+		covered := true
+		for _, c := range text {
+			if c != ' ' && face.Font.SFNT.GlyphIndex(c) == 0 {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return face
+		}
+	}
+	return nil
+}
+
+// textLayerText 获取绘制字形对应的文本
+// 字形变换按变换组取TextCode原文, 组内首个字形承载整组文本
+// 按字形ID或包装字体字符绘制的字形从字体cmap反查字符
+// 入参: face 文本对象字体, glyph 绘制字形, sources 字形对应的TextCode原文, 为nil时由字形推导, index 字形索引
+// 返回: string 文本
+func textLayerText(face *canvas.FontFace, glyph textGlyph, sources []string, index int) string {
+	if sources != nil {
+		return sources[index]
+	}
+	gid := glyph.GlyphID
+	if gid < 0 {
+		runes := []rune(glyph.Text)
+		if len(runes) != 1 || !isPackedGlyphRune(runes[0]) {
+			return glyph.Text
+		}
+		gid = int(runes[0] - packedGlyphRune(0))
+	}
+	if gid > 0xFFFF {
+		return ""
+	}
+	for _, c := range face.Font.SFNT.GlyphToUnicode(uint16(gid)) {
+		if !isPackedGlyphRune(c) {
+			return string(c)
+		}
+	}
+	return ""
+}
+
+// textCodeGlyphSources 获取字形变换后每个绘制字形对应的TextCode原文
+// 入参: runes 文本字符, transforms 字形变换, codeOffset 文本编码偏移
+// 返回: []string 字形对应的原文, 无字形变换时为nil
+func textCodeGlyphSources(runes []rune, transforms map[int]textGlyphTransform, codeOffset int) []string {
+	if len(transforms) == 0 {
+		return nil
+	}
+	sources := make([]string, 0, len(runes))
+	for i := 0; i < len(runes); {
+		if transform, ok := transforms[codeOffset+i]; ok && i+transform.CodeCount <= len(runes) {
+			sources = append(sources, string(runes[i:i+transform.CodeCount]))
+			for range transform.Glyphs[1:] {
+				sources = append(sources, "")
+			}
+			i += transform.CodeCount
+			continue
+		}
+		sources = append(sources, string(runes[i]))
+		i++
+	}
+	return sources
+}
+
+// isPackedGlyphRune 判断是否为包装字体字符
+// 入参: c 字符
+// 返回: bool 是否为包装字体字符
+func isPackedGlyphRune(c rune) bool {
+	return c >= packedGlyphRune(0) && c <= packedGlyphRune(0xFFFF)
+}
+
+// pdfInvisibleTextLayer 将PDF中的文本层改为不可见渲染模式
+// 文本层以textLayerFill的透明度写出, 按页面和表单资源中的图形状态识别,
+// 在文本显示操作前切换为渲染模式3, 其余文本保持原渲染模式
+// 入参: f PDF改写器
+func pdfInvisibleTextLayer(f *pdfFile) {
+	for num := 1; num < len(f.objects); num++ {
+		dict := f.dict(num)
+		var streams []int
+		switch {
+		case pdfDictGet(dict, "Type") == "/Page":
+			streams = pdfRefNumbers(pdfDictGet(dict, "Contents"))
+		case pdfDictGet(dict, "Subtype") == "/Form":
+			streams = []int{num}
+		default:
+			continue
+		}
+		states := pdfTextLayerStates(f, pdfDictGet(dict, "Resources"))
+		if len(states) == 0 {
+			continue
+		}
+		for _, stream := range streams {
+			data, ok := f.streamData(stream)
+			if !ok {
+				continue
+			}
+			if data, ok = pdfInvisibleTextLayerContent(data, states); ok {
+				f.setStream(stream, data)
+			}
+		}
+	}
+}
+
+// pdfTextLayerStates 获取资源中设置填充透明度的图形状态
+// 入参: f PDF改写器, resources 资源字典或其间接引用
+// 返回: map[string]bool 图形状态名称到是否为文本层透明度的映射
+func pdfTextLayerStates(f *pdfFile, resources string) map[string]bool {
+	resolve := func(value string) string {
+		if num := pdfRefNumber(value); num > 0 {
+			return f.dict(num)
+		}
+		return value
+	}
+	extGState := resolve(pdfDictGet(resolve(resources), "ExtGState"))
+	alpha := float64(textLayerFill.A) / 255
+	states := make(map[string]bool)
+	layer := false
+	for _, name := range pdfDictKeys(extGState) {
+		ca, err := strconv.ParseFloat(pdfDictGet(resolve(pdfDictGet(extGState, name)), "ca"), 64)
+		if err != nil {
+			continue
+		}
+		states[name] = math.Abs(ca-alpha) < 1e-4
+		layer = layer || states[name]
+	}
+	if !layer {
+		return nil
+	}
+	return states
+}
+
+// pdfInvisibleTextLayerContent 改写内容流中文本层的渲染模式
+// 按q和Q跟踪图形状态, 文本层的文本显示操作前插入3 Tr, 其后的其他文本恢复原渲染模式
+// 入参: data 内容流, states 图形状态名称到是否为文本层透明度的映射
+// 返回: []byte 新内容流, bool 是否改写
+func pdfInvisibleTextLayerContent(data []byte, states map[string]bool) ([]byte, bool) {
+	type textState struct {
+		layer  bool
+		mode   string
+		actual string
+	}
+	state := textState{mode: "0", actual: "0"}
+	var stack []textState
+	var out bytes.Buffer
+	var operands []string
+	last, operandStart := 0, 0
+	changed := false
+	for i := pdfSkipSpace(data, 0); i < len(data); i = pdfSkipSpace(data, i) {
+		next := pdfSkipValue(data, i)
+		if next <= i {
+			break
+		}
+		token := string(data[i:next])
+		if !pdfContentOperator(token) {
+			if len(operands) == 0 {
+				operandStart = i
+			}
+			operands = append(operands, token)
+			i = next
+			continue
+		}
+		if len(operands) == 0 {
+			operandStart = i
+		}
+		switch token {
+		case "q":
+			stack = append(stack, state)
+		case "Q":
+			if n := len(stack); n > 0 {
+				state, stack = stack[n-1], stack[:n-1]
+			}
+		case "gs":
+			if len(operands) > 0 {
+				if layer, ok := states[strings.TrimPrefix(operands[len(operands)-1], "/")]; ok {
+					state.layer = layer
+				}
+			}
+		case "Tr":
+			if len(operands) > 0 {
+				state.mode = operands[len(operands)-1]
+				state.actual = state.mode
+			}
+		case "Tj", "TJ", "'", "\"":
+			mode := state.mode
+			if state.layer {
+				mode = "3"
+			}
+			if mode != state.actual {
+				out.Write(data[last:operandStart])
+				out.WriteString(" " + mode + " Tr ")
+				last = operandStart
+				state.actual = mode
+				changed = true
+			}
+		}
+		operands = operands[:0]
+		i = next
+	}
+	if !changed {
+		return data, false
+	}
+	out.Write(data[last:])
+	return out.Bytes(), true
+}
+
+// pdfContentOperator 判断内容流记号是否为操作符
+// 入参: token 记号
+// 返回: bool 是否为操作符
+func pdfContentOperator(token string) bool {
+	switch token {
+	case "true", "false", "null":
+		return false
+	case "'", "\"":
+		return true
+	}
+	c := token[0]
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
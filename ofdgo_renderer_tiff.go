@@ -0,0 +1,705 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+	"math"
+
+	"github.com/tdewolff/canvas"
+	"github.com/tdewolff/canvas/renderers/rasterizer"
+)
+
+// TIFFCompression TIFF压缩方式
+type TIFFCompression int
+
+const (
+	TIFFCompressionLZW  TIFFCompression = iota // LZW压缩
+	TIFFCompressionG4                          // CCITT G4压缩, 仅用于二值图像
+	TIFFCompressionNone                        // 不压缩
+)
+
+// TIFFColor TIFF颜色模式
+type TIFFColor int
+
+const (
+	TIFFColorRGB     TIFFColor = iota // 24位RGB
+	TIFFColorGray                     // 8位灰度
+	TIFFColorBilevel                  // 1位二值
+)
+
+// TIFFDither 二值化抖动方式
+type TIFFDither int
+
+const (
+	TIFFDitherThreshold      TIFFDither = iota // 固定阈值
+	TIFFDitherFloydSteinberg                   // Floyd-Steinberg误差扩散
+)
+
+// tiffOptions TIFF导出选项
+type tiffOptions struct {
+	Compression TIFFCompression
+	Color       TIFFColor
+	Dither      TIFFDither
+	Threshold   uint8
+}
+
+// TIFFOption TIFF导出配置选项
+type TIFFOption func(*tiffOptions)
+
+// WithTIFFCompression 设置TIFF压缩方式
+// 入参: compression 压缩方式, 默认LZW
+// 返回: TIFFOption TIFF选项
+func WithTIFFCompression(compression TIFFCompression) TIFFOption {
+	return func(o *tiffOptions) {
+		o.Compression = compression
+	}
+}
+
+// WithTIFFColor 设置TIFF颜色模式
+// 入参: color 颜色模式, 默认RGB
+// 返回: TIFFOption TIFF选项
+func WithTIFFColor(color TIFFColor) TIFFOption {
+	return func(o *tiffOptions) {
+		o.Color = color
+	}
+}
+
+// WithTIFFDither 设置二值图像的抖动方式
+// 入参: dither 抖动方式, 默认固定阈值
+// 返回: TIFFOption TIFF选项
+func WithTIFFDither(dither TIFFDither) TIFFOption {
+	return func(o *tiffOptions) {
+		o.Dither = dither
+	}
+}
+
+// WithTIFFThreshold 设置二值化阈值
+// 灰度低于阈值的像素输出为黑色
+// 入参: threshold 阈值, 默认128
+// 返回: TIFFOption TIFF选项
+func WithTIFFThreshold(threshold uint8) TIFFOption {
+	return func(o *tiffOptions) {
+		o.Threshold = threshold
+	}
+}
+
+// newTIFFOptions 创建TIFF导出选项
+// 入参: opts TIFF选项
+// 返回: *tiffOptions TIFF导出选项, error 错误信息
+func newTIFFOptions(opts []TIFFOption) (*tiffOptions, error) {
+	o := &tiffOptions{Threshold: 128}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.Compression == TIFFCompressionG4 && o.Color != TIFFColorBilevel {
+		return nil, fmt.Errorf("ccitt g4 compression requires bilevel color")
+	}
+	return o, nil
+}
+
+// tiffPage 已编码的TIFF页面
+type tiffPage struct {
+	Width  int
+	Height int
+	Data   []byte
+}
+
+// RenderToTIFF 按DPI渲染单页并写出为TIFF
+// 入参: page 页面内容, writer 输出流, opts TIFF选项
+// 返回: error 错误信息
+func (r *Renderer) RenderToTIFF(page *PageContent, writer io.Writer, opts ...TIFFOption) error {
+	o, err := newTIFFOptions(opts)
+	if err != nil {
+		return err
+	}
+	img, err := r.RenderToImage(page)
+	if err != nil {
+		return err
+	}
+	tw := newTIFFWriter(writer, o, r.DPI)
+	if err := tw.writePage(o.encode(img)); err != nil {
+		return err
+	}
+	return tw.close()
+}
+
+// RenderToMultiPageTIFF 将整个文档导出为多页TIFF
+// 页面按WithWorkers设置并发渲染和编码, 按页面顺序流式写入
+// 入参: writer 输出流, opts TIFF选项
+// 返回: error 错误信息
+func (r *Renderer) RenderToMultiPageTIFF(writer io.Writer, opts ...TIFFOption) error {
+	_, err := r.ExportMultiPageTIFF(writer, opts...)
+	return err
+}
+
+// ExportMultiPageTIFF 将整个文档导出为多页TIFF并返回导出结果摘要
+// 页面按渲染器DPI栅格化, 每页编码完成后立即写出, 页面错误按WithPageErrorPolicy处理
+// 入参: writer 输出流, opts TIFF选项
+// 返回: *ExportSummary 导出结果摘要, error 错误信息
+func (r *Renderer) ExportMultiPageTIFF(writer io.Writer, opts ...TIFFOption) (*ExportSummary, error) {
+	o, err := newTIFFOptions(opts)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := r.Reader.Doc()
+	if err != nil {
+		return nil, err
+	}
+	if len(doc.Pages.Page) == 0 {
+		return nil, fmt.Errorf("no pages found")
+	}
+	summary := &ExportSummary{Total: len(doc.Pages.Page)}
+	pages, readErrs, err := r.readExportPages(doc)
+	if err != nil {
+		return summary, err
+	}
+	encoded := make([]tiffPage, len(pages))
+	render := func(renderer *Renderer, i int) pageResult {
+		if readErrs[i] != nil {
+			return pageResult{index: i, err: readErrs[i]}
+		}
+		img, err := renderer.RenderToImage(pages[i].Content)
+		if err != nil {
+			return pageResult{index: i, err: &PageError{Index: i, Stage: ExportStageRender, Err: err}}
+		}
+		encoded[i] = o.encode(img)
+		return pageResult{index: i}
+	}
+	tw := newTIFFWriter(writer, o, r.DPI)
+	written := 0
+	err = r.renderPagesParallel(len(pages), true, render, func(result pageResult) error {
+		page := encoded[result.index]
+		encoded[result.index] = tiffPage{}
+		if result.err != nil {
+			placeholder, err := r.pageFailed(summary, result.err)
+			if err != nil || !placeholder {
+				return err
+			}
+			c := r.placeholderCanvas(pages[result.index].Box, result.err)
+			page = o.encode(rasterizer.Draw(c, canvas.DPMM(r.DPI/25.4), canvas.DefaultColorSpace))
+		} else {
+			summary.Rendered++
+		}
+		if err := tw.writePage(page); err != nil {
+			return err
+		}
+		written++
+		r.reportProgress(ExportStageWrite, result.index, written, len(pages))
+		return nil
+	})
+	summary.finish()
+	if err != nil {
+		return summary, err
+	}
+	if written == 0 {
+		return summary, fmt.Errorf("no pages rendered")
+	}
+	return summary, tw.close()
+}
+
+// encode 按颜色模式和压缩方式编码页面图像
+// 入参: img 页面图像
+// 返回: tiffPage 已编码的页面
+func (o *tiffOptions) encode(img image.Image) tiffPage {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	var data []byte
+	switch o.Color {
+	case TIFFColorGray:
+		data = tiffGray(img)
+	case TIFFColorBilevel:
+		bits := tiffBilevel(tiffGray(img), w, h, o.Dither, o.Threshold)
+		if o.Compression == TIFFCompressionG4 {
+			return tiffPage{Width: w, Height: h, Data: tiffG4Encode(bits, w, h)}
+		}
+		data = tiffPackBits(bits, w, h)
+	default:
+		data = tiffRGB(img)
+	}
+	if o.Compression == TIFFCompressionLZW {
+		data = tiffLZWEncode(data)
+	}
+	return tiffPage{Width: w, Height: h, Data: data}
+}
+
+// tiffRGB 将图像转为白底合成的RGB采样
+// 入参: img 图像
+// 返回: []byte 逐行排列的RGB采样
+func tiffRGB(img image.Image) []byte {
+	b := img.Bounds()
+	data := make([]byte, 0, b.Dx()*b.Dy()*3)
+	if rgba, ok := img.(*image.RGBA); ok {
+		for y := b.Min.Y; y < b.Max.Y; y++ {
+			row := rgba.Pix[rgba.PixOffset(b.Min.X, y):rgba.PixOffset(b.Max.X, y)]
+			for i := 0; i < len(row); i += 4 {
+				bg := 255 - row[i+3]
+				data = append(data, row[i]+bg, row[i+1]+bg, row[i+2]+bg)
+			}
+		}
+		return data
+	}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			cr, cg, cb, ca := img.At(x, y).RGBA()
+			bg := 0xffff - ca
+			data = append(data, byte((cr+bg)>>8), byte((cg+bg)>>8), byte((cb+bg)>>8))
+		}
+	}
+	return data
+}
+
+// tiffGray 将图像转为白底合成的8位灰度
+// 入参: img 图像
+// 返回: []byte 逐行排列的灰度采样
+func tiffGray(img image.Image) []byte {
+	b := img.Bounds()
+	rgb := tiffRGB(img)
+	data := make([]byte, b.Dx()*b.Dy())
+	for i := range data {
+		cr, cg, cb := uint32(rgb[i*3]), uint32(rgb[i*3+1]), uint32(rgb[i*3+2])
+		data[i] = byte((19595*cr + 38470*cg + 7471*cb + 1<<15) >> 16)
+	}
+	return data
+}
+
+// tiffBilevel 将灰度图像二值化
+// 入参: gray 灰度采样, w 宽度, h 高度, dither 抖动方式, threshold 阈值
+// 返回: []byte 逐像素二值结果, 1为黑色
+func tiffBilevel(gray []byte, w, h int, dither TIFFDither, threshold uint8) []byte {
+	bits := make([]byte, w*h)
+	if dither != TIFFDitherFloydSteinberg {
+		for i, v := range gray {
+			if v < threshold {
+				bits[i] = 1
+			}
+		}
+		return bits
+	}
+	cur := make([]int, w+2)
+	next := make([]int, w+2)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := int(gray[y*w+x]) + cur[x+1]/16
+			out := 255
+			if v < int(threshold) {
+				bits[y*w+x] = 1
+				out = 0
+			}
+			e := v - out
+			cur[x+2] += e * 7
+			next[x] += e * 3
+			next[x+1] += e * 5
+			next[x+2] += e
+		}
+		cur, next = next, cur
+		clear(next)
+	}
+	return bits
+}
+
+// tiffPackBits 将二值像素按行打包为位图
+// 入参: bits 逐像素二值结果, w 宽度, h 高度
+// 返回: []byte 高位在前、行尾按字节补齐的位图
+func tiffPackBits(bits []byte, w, h int) []byte {
+	stride := (w + 7) / 8
+	data := make([]byte, stride*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if bits[y*w+x] != 0 {
+				data[y*stride+x/8] |= 0x80 >> (x % 8)
+			}
+		}
+	}
+	return data
+}
+
+// tiffBitWriter 高位在前的位流写入器
+type tiffBitWriter struct {
+	buf []byte
+	acc uint64
+	n   uint
+}
+
+// write 写入编码
+// 入参: code 编码值, bits 编码位数
+func (w *tiffBitWriter) write(code uint32, bits uint) {
+	w.acc = w.acc<<bits | uint64(code)
+	w.n += bits
+	for w.n >= 8 {
+		w.n -= 8
+		w.buf = append(w.buf, byte(w.acc>>w.n))
+	}
+	w.acc &= 1<<w.n - 1
+}
+
+// writeBits 写入以01字符串表示的编码
+// 入参: code 编码
+func (w *tiffBitWriter) writeBits(code string) {
+	var v uint32
+	for i := 0; i < len(code); i++ {
+		v = v<<1 | uint32(code[i]-'0')
+	}
+	w.write(v, uint(len(code)))
+}
+
+// bytes 按字节补齐并返回位流
+// 返回: []byte 位流数据
+func (w *tiffBitWriter) bytes() []byte {
+	if w.n > 0 {
+		w.buf = append(w.buf, byte(w.acc<<(8-w.n)))
+		w.acc, w.n = 0, 0
+	}
+	return w.buf
+}
+
+// tiffLZWEncode 按TIFF规范进行LZW压缩
+// 编码位宽按提前切换规则增长, 编码表满时输出清除码
+// 入参: data 原始数据
+// 返回: []byte 压缩数据
+func tiffLZWEncode(data []byte) []byte {
+	const clearCode, eoiCode, firstCode, maxCode = 256, 257, 258, 4094
+	w := &tiffBitWriter{}
+	width := uint(9)
+	w.write(clearCode, width)
+	if len(data) == 0 {
+		w.write(eoiCode, width)
+		return w.bytes()
+	}
+	table := make(map[uint32]uint32)
+	next := uint32(firstCode)
+	prefix := uint32(data[0])
+	for _, c := range data[1:] {
+		key := prefix<<8 | uint32(c)
+		if code, ok := table[key]; ok {
+			prefix = code
+			continue
+		}
+		w.write(prefix, width)
+		table[key] = next
+		next++
+		if next == maxCode {
+			w.write(clearCode, width)
+			clear(table)
+			next, width = firstCode, 9
+		} else if next == 1<<width {
+			width++
+		}
+		prefix = uint32(c)
+	}
+	w.write(prefix, width)
+	if next+1 == 1<<width {
+		width++
+	}
+	w.write(eoiCode, width)
+	return w.bytes()
+}
+
+// tiffG4Encode 按CCITT T.6规范进行G4压缩
+// 入参: bits 逐像素二值结果, w 宽度, h 高度
+// 返回: []byte 压缩数据
+func tiffG4Encode(bits []byte, w, h int) []byte {
+	bw := &tiffBitWriter{}
+	ref := make([]byte, w)
+	for y := 0; y < h; y++ {
+		cur := bits[y*w : (y+1)*w]
+		a0, color := -1, byte(0)
+		for a0 < w {
+			a1 := tiffNextChange(cur, a0+1)
+			b1 := tiffNextChange(ref, a0+1)
+			if b1 < w && ref[b1] == color {
+				b1 = tiffNextChange(ref, b1+1)
+			}
+			b2 := tiffNextChange(ref, b1+1)
+			if b2 < a1 {
+				bw.writeBits("0001")
+				a0 = b2
+				continue
+			}
+			if d := a1 - b1; d >= -3 && d <= 3 {
+				bw.writeBits(tiffVerticalCodes[d+3])
+				a0 = a1
+				color ^= 1
+				continue
+			}
+			a2 := tiffNextChange(cur, a1+1)
+			bw.writeBits("001")
+			tiffWriteRun(bw, color, a1-max(a0, 0))
+			tiffWriteRun(bw, color^1, a2-a1)
+			a0 = a2
+		}
+		ref = cur
+	}
+	bw.writeBits("000000000001000000000001")
+	return bw.bytes()
+}
+
+// tiffNextChange 查找行内的下一个变化像素
+// 行首之前的像素视为白色
+// 入参: line 行像素, start 起始位置
+// 返回: int 变化像素位置, 无变化时为行宽
+func tiffNextChange(line []byte, start int) int {
+	for p := max(start, 0); p < len(line); p++ {
+		prev := byte(0)
+		if p > 0 {
+			prev = line[p-1]
+		}
+		if line[p] != prev {
+			return p
+		}
+	}
+	return len(line)
+}
+
+// tiffWriteRun 写入水平模式的游程编码
+// 入参: w 位流写入器, color 游程颜色, 1为黑色, run 游程长度
+func tiffWriteRun(w *tiffBitWriter, color byte, run int) {
+	term, makeup := tiffWhiteTerm[:], tiffWhiteMakeup[:]
+	if color != 0 {
+		term, makeup = tiffBlackTerm[:], tiffBlackMakeup[:]
+	}
+	for run >= 2624 {
+		w.writeBits(tiffExtMakeup[len(tiffExtMakeup)-1])
+		run -= 2560
+	}
+	if run >= 64 {
+		if m := run / 64; m <= len(makeup) {
+			w.writeBits(makeup[m-1])
+		} else {
+			w.writeBits(tiffExtMakeup[m-len(makeup)-1])
+		}
+		run %= 64
+	}
+	w.writeBits(term[run])
+}
+
+// tiffVerticalCodes 垂直模式编码, 按a1-b1从-3到3排列
+var tiffVerticalCodes = [7]string{"0000010", "000010", "010", "1", "011", "000011", "0000011"}
+
+// tiffWhiteTerm 白色游程终止码
+var tiffWhiteTerm = [64]string{
+	"00110101", "000111", "0111", "1000", "1011", "1100", "1110", "1111",
+	"10011", "10100", "00111", "01000", "001000", "000011", "110100", "110101",
+	"101010", "101011", "0100111", "0001100", "0001000", "0010111", "0000011", "0000100",
+	"0101000", "0101011", "0010011", "0100100", "0011000", "00000010", "00000011", "00011010",
+	"00011011", "00010010", "00010011", "00010100", "00010101", "00010110", "00010111", "00101000",
+	"00101001", "00101010", "00101011", "00101100", "00101101", "00000100", "00000101", "00001010",
+	"00001011", "01010010", "01010011", "01010100", "01010101", "00100100", "00100101", "01011000",
+	"01011001", "01011010", "01011011", "01001010", "01001011", "00110010", "00110011", "00110100",
+}
+
+// tiffWhiteMakeup 白色游程组合码, 对应64至1728
+var tiffWhiteMakeup = [27]string{
+	"11011", "10010", "010111", "0110111", "00110110", "00110111", "01100100", "01100101",
+	"01101000", "01100111", "011001100", "011001101", "011010010", "011010011", "011010100", "011010101",
+	"011010110", "011010111", "011011000", "011011001", "011011010", "011011011", "010011000", "010011001",
+	"010011010", "011000", "010011011",
+}
+
+// tiffBlackTerm 黑色游程终止码
+var tiffBlackTerm = [64]string{
+	"0000110111", "010", "11", "10", "011", "0011", "0010", "00011",
+	"000101", "000100", "0000100", "0000101", "0000111", "00000100", "00000111", "000011000",
+	"0000010111", "0000011000", "0000001000", "00001100111", "00001101000", "00001101100", "00000110111", "00000101000",
+	"00000010111", "00000011000", "000011001010", "000011001011", "000011001100", "000011001101", "000001101000", "000001101001",
+	"000001101010", "000001101011", "000011010010", "000011010011", "000011010100", "000011010101", "000011010110", "000011010111",
+	"000001101100", "000001101101", "000011011010", "000011011011", "000001010100", "000001010101", "000001010110", "000001010111",
+	"000001100100", "000001100101", "000001010010", "000001010011", "000000100100", "000000110111", "000000111000", "000000100111",
+	"000000101000", "000001011000", "000001011001", "000000101011", "000000101100", "000001011010", "000001100110", "000001100111",
+}
+
+// tiffBlackMakeup 黑色游程组合码, 对应64至1728
+var tiffBlackMakeup = [27]string{
+	"0000001111", "000011001000", "000011001001", "000001011011", "000000110011", "000000110100", "000000110101", "0000001101100",
+	"0000001101101", "0000001001010", "0000001001011", "0000001001100", "0000001001101", "0000001110010", "0000001110011", "0000001110100",
+	"0000001110101", "0000001110110", "0000001110111", "0000001010010", "0000001010011", "0000001010100", "0000001010101", "0000001011010",
+	"0000001011011", "0000001100100", "0000001100101",
+}
+
+// tiffExtMakeup 黑白共用的扩展组合码, 对应1792至2560
+var tiffExtMakeup = [13]string{
+	"00000001000", "00000001100", "00000001101", "000000010010", "000000010011", "000000010100", "000000010101",
+	"000000010110", "000000010111", "000000011100", "000000011101", "000000011110", "000000011111",
+}
+
+// tiffWriter 多页TIFF流式写入器
+// 每页的下一IFD偏移需在下一页到达后确定, 写入器保留一页待写
+type tiffWriter struct {
+	w       io.Writer
+	opts    *tiffOptions
+	dpi     float64
+	offset  uint32
+	number  int
+	pending *tiffPage
+	err     error
+}
+
+// newTIFFWriter 创建多页TIFF流式写入器
+// 入参: w 输出流, opts TIFF导出选项, dpi 图像分辨率
+// 返回: *tiffWriter 写入器
+func newTIFFWriter(w io.Writer, opts *tiffOptions, dpi float64) *tiffWriter {
+	return &tiffWriter{w: w, opts: opts, dpi: dpi}
+}
+
+// writePage 写入页面
+// 页面在下一页到达或关闭写入器时写出
+// 入参: page 已编码的页面
+// 返回: error 错误信息
+func (t *tiffWriter) writePage(page tiffPage) error {
+	if t.err != nil {
+		return t.err
+	}
+	if t.offset == 0 {
+		t.write([]byte{'I', 'I', 42, 0, 8, 0, 0, 0})
+	}
+	if t.pending != nil {
+		t.flush(false)
+	}
+	t.pending = &page
+	return t.err
+}
+
+// close 写出最后一页
+// 返回: error 错误信息
+func (t *tiffWriter) close() error {
+	if t.err == nil && t.pending != nil {
+		t.flush(true)
+	}
+	return t.err
+}
+
+// write 写入数据并累计偏移
+// 入参: data 数据
+func (t *tiffWriter) write(data []byte) {
+	if t.err != nil {
+		return
+	}
+	if uint64(t.offset)+uint64(len(data)) > math.MaxUint32 {
+		t.err = fmt.Errorf("tiff file exceeds 4 GiB")
+		return
+	}
+	_, t.err = t.w.Write(data)
+	t.offset += uint32(len(data))
+}
+
+// flush 写出待写页面的IFD、附加数据和图像数据
+// 入参: last 是否为最后一页
+func (t *tiffWriter) flush(last bool) {
+	page := t.pending
+	t.pending = nil
+	type entry struct {
+		tag, typ uint16
+		count    uint32
+		value    []byte
+	}
+	short := func(v ...uint16) []byte {
+		b := make([]byte, 2*len(v))
+		for i, x := range v {
+			binary.LittleEndian.PutUint16(b[2*i:], x)
+		}
+		return b
+	}
+	long := func(v uint32) []byte {
+		return binary.LittleEndian.AppendUint32(nil, v)
+	}
+	dpi := uint32(math.Round(t.dpi))
+	if dpi == 0 {
+		dpi = 72
+	}
+	resolution := append(long(dpi), long(1)...)
+	compression := uint16(1)
+	switch t.opts.Compression {
+	case TIFFCompressionLZW:
+		compression = 5
+	case TIFFCompressionG4:
+		compression = 4
+	}
+	photometric, bitsPerSample := uint16(2), []uint16{8, 8, 8}
+	switch t.opts.Color {
+	case TIFFColorGray:
+		photometric, bitsPerSample = 1, []uint16{8}
+	case TIFFColorBilevel:
+		photometric, bitsPerSample = 0, []uint16{1}
+	}
+	software := append([]byte("xiaoqidun/ofdgo"), 0)
+	entries := []entry{
+		{254, 4, 1, long(2)},
+		{256, 4, 1, long(uint32(page.Width))},
+		{257, 4, 1, long(uint32(page.Height))},
+		{258, 3, uint32(len(bitsPerSample)), short(bitsPerSample...)},
+		{259, 3, 1, short(compression)},
+		{262, 3, 1, short(photometric)},
+		{273, 4, 1, nil},
+		{277, 3, 1, short(uint16(len(bitsPerSample)))},
+		{278, 4, 1, long(uint32(page.Height))},
+		{279, 4, 1, long(uint32(len(page.Data)))},
+		{282, 5, 1, resolution},
+		{283, 5, 1, resolution},
+	}
+	if compression == 4 {
+		entries = append(entries, entry{293, 4, 1, long(0)})
+	}
+	entries = append(entries,
+		entry{296, 3, 1, short(2)},
+		entry{297, 3, 2, short(uint16(t.number), 0)},
+		entry{305, 2, uint32(len(software)), software},
+	)
+	ifdSize := uint32(2 + 12*len(entries) + 4)
+	extra := []byte{}
+	extraOffset := t.offset + ifdSize
+	ifd := binary.LittleEndian.AppendUint16(nil, uint16(len(entries)))
+	for i := range entries {
+		e := &entries[i]
+		if e.tag == 273 {
+			continue
+		}
+		if len(e.value) > 4 {
+			offset := extraOffset + uint32(len(extra))
+			extra = append(extra, e.value...)
+			if len(extra)%2 == 1 {
+				extra = append(extra, 0)
+			}
+			e.value = long(offset)
+		}
+	}
+	dataOffset := extraOffset + uint32(len(extra))
+	next := uint32(0)
+	if !last {
+		next = dataOffset + uint32(len(page.Data))
+		next += next % 2
+	}
+	for _, e := range entries {
+		if e.tag == 273 {
+			e.value = long(dataOffset)
+		}
+		ifd = binary.LittleEndian.AppendUint16(ifd, e.tag)
+		ifd = binary.LittleEndian.AppendUint16(ifd, e.typ)
+		ifd = binary.LittleEndian.AppendUint32(ifd, e.count)
+		value := make([]byte, 4)
+		copy(value, e.value)
+		ifd = append(ifd, value...)
+	}
+	ifd = binary.LittleEndian.AppendUint32(ifd, next)
+	t.write(ifd)
+	t.write(extra)
+	t.write(page.Data)
+	if !last && len(page.Data)%2 == 1 {
+		t.write([]byte{0})
+	}
+	t.number++
+}
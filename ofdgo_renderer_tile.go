@@ -0,0 +1,430 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tdewolff/canvas"
+	"github.com/tdewolff/canvas/renderers/rasterizer"
+)
+
+// PageRaster 可重复光栅化的页面
+// 页面内容只解析和绘制一次, 解码后的图片在各次区域渲染之间复用
+// 同一PageRaster不可在多个协程中并发使用
+type PageRaster struct {
+	canvas *canvas.Canvas
+	box    Box
+	dpi    float64
+}
+
+// NewPageRaster 创建可重复光栅化的页面
+// 入参: page 页面内容
+// 返回: *PageRaster 页面光栅化器, error 错误信息
+func (r *Renderer) NewPageRaster(page *PageContent) (*PageRaster, error) {
+	box, err := r.GetPageBox(page)
+	if err != nil {
+		return nil, err
+	}
+	c, err := r.RenderPage(page)
+	if err != nil {
+		return nil, err
+	}
+	return &PageRaster{canvas: c, box: Box{W: box.W, H: box.H}, dpi: r.DPI}, nil
+}
+
+// Box 获取页面区域
+// 返回: Box 页面区域, 原点为页面左上角, 单位为毫米
+func (p *PageRaster) Box() Box {
+	return p.box
+}
+
+// RenderRegion 渲染页面区域
+// 入参: page 页面内容, region 页面区域, 原点为页面左上角, 单位为毫米, dst 目标图像, 区域按目标图像尺寸缩放
+// 返回: error 错误信息
+func (r *Renderer) RenderRegion(page *PageContent, region Box, dst *image.RGBA) error {
+	raster, err := r.NewPageRaster(page)
+	if err != nil {
+		return err
+	}
+	return raster.RenderRegion(region, dst)
+}
+
+// RenderRegion 渲染页面区域
+// 区域缩放到目标图像的边界, 目标图像先清为透明, 只绘制与区域相交的图形
+// 入参: region 页面区域, 原点为页面左上角, 单位为毫米, dst 目标图像
+// 返回: error 错误信息
+func (p *PageRaster) RenderRegion(region Box, dst *image.RGBA) error {
+	bounds := dst.Bounds()
+	if bounds.Empty() {
+		return fmt.Errorf("empty destination image")
+	}
+	if region.W <= 0 || region.H <= 0 {
+		return fmt.Errorf("invalid region %v", region)
+	}
+	img := dst
+	if bounds.Min != (image.Point{}) {
+		img = &image.RGBA{Pix: dst.Pix[dst.PixOffset(bounds.Min.X, bounds.Min.Y):], Stride: dst.Stride, Rect: image.Rect(0, 0, bounds.Dx(), bounds.Dy())}
+	}
+	draw.Draw(img, img.Rect, image.Transparent, image.Point{}, draw.Src)
+	sx := float64(bounds.Dx()) / region.W
+	sy := float64(bounds.Dy()) / region.H
+	ras := rasterizer.FromImage(img, canvas.DPMM(sx), canvas.DefaultColorSpace)
+	w, h := ras.Size()
+	view := canvas.Identity.Scale(1, sy/sx).Translate(-region.X, region.Y+region.H-p.box.H)
+	p.canvas.RenderViewTo(&regionRenderer{Renderer: ras, bounds: canvas.Rect{X1: w, Y1: h}}, view)
+	ras.Close()
+	return nil
+}
+
+// regionRenderer 区域渲染器
+// 跳过与目标区域不相交的路径、文本和图片
+type regionRenderer struct {
+	canvas.Renderer
+	bounds canvas.Rect
+}
+
+// RenderPath 渲染路径
+// 入参: path 路径, style 样式, m 变换矩阵
+func (r *regionRenderer) RenderPath(path *canvas.Path, style canvas.Style, m canvas.Matrix) {
+	b := path.FastBounds().Transform(m)
+	if style.HasStroke() {
+		b = b.Expand(style.StrokeWidth * math.Sqrt(math.Abs(m.Det())) * 4)
+	}
+	if b.Touches(r.bounds) {
+		r.Renderer.RenderPath(path, style, m)
+	}
+}
+
+// RenderText 渲染文本
+// 入参: text 文本, m 变换矩阵
+func (r *regionRenderer) RenderText(text *canvas.Text, m canvas.Matrix) {
+	if text.WritingMode == canvas.HorizontalTB {
+		b := text.Bounds()
+		if !b.Expand(b.H()).Transform(m).Touches(r.bounds) {
+			return
+		}
+	}
+	r.Renderer.RenderText(text, m)
+}
+
+// RenderImage 渲染图片
+// 入参: img 图片, m 变换矩阵
+func (r *regionRenderer) RenderImage(img image.Image, m canvas.Matrix) {
+	size := img.Bounds().Size()
+	b := canvas.Rect{X1: float64(size.X), Y1: float64(size.Y)}.Transform(m)
+	if b.Touches(r.bounds) {
+		r.Renderer.RenderImage(img, m)
+	}
+}
+
+// TileLayout 瓦片金字塔布局
+type TileLayout int
+
+const (
+	TileLayoutDeepZoom TileLayout = iota // DeepZoom布局, 输出name.dzi和name_files/级别/列_行.扩展名
+	TileLayoutIIIF                       // IIIF Image API 3.0 level 0静态布局, 输出name/info.json和区域/尺寸/0/default.扩展名
+)
+
+// tileOptions 瓦片导出选项
+type tileOptions struct {
+	DPI     float64
+	Size    int
+	Overlap int
+	Layout  TileLayout
+	Format  string
+	BaseURL string
+}
+
+// TileOption 瓦片导出配置选项
+type TileOption func(*tileOptions)
+
+// WithTileDPI 设置金字塔最高级别的分辨率
+// 入参: dpi 分辨率, 默认使用渲染器DPI
+// 返回: TileOption 瓦片选项
+func WithTileDPI(dpi float64) TileOption {
+	return func(o *tileOptions) {
+		o.DPI = dpi
+	}
+}
+
+// WithTileSize 设置瓦片尺寸
+// 入参: size 瓦片边长像素, 默认256
+// 返回: TileOption 瓦片选项
+func WithTileSize(size int) TileOption {
+	return func(o *tileOptions) {
+		o.Size = size
+	}
+}
+
+// WithTileOverlap 设置DeepZoom瓦片重叠像素
+// IIIF布局不使用重叠
+// 入参: overlap 重叠像素, 默认0
+// 返回: TileOption 瓦片选项
+func WithTileOverlap(overlap int) TileOption {
+	return func(o *tileOptions) {
+		o.Overlap = overlap
+	}
+}
+
+// WithTileLayout 设置瓦片金字塔布局
+// 入参: layout 布局, 默认DeepZoom
+// 返回: TileOption 瓦片选项
+func WithTileLayout(layout TileLayout) TileOption {
+	return func(o *tileOptions) {
+		o.Layout = layout
+	}
+}
+
+// WithTileFormat 设置瓦片图片格式
+// 入参: format 图片格式, png或jpg, 默认png
+// 返回: TileOption 瓦片选项
+func WithTileFormat(format string) TileOption {
+	return func(o *tileOptions) {
+		o.Format = format
+	}
+}
+
+// WithTileBaseURL 设置IIIF图像服务的基础地址
+// info.json的id为基础地址加图像名称, 未设置时为图像名称
+// 入参: baseURL 基础地址
+// 返回: TileOption 瓦片选项
+func WithTileBaseURL(baseURL string) TileOption {
+	return func(o *tileOptions) {
+		o.BaseURL = baseURL
+	}
+}
+
+// TilePyramid 瓦片金字塔结构
+type TilePyramid struct {
+	Width    int        // 最高级别宽度像素
+	Height   int        // 最高级别高度像素
+	TileSize int        // 瓦片边长像素
+	Overlap  int        // 瓦片重叠像素
+	Levels   int        // 级别数量
+	Layout   TileLayout // 布局
+	Format   string     // 图片格式
+}
+
+// Tile 瓦片信息
+type Tile struct {
+	Level  int    // 级别, DeepZoom从0开始递增, IIIF按缩放倍数从大到小递增
+	Scale  int    // 相对最高级别的缩小倍数
+	Col    int    // 列
+	Row    int    // 行
+	Region Box    // 页面区域, 原点为页面左上角, 单位为毫米
+	Path   string // 布局中的相对路径
+}
+
+// TileFunc 瓦片回调
+// 入参: tile 瓦片信息, img 瓦片图像, 仅在回调期间有效
+// 返回: error 错误信息, 非空时停止生成
+type TileFunc func(tile Tile, img *image.RGBA) error
+
+// newTileOptions 创建瓦片导出选项
+// 入参: dpi 默认分辨率, opts 瓦片选项
+// 返回: *tileOptions 瓦片导出选项, error 错误信息
+func newTileOptions(dpi float64, opts []TileOption) (*tileOptions, error) {
+	o := &tileOptions{DPI: dpi, Size: 256, Format: "png"}
+	for _, opt := range opts {
+		opt(o)
+	}
+	o.Format = strings.ToLower(o.Format)
+	if o.Format == "jpeg" {
+		o.Format = "jpg"
+	}
+	if o.Format != "png" && o.Format != "jpg" {
+		return nil, fmt.Errorf("unsupported tile format %s", o.Format)
+	}
+	if o.Size <= 0 || o.Overlap < 0 || o.DPI <= 0 {
+		return nil, fmt.Errorf("invalid tile options")
+	}
+	if o.Layout == TileLayoutIIIF {
+		o.Overlap = 0
+	}
+	return o, nil
+}
+
+// RenderTiles 生成页面的瓦片金字塔
+// 逐级逐行渲染瓦片, 所有瓦片复用同一页面内容和同一瓦片缓冲区
+// 入参: fn 瓦片回调, opts 瓦片选项
+// 返回: *TilePyramid 瓦片金字塔结构, error 错误信息
+func (p *PageRaster) RenderTiles(fn TileFunc, opts ...TileOption) (*TilePyramid, error) {
+	o, err := newTileOptions(p.dpi, opts)
+	if err != nil {
+		return nil, err
+	}
+	return p.renderTiles(o, fn)
+}
+
+// renderTiles 生成页面的瓦片金字塔
+// 入参: o 瓦片导出选项, fn 瓦片回调
+// 返回: *TilePyramid 瓦片金字塔结构, error 错误信息
+func (p *PageRaster) renderTiles(o *tileOptions, fn TileFunc) (*TilePyramid, error) {
+	width := max(int(p.box.W*o.DPI/25.4+0.5), 1)
+	height := max(int(p.box.H*o.DPI/25.4+0.5), 1)
+	pyramid := &TilePyramid{Width: width, Height: height, TileSize: o.Size, Overlap: o.Overlap, Layout: o.Layout, Format: o.Format}
+	var scales []int
+	if o.Layout == TileLayoutIIIF {
+		for scale := 1; ; scale *= 2 {
+			scales = append(scales, scale)
+			if ceilDiv(width, scale) <= o.Size && ceilDiv(height, scale) <= o.Size {
+				break
+			}
+		}
+	} else {
+		top := int(math.Ceil(math.Log2(float64(max(width, height)))))
+		for level := 0; level <= top; level++ {
+			scales = append(scales, 1<<(top-level))
+		}
+	}
+	pyramid.Levels = len(scales)
+	buf := image.NewRGBA(image.Rect(0, 0, o.Size+2*o.Overlap, o.Size+2*o.Overlap))
+	for level, scale := range scales {
+		lw, lh := ceilDiv(width, scale), ceilDiv(height, scale)
+		sx, sy := float64(lw)/p.box.W, float64(lh)/p.box.H
+		for row := 0; row*o.Size < lh; row++ {
+			for col := 0; col*o.Size < lw; col++ {
+				x0, y0 := max(col*o.Size-o.Overlap, 0), max(row*o.Size-o.Overlap, 0)
+				x1, y1 := min((col+1)*o.Size+o.Overlap, lw), min((row+1)*o.Size+o.Overlap, lh)
+				tile := Tile{Level: level, Scale: scale, Col: col, Row: row}
+				tile.Region = Box{X: float64(x0) / sx, Y: float64(y0) / sy, W: float64(x1-x0) / sx, H: float64(y1-y0) / sy}
+				if o.Layout == TileLayoutIIIF {
+					region := "full"
+					rx, ry := x0*scale, y0*scale
+					rw, rh := min(x1*scale, width)-rx, min(y1*scale, height)-ry
+					if rx != 0 || ry != 0 || rw != width || rh != height {
+						region = fmt.Sprintf("%d,%d,%d,%d", rx, ry, rw, rh)
+					}
+					tile.Path = fmt.Sprintf("%s/%d,%d/0/default.%s", region, x1-x0, y1-y0, o.Format)
+				} else {
+					tile.Path = fmt.Sprintf("%d/%d_%d.%s", level, col, row, o.Format)
+				}
+				img := buf.SubImage(image.Rect(0, 0, x1-x0, y1-y0)).(*image.RGBA)
+				if err := p.RenderRegion(tile.Region, img); err != nil {
+					return pyramid, err
+				}
+				if err := fn(tile, img); err != nil {
+					return pyramid, err
+				}
+			}
+		}
+	}
+	return pyramid, nil
+}
+
+// ExportTiles 导出页面的瓦片金字塔到目录
+// 入参: page 页面内容, dir 输出目录, name 图像名称, opts 瓦片选项
+// 返回: *TilePyramid 瓦片金字塔结构, error 错误信息
+func (r *Renderer) ExportTiles(page *PageContent, dir, name string, opts ...TileOption) (*TilePyramid, error) {
+	o, err := newTileOptions(r.DPI, opts)
+	if err != nil {
+		return nil, err
+	}
+	raster, err := r.NewPageRaster(page)
+	if err != nil {
+		return nil, err
+	}
+	root := filepath.Join(dir, name+"_files")
+	if o.Layout == TileLayoutIIIF {
+		root = filepath.Join(dir, name)
+	}
+	var data bytes.Buffer
+	pyramid, err := raster.renderTiles(o, func(tile Tile, img *image.RGBA) error {
+		data.Reset()
+		if o.Format == "jpg" {
+			bg := image.NewRGBA(img.Rect)
+			draw.Draw(bg, bg.Rect, image.White, image.Point{}, draw.Src)
+			draw.Draw(bg, bg.Rect, img, img.Rect.Min, draw.Over)
+			if err := jpeg.Encode(&data, bg, &jpeg.Options{Quality: 90}); err != nil {
+				return err
+			}
+		} else if err := png.Encode(&data, img); err != nil {
+			return err
+		}
+		target := filepath.Join(root, filepath.FromSlash(tile.Path))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		return os.WriteFile(target, data.Bytes(), 0o644)
+	})
+	if err != nil {
+		return pyramid, err
+	}
+	if o.Layout == TileLayoutIIIF {
+		return pyramid, os.WriteFile(filepath.Join(root, "info.json"), iiifInfo(pyramid, name, o.BaseURL), 0o644)
+	}
+	dzi := fmt.Sprintf("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<Image xmlns=\"http://schemas.microsoft.com/deepzoom/2008\" Format=\"%s\" Overlap=\"%d\" TileSize=\"%d\">\n<Size Width=\"%d\" Height=\"%d\"/>\n</Image>\n", pyramid.Format, pyramid.Overlap, pyramid.TileSize, pyramid.Width, pyramid.Height)
+	return pyramid, os.WriteFile(filepath.Join(dir, name+".dzi"), []byte(dzi), 0o644)
+}
+
+// iiifInfo 生成IIIF Image API 3.0的info.json
+// 入参: pyramid 瓦片金字塔结构, name 图像名称, baseURL 基础地址
+// 返回: []byte info.json内容
+func iiifInfo(pyramid *TilePyramid, name, baseURL string) []byte {
+	id := name
+	if baseURL != "" {
+		id = strings.TrimRight(baseURL, "/") + "/" + name
+	}
+	type size struct {
+		Width  int `json:"width"`
+		Height int `json:"height"`
+	}
+	type tiles struct {
+		Width        int   `json:"width"`
+		ScaleFactors []int `json:"scaleFactors"`
+	}
+	var factors []int
+	top := 1 << (pyramid.Levels - 1)
+	// 仅最高缩放级别由单个瓦片覆盖整图, 只有该尺寸写出了full图像
+	sizes := []size{{Width: ceilDiv(pyramid.Width, top), Height: ceilDiv(pyramid.Height, top)}}
+	for i := 0; i < pyramid.Levels; i++ {
+		factors = append(factors, 1<<i)
+	}
+	format := "png"
+	if pyramid.Format == "jpg" {
+		format = "jpg"
+	}
+	data, _ := json.MarshalIndent(map[string]any{
+		"@context":         "http://iiif.io/api/image/3/context.json",
+		"id":               id,
+		"type":             "ImageService3",
+		"protocol":         "http://iiif.io/api/image",
+		"profile":          "level0",
+		"width":            pyramid.Width,
+		"height":           pyramid.Height,
+		"sizes":            sizes,
+		"tiles":            []tiles{{Width: pyramid.TileSize, ScaleFactors: factors}},
+		"preferredFormats": []string{format},
+	}, "", "  ")
+	return data
+}
+
+// ceilDiv 向上取整除法
+// 入参: a 被除数, b 除数
+// 返回: int 商
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
@@ -180,11 +180,13 @@ func (r *Reader) parseSignatures(doc *Document) error {
 			sigPath := resolveResourcePath(sigListPath, "", sigRef.BaseLoc)
 			sf, err := r.openFile(sigPath)
 			if err != nil {
+				r.diagnose(DiagnosticWarning, sigPath, sigRef.ID, "failed to open signature: %v", err)
 				return
 			}
 			defer sf.Close()
 			var sigFile SignatureFile
 			if err := xml.NewDecoder(sf).Decode(&sigFile); err != nil {
+				r.diagnose(DiagnosticWarning, sigPath, sigRef.ID, "failed to unmarshal signature: %v", err)
 				return
 			}
 			var sealType string
@@ -206,18 +208,23 @@ func (r *Reader) parseSignatures(doc *Document) error {
 				}
 			}
 			if len(sealData) == 0 {
+				if len(sigFile.SignedInfo.StampAnnot) > 0 {
+					r.diagnose(DiagnosticWarning, sigPath, sigRef.ID, "no seal image found for signature appearance")
+				}
 				return
 			}
 			for _, annot := range sigFile.SignedInfo.StampAnnot {
 				pageID := annot.PageRef
 				bbox, err := parseSignatureStampBox(annot.Boundary)
 				if err != nil {
+					r.diagnose(DiagnosticWarning, sigPath, annot.ID, "invalid stamp boundary: %v", err)
 					continue
 				}
 				var clipBox *Box
 				if annot.Clip != "" {
 					clip, err := parseSignatureStampBox(annot.Clip)
 					if err != nil {
+						r.diagnose(DiagnosticWarning, sigPath, annot.ID, "invalid stamp clip: %v", err)
 						continue
 					}
 					clipBox = &clip
@@ -0,0 +1,114 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"sort"
+	"strings"
+)
+
+// SignatureCoverage 签名保护范围分析
+// Unprotected不包含签名列表及各签名自身的签名文件、签名值和印章文件
+// ModifiedAfterSign表示本签名之后文档存在未受本签名保护的内容或受保护内容已改变
+// UnsignedChanges表示上述改动中存在未被任何后续签名保护的内容
+type SignatureCoverage struct {
+	Protected         []string `json:"protected,omitempty"`
+	Unprotected       []string `json:"unprotected,omitempty"`
+	Changed           []string `json:"changed,omitempty"`
+	SignatureFiles    []string `json:"signatureFiles,omitempty"`
+	LaterSignatures   []string `json:"laterSignatures,omitempty"`
+	ModifiedAfterSign bool     `json:"modifiedAfterSign"`
+	UnsignedChanges   bool     `json:"unsignedChanges"`
+}
+
+// SignaturesModifiedAfter 获取文档最早被修改的签名
+// 入参: reports 按签名顺序排列的签名验证报告
+// 返回: string 签名ID, bool 文档是否在该签名之后被修改
+func SignaturesModifiedAfter(reports []SignatureVerifyReport) (string, bool) {
+	for _, report := range reports {
+		if report.Coverage.ModifiedAfterSign {
+			return report.ID, true
+		}
+	}
+	return "", false
+}
+
+// applySignatureCoverage 分析签名保护范围
+// 未读取到任何引用文件的签名无法判断保护范围, 不标记为已检查, 路径比较不区分大小写
+// 入参: reports 按签名顺序排列的签名验证报告, entries 包内文件列表, sigListPath 签名列表路径
+func applySignatureCoverage(reports []SignatureVerifyReport, entries []string, sigListPath string) {
+	infra := map[string]bool{coveragePathKey(sigListPath): true}
+	for _, report := range reports {
+		for _, name := range report.Coverage.SignatureFiles {
+			infra[coveragePathKey(name)] = true
+		}
+	}
+	intact := make([]map[string]bool, len(reports))
+	for i, report := range reports {
+		intact[i] = make(map[string]bool, len(report.References))
+		for _, ref := range report.References {
+			if ref.OK {
+				intact[i][coveragePathKey(ref.Path)] = true
+			}
+		}
+	}
+	for i := range reports {
+		coverage := &reports[i].Coverage
+		reports[i].CoverageChecked = len(reports[i].References) != 0
+		protected := make(map[string]bool, len(reports[i].References))
+		changed := make(map[string]bool)
+		for _, ref := range reports[i].References {
+			key := coveragePathKey(ref.Path)
+			if !protected[key] {
+				protected[key] = true
+				coverage.Protected = append(coverage.Protected, ref.Path)
+			}
+			if !ref.OK && !changed[key] {
+				changed[key] = true
+				coverage.Changed = append(coverage.Changed, ref.Path)
+			}
+		}
+		for _, name := range entries {
+			if key := coveragePathKey(name); !protected[key] && !infra[key] {
+				coverage.Unprotected = append(coverage.Unprotected, name)
+			}
+		}
+		sort.Strings(coverage.Protected)
+		changes := append(append([]string{}, coverage.Changed...), coverage.Unprotected...)
+		coverage.ModifiedAfterSign = len(changes) != 0
+		for _, name := range changes {
+			key := coveragePathKey(name)
+			signed := false
+			for j := i + 1; j < len(reports); j++ {
+				if intact[j][key] {
+					signed = true
+					if !containsString(coverage.LaterSignatures, reports[j].ID) {
+						coverage.LaterSignatures = append(coverage.LaterSignatures, reports[j].ID)
+					}
+				}
+			}
+			if !signed {
+				coverage.UnsignedChanges = true
+			}
+		}
+	}
+}
+
+// coveragePathKey 获取保护范围比较使用的包内路径
+// 入参: name 包内文件路径
+// 返回: string 清理并转为小写的路径
+func coveragePathKey(name string) string {
+	return strings.ToLower(cleanPackagePath(name))
+}
@@ -0,0 +1,262 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"math/big"
+)
+
+// SignatureSigner 签名密钥提供者
+// 私钥可位于UKey或密码机中, 签名时仅通过crypto.Signer接口调用
+// SM2密钥的Sign入参digest为SM3(ZA||M), opts为*SM2SignerOpts
+// RSA与ECDSA密钥的Sign入参digest为SHA-256摘要, opts为crypto.SHA256
+type SignatureSigner interface {
+	crypto.Signer
+	// Certificates 获取签名证书, 第一个为签名证书, 其后为可选的中间证书
+	Certificates() ([][]byte, error)
+}
+
+// SignatureHasher SM3摘要计算提供者
+// SignatureSigner同时实现本接口时, 签名过程中的SM3摘要交由设备计算
+type SignatureHasher interface {
+	// SumSM3 计算SM3摘要
+	SumSM3(data []byte) ([]byte, error)
+}
+
+// SM2SignerOpts SM2签名选项
+// 设备需要自行计算ZA及摘要时可使用UserID与Message
+type SM2SignerOpts struct {
+	UserID  []byte
+	Message []byte
+}
+
+// HashFunc 获取摘要算法
+// 返回: crypto.Hash 摘要算法, SM3不在标准库定义中因此为0
+func (opts *SM2SignerOpts) HashFunc() crypto.Hash {
+	return 0
+}
+
+// SM2PublicKey SM2公钥
+type SM2PublicKey struct {
+	X *big.Int
+	Y *big.Int
+}
+
+// SM2PrivateKey SM2私钥
+// 签名、解密和公钥推导中私钥与随机数参与的标量乘法及模运算均按常量时间实现
+type SM2PrivateKey struct {
+	SM2PublicKey
+	D *big.Int
+}
+
+// GenerateSM2Key 生成SM2私钥
+// 入参: random 随机数源
+// 返回: *SM2PrivateKey SM2私钥, error 错误信息
+func GenerateSM2Key(random io.Reader) (*SM2PrivateKey, error) {
+	d, err := sm2RandScalar(random)
+	if err != nil {
+		return nil, err
+	}
+	return newSM2PrivateKey(d)
+}
+
+// ParseSM2PrivateKey 解析SM2私钥
+// 入参: data PKCS#8或SEC1格式的DER或PEM编码私钥
+// 返回: *SM2PrivateKey SM2私钥, error 错误信息
+func ParseSM2PrivateKey(data []byte) (*SM2PrivateKey, error) {
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	}
+	var pkcs8 struct {
+		Version    int
+		Algorithm  asn1.RawValue
+		PrivateKey []byte
+	}
+	if rest, err := asn1.Unmarshal(data, &pkcs8); err == nil && len(rest) == 0 {
+		items, ok := asn1Children(pkcs8.Algorithm.Bytes)
+		if !ok || len(items) < 2 {
+			return nil, fmt.Errorf("invalid private key algorithm")
+		}
+		alg, err := asn1OIDString(items[0])
+		if err != nil {
+			return nil, err
+		}
+		curve, err := asn1OIDString(items[1])
+		if err != nil {
+			return nil, err
+		}
+		if alg != signECPublicKey || curve != signCurveSM2P256 {
+			return nil, fmt.Errorf("unsupported private key algorithm")
+		}
+		data = pkcs8.PrivateKey
+	}
+	var sec1 struct {
+		Version    int
+		PrivateKey []byte
+		Rest       []asn1.RawValue `asn1:"optional"`
+	}
+	rest, err := asn1.Unmarshal(data, &sec1)
+	if err != nil || len(rest) != 0 || sec1.Version != 1 {
+		return nil, fmt.Errorf("invalid sm2 private key")
+	}
+	return newSM2PrivateKey(new(big.Int).SetBytes(sec1.PrivateKey))
+}
+
+// newSM2PrivateKey 根据私钥标量创建SM2私钥
+// 入参: d 私钥标量
+// 返回: *SM2PrivateKey SM2私钥, error 错误信息
+func newSM2PrivateKey(d *big.Int) (*SM2PrivateKey, error) {
+	limit := new(big.Int).Sub(sm2P256.N, big.NewInt(1))
+	if d.Sign() <= 0 || d.Cmp(limit) >= 0 {
+		return nil, fmt.Errorf("invalid sm2 private key")
+	}
+	x, y, ok := sm2P256.affine(sm2P256.scalarMult(sm2P256.Gx, sm2P256.Gy, sm2Fixed(d)))
+	if !ok {
+		return nil, fmt.Errorf("invalid sm2 private key")
+	}
+	return &SM2PrivateKey{SM2PublicKey: SM2PublicKey{X: x, Y: y}, D: new(big.Int).Set(d)}, nil
+}
+
+// Public 获取公钥
+// 返回: crypto.PublicKey SM2公钥
+func (key *SM2PrivateKey) Public() crypto.PublicKey {
+	return &SM2PublicKey{X: key.X, Y: key.Y}
+}
+
+// Sign 计算SM2签名
+// 入参: random 随机数源, digest SM3(ZA||M)摘要, opts 签名选项
+// 返回: []byte DER编码签名值, error 错误信息
+func (key *SM2PrivateKey) Sign(random io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if len(digest) != sm3Size {
+		return nil, fmt.Errorf("invalid sm2 digest length: %d", len(digest))
+	}
+	fn := sm2P256.fn
+	e := fn.fromBytes(digest)
+	d := fn.fromBig(key.D)
+	inv := fn.invert(fn.add(d, fn.one))
+	for {
+		k, err := sm2RandScalar(random)
+		if err != nil {
+			return nil, err
+		}
+		scalar := sm2Fixed(k)
+		x, _, ok := sm2P256.affine(sm2P256.scalarMult(sm2P256.Gx, sm2P256.Gy, scalar))
+		if !ok {
+			continue
+		}
+		kk := fn.fromBytes(scalar)
+		rr := fn.add(e, fn.fromBig(x))
+		if sm2IsZero(rr)|sm2IsZero(fn.add(rr, kk)) == 1 {
+			continue
+		}
+		ss := fn.mul(inv, fn.sub(kk, fn.mul(rr, d)))
+		if sm2IsZero(ss) == 1 {
+			continue
+		}
+		r, s := fn.toBig(rr), fn.toBig(ss)
+		return asn1.Marshal(struct {
+			R *big.Int
+			S *big.Int
+		}{r, s})
+	}
+}
+
+// sm2RandScalar 生成SM2随机标量
+// 入参: random 随机数源
+// 返回: *big.Int 取值范围为[1, n-2]的随机标量, error 错误信息
+func sm2RandScalar(random io.Reader) (*big.Int, error) {
+	limit := new(big.Int).Sub(sm2P256.N, big.NewInt(2))
+	buf := make([]byte, 40)
+	if _, err := io.ReadFull(random, buf); err != nil {
+		return nil, err
+	}
+	k := new(big.Int).SetBytes(buf)
+	k.Mod(k, limit)
+	return k.Add(k, big.NewInt(1)), nil
+}
+
+// SoftwareSigner 软件签名密钥提供者
+// 用于本地测试或无硬件设备的场景, 生产环境可替换为UKey或密码机实现
+type SoftwareSigner struct {
+	crypto.Signer
+	certs [][]byte
+}
+
+// NewSoftwareSigner 创建软件签名密钥提供者
+// 入参: key 私钥, 支持*SM2PrivateKey、*rsa.PrivateKey和*ecdsa.PrivateKey, certs DER或PEM编码证书, 第一个为签名证书
+// 返回: *SoftwareSigner 软件签名密钥提供者, error 错误信息
+func NewSoftwareSigner(key crypto.Signer, certs ...[]byte) (*SoftwareSigner, error) {
+	switch key.(type) {
+	case *SM2PrivateKey, *rsa.PrivateKey, *ecdsa.PrivateKey:
+	default:
+		return nil, fmt.Errorf("unsupported private key type: %T", key)
+	}
+	list := appendSignatureCerts(nil, certs...)
+	if len(list) == 0 {
+		return nil, fmt.Errorf("signature certificate not found")
+	}
+	if err := signerKeyMatchesCert(key, list[0]); err != nil {
+		return nil, err
+	}
+	return &SoftwareSigner{Signer: key, certs: list}, nil
+}
+
+// Certificates 获取签名证书
+// 返回: [][]byte DER编码证书列表, error 错误信息
+func (s *SoftwareSigner) Certificates() ([][]byte, error) {
+	return s.certs, nil
+}
+
+// SumSM3 计算SM3摘要
+// 入参: data 原文数据
+// 返回: []byte 摘要值, error 错误信息
+func (s *SoftwareSigner) SumSM3(data []byte) ([]byte, error) {
+	return signSM3(data), nil
+}
+
+// signerKeyMatchesCert 判断私钥与证书公钥是否匹配
+// 入参: key 私钥, cert DER编码证书
+// 返回: error 错误信息
+func signerKeyMatchesCert(key crypto.Signer, cert []byte) error {
+	if pub, ok := key.Public().(*SM2PublicKey); ok {
+		certPub, err := parseSM2PublicKeyFromCert(cert)
+		if err != nil {
+			return err
+		}
+		if certPub.X.Cmp(pub.X) != 0 || certPub.Y.Cmp(pub.Y) != 0 {
+			return fmt.Errorf("private key does not match signature certificate")
+		}
+		return nil
+	}
+	x509Cert, err := x509.ParseCertificate(cert)
+	if err != nil {
+		return err
+	}
+	type equaler interface {
+		Equal(crypto.PublicKey) bool
+	}
+	if pub, ok := key.Public().(equaler); !ok || !pub.Equal(x509Cert.PublicKey) {
+		return fmt.Errorf("private key does not match signature certificate")
+	}
+	return nil
+}
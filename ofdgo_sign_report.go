@@ -0,0 +1,483 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SignatureAuditSchema 签名验证审计报告结构版本
+const SignatureAuditSchema = "ofdgo.signature-audit/v1"
+
+// SignatureReportLang 签名验证报告语言
+type SignatureReportLang string
+
+const (
+	// SignatureReportZH 中文报告
+	SignatureReportZH SignatureReportLang = "zh"
+	// SignatureReportEN 英文报告
+	SignatureReportEN SignatureReportLang = "en"
+)
+
+// SignatureAuditReport 签名验证审计报告
+type SignatureAuditReport struct {
+	Schema        string                `json:"schema"`
+	Generated     time.Time             `json:"generated"`
+	Valid         bool                  `json:"valid"`
+	ModifiedAfter string                `json:"modifiedAfter,omitempty"`
+	Signatures    []SignatureAuditEntry `json:"signatures"`
+}
+
+// SignatureAuditEntry 单个签名审计信息
+type SignatureAuditEntry struct {
+	ID                string                      `json:"id"`
+	BaseLoc           string                      `json:"baseLoc,omitempty"`
+	Type              string                      `json:"type"`
+	Valid             bool                        `json:"valid"`
+	IntegrityValid    bool                        `json:"integrityValid"`
+	TrustedValid      bool                        `json:"trustedValid"`
+	Signer            string                      `json:"signer,omitempty"`
+	Provider          string                      `json:"provider,omitempty"`
+	Company           string                      `json:"company,omitempty"`
+	ProviderVersion   string                      `json:"providerVersion,omitempty"`
+	SignatureMethod   string                      `json:"signatureMethod,omitempty"`
+	DigestMethod      string                      `json:"digestMethod,omitempty"`
+	SignatureDateTime string                      `json:"signatureDateTime,omitempty"`
+	SignatureTime     time.Time                   `json:"signatureTime,omitzero"`
+	SignCertChain     []SignatureAuditCert        `json:"signCertChain,omitempty"`
+	SealCertChain     []SignatureAuditCert        `json:"sealCertChain,omitempty"`
+	Seal              *SignatureAuditSeal         `json:"seal,omitempty"`
+	Checks            []SignatureAuditCheck       `json:"checks"`
+	References        []SignatureAuditReference   `json:"references,omitempty"`
+	Stamps            []SignatureAuditStamp       `json:"stamps,omitempty"`
+	Revocations       []SignatureRevocationStatus `json:"revocations,omitempty"`
+	Coverage          SignatureCoverage           `json:"coverage"`
+	Error             string                      `json:"error,omitempty"`
+}
+
+// SignatureAuditCert 证书审计信息
+type SignatureAuditCert struct {
+	Subject      string    `json:"subject,omitempty"`
+	CommonName   string    `json:"commonName,omitempty"`
+	Organization string    `json:"organization,omitempty"`
+	Issuer       string    `json:"issuer,omitempty"`
+	SerialNumber string    `json:"serialNumber,omitempty"`
+	NotBefore    time.Time `json:"notBefore,omitzero"`
+	NotAfter     time.Time `json:"notAfter,omitzero"`
+	SHA256       string    `json:"sha256,omitempty"`
+	DER          []byte    `json:"der,omitempty"`
+}
+
+// SignatureAuditSeal 电子印章审计信息
+type SignatureAuditSeal struct {
+	ID          string    `json:"id,omitempty"`
+	Name        string    `json:"name,omitempty"`
+	VendorID    string    `json:"vendorId,omitempty"`
+	Type        int       `json:"type,omitempty"`
+	Version     int       `json:"version,omitempty"`
+	CreateTime  time.Time `json:"createTime,omitzero"`
+	ValidStart  time.Time `json:"validStart,omitzero"`
+	ValidEnd    time.Time `json:"validEnd,omitzero"`
+	PictureType string    `json:"pictureType,omitempty"`
+	Picture     []byte    `json:"picture,omitempty"`
+}
+
+// SignatureAuditCheck 签名检查项结果
+type SignatureAuditCheck struct {
+	Name    string `json:"name"`
+	Checked bool   `json:"checked"`
+	OK      bool   `json:"ok"`
+}
+
+// SignatureAuditReference 保护文件审计信息
+type SignatureAuditReference struct {
+	FileRef    string `json:"fileRef"`
+	Path       string `json:"path"`
+	OK         bool   `json:"ok"`
+	CheckValue []byte `json:"checkValue,omitempty"`
+	Actual     []byte `json:"actual,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// SignatureAuditStamp 签名外观审计信息
+type SignatureAuditStamp struct {
+	ID       string  `json:"id,omitempty"`
+	Page     int     `json:"page"`
+	PageID   string  `json:"pageId,omitempty"`
+	Boundary string  `json:"boundary,omitempty"`
+	X        float64 `json:"x"`
+	Y        float64 `json:"y"`
+	Width    float64 `json:"width"`
+	Height   float64 `json:"height"`
+}
+
+// signatureCheckLabels 签名检查项名称
+var signatureCheckLabels = map[string][2]string{
+	"digest":        {"保护文件摘要", "Protected file digests"},
+	"dataHash":      {"签名原文摘要", "Signed data hash"},
+	"signedValue":   {"签名值", "Signature value"},
+	"seal":          {"电子印章签名", "Seal signature"},
+	"sealMatch":     {"印章文件一致", "Seal file match"},
+	"cert":          {"签名证书", "Signer certificate"},
+	"signatureTime": {"签名时间在证书有效期内", "Signing time within certificate validity"},
+	"sealTime":      {"签名时间在印章有效期内", "Signing time within seal validity"},
+	"sealCertTime":  {"签名时间在制章证书有效期内", "Signing time within seal certificate validity"},
+	"certTime":      {"证书在验证时间有效", "Certificates valid at verification time"},
+	"certTrust":     {"证书链可信", "Certificate chain trusted"},
+	"revocation":    {"证书未吊销", "Certificates not revoked"},
+	"coverage":      {"签名后文档未修改", "Document unchanged after signing"},
+}
+
+// signatureReportTexts 签名报告固定文本
+var signatureReportTexts = map[string][2]string{
+	"title":       {"OFD签名验证报告", "OFD Signature Verification Report"},
+	"generated":   {"生成时间", "Generated"},
+	"count":       {"签名数量", "Signatures"},
+	"verdict":     {"总体结论", "Overall result"},
+	"valid":       {"有效", "Valid"},
+	"invalid":     {"无效", "Invalid"},
+	"none":        {"文件未发现签名", "No signatures found"},
+	"modified":    {"文档在签名%s之后被修改", "Document modified after signature %s"},
+	"signature":   {"签名", "Signature"},
+	"result":      {"结论", "Result"},
+	"signer":      {"签名人", "Signer"},
+	"time":        {"签名时间", "Signing time"},
+	"provider":    {"签名组件", "Provider"},
+	"method":      {"签名算法", "Signature method"},
+	"sealName":    {"印章", "Seal"},
+	"signCert":    {"签名证书链", "Signer certificate chain"},
+	"sealCert":    {"制章证书链", "Seal certificate chain"},
+	"checks":      {"检查项", "Checks"},
+	"pass":        {"通过", "PASS"},
+	"fail":        {"失败", "FAIL"},
+	"skip":        {"未检查", "N/A"},
+	"stamps":      {"外观位置", "Stamp positions"},
+	"page":        {"第%d页", "page %d"},
+	"references":  {"保护文件", "Protected files"},
+	"unprotected": {"未保护文件", "Unprotected files"},
+	"later":       {"后续签名", "Later signatures"},
+	"error":       {"错误", "Error"},
+	"issuer":      {"颁发者", "Issuer"},
+	"serial":      {"序列号", "Serial"},
+	"validity":    {"有效期", "Validity"},
+}
+
+// NewSignatureAuditReport 创建签名验证审计报告
+// 入参: reports 签名验证报告
+// 返回: SignatureAuditReport 签名验证审计报告
+func NewSignatureAuditReport(reports []SignatureVerifyReport) SignatureAuditReport {
+	audit := SignatureAuditReport{
+		Schema:     SignatureAuditSchema,
+		Generated:  time.Now(),
+		Valid:      len(reports) != 0,
+		Signatures: make([]SignatureAuditEntry, 0, len(reports)),
+	}
+	for _, report := range reports {
+		if !report.Valid {
+			audit.Valid = false
+		}
+		audit.Signatures = append(audit.Signatures, signatureAuditEntry(report))
+	}
+	audit.ModifiedAfter, _ = SignaturesModifiedAfter(reports)
+	return audit
+}
+
+// signatureAuditEntry 转换单个签名审计信息
+// 入参: report 签名验证报告
+// 返回: SignatureAuditEntry 单个签名审计信息
+func signatureAuditEntry(report SignatureVerifyReport) SignatureAuditEntry {
+	entry := SignatureAuditEntry{
+		ID:                report.ID,
+		BaseLoc:           report.BaseLoc,
+		Type:              string(report.Type),
+		Valid:             report.Valid,
+		IntegrityValid:    report.IntegrityValid(),
+		TrustedValid:      report.TrustedValid(),
+		Signer:            report.Signer,
+		Provider:          report.Provider.ProviderName,
+		Company:           report.Provider.Company,
+		ProviderVersion:   report.Provider.Version,
+		SignatureMethod:   report.SignatureMethod,
+		DigestMethod:      report.DigestMethod,
+		SignatureDateTime: report.SignatureDateTime,
+		SignatureTime:     report.SignatureTime,
+		SignCertChain:     signatureAuditCerts(report.SignCertChain, report.SignCert),
+		SealCertChain:     signatureAuditCerts(report.SealCertChain, report.SealCert),
+		Checks:            signatureAuditChecks(report),
+		Revocations:       report.Revocations,
+		Coverage:          report.Coverage,
+		Error:             report.Error,
+	}
+	if report.Type != SignTypeSign {
+		entry.Seal = &SignatureAuditSeal{
+			ID:          report.SealInfo.ID,
+			Name:        report.SealInfo.Name,
+			VendorID:    report.SealInfo.VendorID,
+			Type:        report.SealInfo.Type,
+			Version:     report.SealInfo.Version,
+			CreateTime:  report.SealInfo.CreateTime,
+			ValidStart:  report.SealInfo.ValidStart,
+			ValidEnd:    report.SealInfo.ValidEnd,
+			PictureType: report.SealType,
+			Picture:     report.SealPicture,
+		}
+	}
+	for _, ref := range report.References {
+		entry.References = append(entry.References, SignatureAuditReference{
+			FileRef:    ref.FileRef,
+			Path:       ref.Path,
+			OK:         ref.OK,
+			CheckValue: ref.CheckValue,
+			Actual:     ref.Actual,
+			Error:      ref.Error,
+		})
+	}
+	for _, position := range report.StampPositions {
+		entry.Stamps = append(entry.Stamps, SignatureAuditStamp{
+			ID:       position.ID,
+			Page:     position.Page,
+			PageID:   position.PageID,
+			Boundary: position.Boundary,
+			X:        position.Box.X,
+			Y:        position.Box.Y,
+			Width:    position.Box.W,
+			Height:   position.Box.H,
+		})
+	}
+	if report.StampPositionError != "" {
+		if entry.Error != "" {
+			entry.Error += "; "
+		}
+		entry.Error += report.StampPositionError
+	}
+	return entry
+}
+
+// signatureAuditCerts 转换证书链审计信息
+// 入参: chain 证书链, leaf 终端证书
+// 返回: []SignatureAuditCert 证书审计信息
+func signatureAuditCerts(chain []SignatureCertInfo, leaf SignatureCertInfo) []SignatureAuditCert {
+	if len(chain) == 0 && len(leaf.Raw) != 0 {
+		chain = []SignatureCertInfo{leaf}
+	}
+	out := make([]SignatureAuditCert, 0, len(chain))
+	for _, info := range chain {
+		cert := SignatureAuditCert{
+			Subject:      info.Subject,
+			CommonName:   info.CommonName,
+			Organization: info.Organization,
+			Issuer:       info.Issuer,
+			SerialNumber: info.SerialNumber,
+			NotBefore:    info.NotBefore,
+			NotAfter:     info.NotAfter,
+			DER:          info.Raw,
+		}
+		if len(info.Raw) != 0 {
+			sum := sha256.Sum256(info.Raw)
+			cert.SHA256 = hex.EncodeToString(sum[:])
+		}
+		out = append(out, cert)
+	}
+	return out
+}
+
+// signatureAuditChecks 获取签名检查项结果
+// 入参: report 签名验证报告
+// 返回: []SignatureAuditCheck 检查项结果
+func signatureAuditChecks(report SignatureVerifyReport) []SignatureAuditCheck {
+	integrity := report.IntegrityValid()
+	checks := []SignatureAuditCheck{
+		{Name: "digest", Checked: true, OK: report.DigestOK},
+		{Name: "dataHash", Checked: integrity, OK: report.DataHashOK},
+		{Name: "signedValue", Checked: integrity, OK: report.SignedValueOK},
+	}
+	if report.Type != SignTypeSign {
+		checks = append(checks,
+			SignatureAuditCheck{Name: "seal", Checked: integrity, OK: report.SealOK},
+			SignatureAuditCheck{Name: "sealMatch", Checked: integrity, OK: report.SealMatchOK},
+		)
+	}
+	return append(checks,
+		SignatureAuditCheck{Name: "cert", Checked: integrity, OK: report.CertOK},
+		SignatureAuditCheck{Name: "signatureTime", Checked: report.SignatureTimeChecked, OK: report.SignatureTimeOK},
+		SignatureAuditCheck{Name: "sealTime", Checked: report.SealTimeChecked, OK: report.SealTimeOK},
+		SignatureAuditCheck{Name: "sealCertTime", Checked: report.SealCertTimeChecked, OK: report.SealCertTimeOK},
+		SignatureAuditCheck{Name: "certTime", Checked: report.CertTimeChecked, OK: report.CertTimeOK},
+		SignatureAuditCheck{Name: "certTrust", Checked: report.CertTrustChecked, OK: report.CertTrustOK},
+		SignatureAuditCheck{Name: "revocation", Checked: report.RevocationChecked, OK: report.RevocationOK},
+		SignatureAuditCheck{Name: "coverage", Checked: report.CoverageChecked, OK: report.CoverageChecked && !report.Coverage.ModifiedAfterSign},
+	)
+}
+
+// JSON 编码签名验证审计报告
+// 返回: []byte JSON数据, error 错误信息
+func (audit SignatureAuditReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(audit, "", "  ")
+}
+
+// Text 生成可读签名验证报告
+// 入参: lang 报告语言
+// 返回: string 报告文本
+func (audit SignatureAuditReport) Text(lang SignatureReportLang) string {
+	var b strings.Builder
+	for _, line := range audit.lines(lang) {
+		b.WriteString(strings.Repeat("  ", line.Indent))
+		b.WriteString(line.Text)
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// signatureReportLine 签名报告文本行
+type signatureReportLine struct {
+	Text    string
+	Indent  int
+	Heading bool
+	Status  int
+	Entry   int
+}
+
+// lines 生成签名报告文本行
+// 入参: lang 报告语言
+// 返回: []signatureReportLine 报告文本行
+func (audit SignatureAuditReport) lines(lang SignatureReportLang) []signatureReportLine {
+	t := func(key string) string {
+		return signatureReportText(signatureReportTexts, key, lang)
+	}
+	verdict := t("invalid")
+	if audit.Valid {
+		verdict = t("valid")
+	}
+	lines := []signatureReportLine{
+		{Text: t("title"), Heading: true, Entry: -1},
+		{Text: t("generated") + ": " + audit.Generated.Format(time.RFC3339), Entry: -1},
+		{Text: fmt.Sprintf("%s: %d", t("count"), len(audit.Signatures)), Entry: -1},
+		{Text: t("verdict") + ": " + verdict, Status: signatureReportStatus(true, audit.Valid), Entry: -1},
+	}
+	if len(audit.Signatures) == 0 {
+		lines = append(lines, signatureReportLine{Text: t("none"), Entry: -1})
+	}
+	if audit.ModifiedAfter != "" {
+		lines = append(lines, signatureReportLine{Text: fmt.Sprintf(t("modified"), audit.ModifiedAfter), Status: -1, Entry: -1})
+	}
+	for i, entry := range audit.Signatures {
+		add := func(indent int, text string) {
+			lines = append(lines, signatureReportLine{Text: text, Indent: indent, Entry: i})
+		}
+		lines = append(lines, signatureReportLine{Text: fmt.Sprintf("[%d] %s %s (%s)", i+1, t("signature"), entry.ID, entry.Type), Heading: true, Entry: i})
+		result := t("invalid")
+		if entry.Valid {
+			result = t("valid")
+		}
+		lines = append(lines, signatureReportLine{Text: t("result") + ": " + result, Indent: 1, Status: signatureReportStatus(true, entry.Valid), Entry: i})
+		if entry.Signer != "" {
+			add(1, t("signer")+": "+entry.Signer)
+		}
+		if entry.SignatureDateTime != "" {
+			add(1, t("time")+": "+entry.SignatureDateTime)
+		}
+		if entry.Provider != "" {
+			add(1, t("provider")+": "+strings.TrimSpace(entry.Provider+" "+entry.ProviderVersion))
+		}
+		if entry.SignatureMethod != "" {
+			add(1, t("method")+": "+entry.SignatureMethod)
+		}
+		if entry.Seal != nil && (entry.Seal.Name != "" || entry.Seal.ID != "") {
+			add(1, t("sealName")+": "+strings.TrimSpace(entry.Seal.Name+" "+entry.Seal.ID))
+		}
+		for _, chain := range []struct {
+			Key   string
+			Certs []SignatureAuditCert
+		}{{"signCert", entry.SignCertChain}, {"sealCert", entry.SealCertChain}} {
+			if len(chain.Certs) == 0 {
+				continue
+			}
+			add(1, t(chain.Key)+":")
+			for _, cert := range chain.Certs {
+				add(2, cert.Subject)
+				add(3, t("issuer")+": "+cert.Issuer)
+				add(3, t("serial")+": "+cert.SerialNumber)
+				add(3, t("validity")+": "+cert.NotBefore.Format("2006-01-02")+" ~ "+cert.NotAfter.Format("2006-01-02"))
+			}
+		}
+		add(1, t("checks")+":")
+		for _, check := range entry.Checks {
+			status := t("skip")
+			if check.Checked && check.OK {
+				status = t("pass")
+			} else if check.Checked {
+				status = t("fail")
+			}
+			label := signatureReportText(signatureCheckLabels, check.Name, lang)
+			lines = append(lines, signatureReportLine{Text: "[" + status + "] " + label, Indent: 2, Status: signatureReportStatus(check.Checked, check.OK), Entry: i})
+		}
+		if len(entry.Stamps) != 0 {
+			add(1, t("stamps")+":")
+			for _, stamp := range entry.Stamps {
+				add(2, fmt.Sprintf(t("page")+" (%.2f, %.2f, %.2f, %.2f)", stamp.Page, stamp.X, stamp.Y, stamp.Width, stamp.Height))
+			}
+		}
+		passed := 0
+		for _, ref := range entry.References {
+			if ref.OK {
+				passed++
+			}
+		}
+		add(1, fmt.Sprintf("%s: %d/%d", t("references"), passed, len(entry.References)))
+		if len(entry.Coverage.Unprotected) != 0 {
+			add(1, t("unprotected")+": "+strings.Join(entry.Coverage.Unprotected, ", "))
+		}
+		if len(entry.Coverage.LaterSignatures) != 0 {
+			add(1, t("later")+": "+strings.Join(entry.Coverage.LaterSignatures, ", "))
+		}
+		if entry.Error != "" {
+			lines = append(lines, signatureReportLine{Text: t("error") + ": " + entry.Error, Indent: 1, Status: -1, Entry: i})
+		}
+	}
+	return lines
+}
+
+// signatureReportText 获取签名报告本地化文本
+// 入参: texts 文本表, key 文本键, lang 报告语言
+// 返回: string 本地化文本
+func signatureReportText(texts map[string][2]string, key string, lang SignatureReportLang) string {
+	text, ok := texts[key]
+	if !ok {
+		return key
+	}
+	if lang == SignatureReportEN {
+		return text[1]
+	}
+	return text[0]
+}
+
+// signatureReportStatus 获取签名报告状态
+// 入参: checked 是否检查, ok 是否通过
+// 返回: int 状态, 1通过, -1失败, 0未检查
+func signatureReportStatus(checked, ok bool) int {
+	switch {
+	case checked && ok:
+		return 1
+	case checked:
+		return -1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,459 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/tdewolff/canvas"
+	"github.com/tdewolff/canvas/renderers/pdf"
+)
+
+const (
+	signatureReportPageW    = 210.0
+	signatureReportPageH    = 297.0
+	signatureReportMargin   = 20.0
+	signatureReportTextW    = 125.0
+	signatureReportSealX    = 150.0
+	signatureReportSealSize = 35.0
+	signatureReportFontSize = 3.5
+	signatureReportHeadSize = 5.0
+	signatureReportFontName = "宋体"
+)
+
+// signatureReportItemKind 签名报告版面元素类型
+type signatureReportItemKind uint8
+
+const (
+	signatureReportItemText signatureReportItemKind = iota
+	signatureReportItemImage
+	signatureReportItemRect
+)
+
+// signatureReportItem 签名报告版面元素
+// 坐标采用OFD页面坐标系, 单位为毫米, 文本Y为基线位置
+type signatureReportItem struct {
+	Kind  signatureReportItemKind
+	X     float64
+	Y     float64
+	W     float64
+	H     float64
+	Size  float64
+	Text  string
+	Color color.RGBA
+	Fill  bool
+	Image image.Image
+}
+
+// RenderSignatureReportPDF 渲染签名验证报告为PDF
+// 入参: audit 签名验证审计报告, lang 报告语言, writer 输出流
+// 返回: error 错误信息
+func (r *Renderer) RenderSignatureReportPDF(audit SignatureAuditReport, lang SignatureReportLang, writer io.Writer) error {
+	pages := r.signatureReportLayout(audit, lang)
+	renderer, release := r.acquireState()
+	defer release()
+	ff := renderer.signatureReportFont()
+	if ff == nil {
+		return fmt.Errorf("no font available for signature report")
+	}
+	var buf bytes.Buffer
+	p := pdf.New(&buf, signatureReportPageW, signatureReportPageH, nil)
+	p.SetInfo(signatureReportText(signatureReportTexts, "title", lang), "", "", "", "xiaoqidun/ofdgo")
+	for i, items := range pages {
+		if i > 0 {
+			p.NewPage(signatureReportPageW, signatureReportPageH)
+		}
+		c := canvas.New(signatureReportPageW, signatureReportPageH)
+		ctx := canvas.NewContext(c)
+		ctx.SetFillColor(canvas.White)
+		ctx.DrawPath(0, 0, canvas.Rectangle(signatureReportPageW, signatureReportPageH))
+		for _, item := range items {
+			drawSignatureReportItem(ctx, ff, item)
+		}
+		c.RenderTo(p)
+	}
+	if err := p.Close(); err != nil {
+		return err
+	}
+	_, err := writer.Write(replacePDFProducer(buf.Bytes()))
+	return err
+}
+
+// drawSignatureReportItem 绘制签名报告版面元素
+// 入参: ctx 画布上下文, ff 字体族, item 版面元素
+func drawSignatureReportItem(ctx *canvas.Context, ff *canvas.FontFamily, item signatureReportItem) {
+	switch item.Kind {
+	case signatureReportItemText:
+		face := ff.Face(item.Size*ptPerMM, item.Color, canvas.FontRegular, canvas.FontNormal)
+		ctx.DrawText(item.X, signatureReportPageH-item.Y, canvas.NewTextLine(face, item.Text, canvas.Left))
+	case signatureReportItemImage:
+		bounds := item.Image.Bounds()
+		ctx.Push()
+		ctx.Translate(item.X, signatureReportPageH-(item.Y+item.H))
+		ctx.Scale(item.W/float64(bounds.Dx()), item.H/float64(bounds.Dy()))
+		ctx.DrawImage(0, 0, item.Image, canvas.DPMM(1.0))
+		ctx.Pop()
+	case signatureReportItemRect:
+		ctx.Push()
+		if item.Fill {
+			ctx.SetFillColor(item.Color)
+			ctx.SetStrokeColor(canvas.Transparent)
+		} else {
+			ctx.SetFillColor(canvas.Transparent)
+			ctx.SetStrokeColor(item.Color)
+			ctx.SetStrokeWidth(0.2)
+		}
+		ctx.DrawPath(item.X, signatureReportPageH-(item.Y+item.H), canvas.Rectangle(item.W, item.H))
+		ctx.Pop()
+	}
+}
+
+// RenderSignatureReportOFD 渲染签名验证报告为OFD
+// 入参: audit 签名验证审计报告, lang 报告语言, writer 输出流
+// 返回: error 错误信息
+func (r *Renderer) RenderSignatureReportOFD(audit SignatureAuditReport, lang SignatureReportLang, writer io.Writer) error {
+	pages := r.signatureReportLayout(audit, lang)
+	zw := zip.NewWriter(writer)
+	nextID := 1
+	newID := func() int {
+		nextID++
+		return nextID
+	}
+	var media strings.Builder
+	var pageRefs strings.Builder
+	for i, items := range pages {
+		var objects strings.Builder
+		for _, item := range items {
+			id := newID()
+			switch item.Kind {
+			case signatureReportItemText:
+				w := signatureReportTextWidth(item.Text, item.Size)
+				fmt.Fprintf(&objects, `<ofd:TextObject ID="%d" Boundary="%s" Font="1" Size="%s"><ofd:FillColor Value="%d %d %d"/><ofd:TextCode X="0" Y="%s">%s</ofd:TextCode></ofd:TextObject>`,
+					id, signatureReportBox(item.X, item.Y-item.Size, w, item.Size*1.4), formatOFDNumber(item.Size), item.Color.R, item.Color.G, item.Color.B, formatOFDNumber(item.Size), xmlEscapeString(item.Text))
+			case signatureReportItemImage:
+				var data bytes.Buffer
+				if err := png.Encode(&data, item.Image); err != nil {
+					return err
+				}
+				mediaID := newID()
+				name := fmt.Sprintf("Image_%d.png", mediaID)
+				if err := writeZipEntry(zw, "Doc_0/Res/"+name, data.Bytes()); err != nil {
+					return err
+				}
+				fmt.Fprintf(&media, `<ofd:MultiMedia ID="%d" Type="Image" Format="PNG"><ofd:MediaFile>%s</ofd:MediaFile></ofd:MultiMedia>`, mediaID, name)
+				fmt.Fprintf(&objects, `<ofd:ImageObject ID="%d" Boundary="%s" CTM="%s 0 0 %s 0 0" ResourceID="%d"/>`,
+					id, signatureReportBox(item.X, item.Y, item.W, item.H), formatOFDNumber(item.W), formatOFDNumber(item.H), mediaID)
+			case signatureReportItemRect:
+				fill, stroke := "false", "true"
+				if item.Fill {
+					fill, stroke = "true", "false"
+				}
+				fmt.Fprintf(&objects, `<ofd:PathObject ID="%d" Boundary="%s" Fill="%s" Stroke="%s" LineWidth="0.2"><ofd:FillColor Value="%d %d %d"/><ofd:StrokeColor Value="%d %d %d"/><ofd:AbbreviatedData>M 0 0 L %s 0 L %s %s L 0 %s C</ofd:AbbreviatedData></ofd:PathObject>`,
+					id, signatureReportBox(item.X, item.Y, item.W, item.H), fill, stroke, item.Color.R, item.Color.G, item.Color.B, item.Color.R, item.Color.G, item.Color.B,
+					formatOFDNumber(item.W), formatOFDNumber(item.W), formatOFDNumber(item.H), formatOFDNumber(item.H))
+			}
+		}
+		layerID := newID()
+		content := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<ofd:Page xmlns:ofd="http://www.ofdspec.org/2016"><ofd:Content><ofd:Layer ID="%d">%s</ofd:Layer></ofd:Content></ofd:Page>`, layerID, objects.String())
+		if err := writeZipEntry(zw, fmt.Sprintf("Doc_0/Pages/Page_%d/Content.xml", i), []byte(content)); err != nil {
+			return err
+		}
+		fmt.Fprintf(&pageRefs, `<ofd:Page ID="%d" BaseLoc="Pages/Page_%d/Content.xml"/>`, newID(), i)
+	}
+	title := signatureReportText(signatureReportTexts, "title", lang)
+	files := []struct {
+		Name string
+		Data string
+	}{
+		{"OFD.xml", fmt.Sprintf(`<ofd:OFD xmlns:ofd="http://www.ofdspec.org/2016" Version="1.0" DocType="OFD"><ofd:DocBody><ofd:DocInfo><ofd:DocID>%x</ofd:DocID><ofd:Title>%s</ofd:Title><ofd:CreationDate>%s</ofd:CreationDate><ofd:Creator>xiaoqidun/ofdgo</ofd:Creator></ofd:DocInfo><ofd:DocRoot>Doc_0/Document.xml</ofd:DocRoot></ofd:DocBody></ofd:OFD>`,
+			audit.Generated.UnixNano(), xmlEscapeString(title), audit.Generated.Format(time.DateOnly))},
+		{"Doc_0/Document.xml", fmt.Sprintf(`<ofd:Document xmlns:ofd="http://www.ofdspec.org/2016"><ofd:CommonData><ofd:MaxUnitID>%d</ofd:MaxUnitID><ofd:PageArea><ofd:PhysicalBox>0 0 %s %s</ofd:PhysicalBox></ofd:PageArea><ofd:PublicRes>PublicRes.xml</ofd:PublicRes><ofd:DocumentRes>DocumentRes.xml</ofd:DocumentRes></ofd:CommonData><ofd:Pages>%s</ofd:Pages></ofd:Document>`,
+			nextID, formatOFDNumber(signatureReportPageW), formatOFDNumber(signatureReportPageH), pageRefs.String())},
+		{"Doc_0/PublicRes.xml", fmt.Sprintf(`<ofd:Res xmlns:ofd="http://www.ofdspec.org/2016" BaseLoc="Res"><ofd:Fonts><ofd:Font ID="1" FontName="%s" FamilyName="%s"/></ofd:Fonts></ofd:Res>`,
+			signatureReportFontName, signatureReportFontName)},
+		{"Doc_0/DocumentRes.xml", `<ofd:Res xmlns:ofd="http://www.ofdspec.org/2016" BaseLoc="Res"><ofd:MultiMedias>` + media.String() + `</ofd:MultiMedias></ofd:Res>`},
+	}
+	for _, file := range files {
+		if err := writeZipEntry(zw, file.Name, []byte(`<?xml version="1.0" encoding="UTF-8"?>`+"\n"+file.Data)); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// writeZipEntry 写入压缩包文件
+// 入参: zw 压缩包写入器, name 文件名, data 文件数据
+// 返回: error 错误信息
+func writeZipEntry(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// xmlEscapeString 转义XML文本
+// 入参: s 原始文本
+// 返回: string 转义后的文本
+func xmlEscapeString(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// signatureReportBox 格式化签名报告区域
+// 入参: x 横坐标, y 纵坐标, w 宽度, h 高度
+// 返回: string 区域字符串
+func signatureReportBox(x, y, w, h float64) string {
+	return formatOFDNumber(x) + " " + formatOFDNumber(y) + " " + formatOFDNumber(w) + " " + formatOFDNumber(h)
+}
+
+// formatOFDNumber 格式化OFD数值
+// 入参: v 数值
+// 返回: string 数值字符串
+func formatOFDNumber(v float64) string {
+	s := fmt.Sprintf("%.3f", v)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "" || s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// signatureReportFont 获取签名报告字体
+// 依次尝试宋体、默认字体及字体目录中的任意字体
+// 返回: *canvas.FontFamily 字体族
+func (r *Renderer) signatureReportFont() *canvas.FontFamily {
+	font := &Font{FontName: signatureReportFontName, FamilyName: signatureReportFontName}
+	for _, source := range r.fontSources("ofdgo-signature-report", font, canvas.FontRegular) {
+		if family := r.loadFontSource(canvas.NewFontFamily(font.FontName), source, canvas.FontRegular); family != nil {
+			return family
+		}
+	}
+	if r.defaultFontLoaded {
+		return r.fontFamily
+	}
+	for _, dir := range r.fontDirs {
+		files, _ := filepath.Glob(filepath.Join(dir, "*"))
+		for _, name := range files {
+			if family := r.loadFontSource(canvas.NewFontFamily(font.FontName), fontSource{kind: fontSourceFile, name: name}, canvas.FontRegular); family != nil {
+				return family
+			}
+		}
+	}
+	for index, fsys := range r.fontFS {
+		names, _ := fs.Glob(fsys, "*")
+		for _, name := range names {
+			if family := r.loadFontSource(canvas.NewFontFamily(font.FontName), fontSource{kind: fontSourceFS, index: index, name: name}, canvas.FontRegular); family != nil {
+				return family
+			}
+		}
+	}
+	return nil
+}
+
+// signatureReportLayout 排版签名验证报告
+// 入参: audit 签名验证审计报告, lang 报告语言
+// 返回: [][]signatureReportItem 各页版面元素
+func (r *Renderer) signatureReportLayout(audit SignatureAuditReport, lang SignatureReportLang) [][]signatureReportItem {
+	var pages [][]signatureReportItem
+	var items []signatureReportItem
+	y := signatureReportMargin
+	newPage := func() {
+		pages = append(pages, items)
+		items = nil
+		y = signatureReportMargin
+	}
+	placed := make(map[int]bool)
+	for _, line := range audit.lines(lang) {
+		size := signatureReportFontSize
+		if line.Heading {
+			size = signatureReportHeadSize
+		}
+		if line.Heading && line.Entry >= 0 && y+signatureReportSealSize+size*3 > signatureReportPageH-signatureReportMargin {
+			newPage()
+		}
+		width := signatureReportPageW - 2*signatureReportMargin
+		if line.Entry >= 0 {
+			width = signatureReportTextW
+		}
+		x := signatureReportMargin + float64(line.Indent)*size*1.5
+		for _, text := range wrapSignatureReportText(line.Text, size, width-(x-signatureReportMargin)) {
+			if y+size*1.6 > signatureReportPageH-signatureReportMargin {
+				newPage()
+			}
+			y += size * 1.6
+			items = append(items, signatureReportItem{Kind: signatureReportItemText, X: x, Y: y, Size: size, Text: text, Color: signatureReportColor(line.Status)})
+		}
+		if line.Heading && line.Entry >= 0 && !placed[line.Entry] {
+			placed[line.Entry] = true
+			items = append(items, r.signatureReportSeal(audit.Signatures[line.Entry], y-size)...)
+		}
+	}
+	return append(pages, items)
+}
+
+// signatureReportSeal 排版签名印章图像与外观位置示意
+// 入参: entry 单个签名审计信息, top 顶部位置
+// 返回: []signatureReportItem 版面元素
+func (r *Renderer) signatureReportSeal(entry SignatureAuditEntry, top float64) []signatureReportItem {
+	var items []signatureReportItem
+	if img := r.signatureReportSealImage(entry); img != nil {
+		w, h := signatureReportSealSize, signatureReportSealSize
+		bounds := img.Bounds()
+		if bounds.Dx() > 0 && bounds.Dy() > 0 {
+			if bounds.Dx() > bounds.Dy() {
+				h = w * float64(bounds.Dy()) / float64(bounds.Dx())
+			} else {
+				w = h * float64(bounds.Dx()) / float64(bounds.Dy())
+			}
+		}
+		items = append(items, signatureReportItem{Kind: signatureReportItemImage, X: signatureReportSealX, Y: top, W: w, H: h, Image: img})
+		top += signatureReportSealSize + 4
+	}
+	if len(entry.Stamps) == 0 {
+		return items
+	}
+	box, ok := r.signatureReportPageBox(entry.Stamps[0].Page)
+	if !ok || box.W <= 0 || box.H <= 0 {
+		return items
+	}
+	scale := signatureReportSealSize / box.W
+	if box.H > box.W {
+		scale = signatureReportSealSize / box.H
+	}
+	gray := color.RGBA{R: 110, G: 110, B: 110, A: 255}
+	items = append(items, signatureReportItem{Kind: signatureReportItemRect, X: signatureReportSealX, Y: top, W: box.W * scale, H: box.H * scale, Color: gray})
+	for _, stamp := range entry.Stamps {
+		if stamp.Page != entry.Stamps[0].Page {
+			continue
+		}
+		items = append(items, signatureReportItem{
+			Kind:  signatureReportItemRect,
+			X:     signatureReportSealX + stamp.X*scale,
+			Y:     top + stamp.Y*scale,
+			W:     stamp.Width * scale,
+			H:     stamp.Height * scale,
+			Color: color.RGBA{R: 200, A: 255},
+			Fill:  true,
+		})
+	}
+	return items
+}
+
+// signatureReportSealImage 获取签名印章图像
+// 入参: entry 单个签名审计信息
+// 返回: image.Image 印章图像
+func (r *Renderer) signatureReportSealImage(entry SignatureAuditEntry) image.Image {
+	if entry.Seal == nil || len(entry.Seal.Picture) == 0 {
+		return nil
+	}
+	if strings.EqualFold(entry.Seal.PictureType, "ofd") {
+		if img := r.renderOFDStampImage(entry.Seal.Picture); img != nil {
+			return stampImageWithTransparentWhite(img)
+		}
+		return nil
+	}
+	img, err := decodeImageDataLimited(entry.Seal.Picture, r.limits)
+	if err != nil {
+		return nil
+	}
+	return stampImageWithTransparentWhite(imagePixelSource(img))
+}
+
+// signatureReportPageBox 获取签名外观所在页面区域
+// 入参: page 页码
+// 返回: Box 页面区域, bool 是否存在
+func (r *Renderer) signatureReportPageBox(page int) (Box, bool) {
+	if r.Reader == nil {
+		return Box{}, false
+	}
+	doc, err := r.Reader.Doc()
+	if err != nil || page < 1 || page > len(doc.Pages.Page) {
+		return Box{}, false
+	}
+	content, err := r.Reader.PageContent(doc.Pages.Page[page-1])
+	if err != nil {
+		return Box{}, false
+	}
+	box, err := r.GetPageBox(content)
+	return box, err == nil
+}
+
+// signatureReportColor 获取签名报告状态颜色
+// 入参: status 状态
+// 返回: color.RGBA 颜色
+func signatureReportColor(status int) color.RGBA {
+	switch status {
+	case 1:
+		return color.RGBA{G: 128, A: 255}
+	case -1:
+		return color.RGBA{R: 200, A: 255}
+	default:
+		return color.RGBA{A: 255}
+	}
+}
+
+// signatureReportTextWidth 估算签名报告文本宽度
+// 入参: text 文本, size 字号
+// 返回: float64 文本宽度
+func signatureReportTextWidth(text string, size float64) float64 {
+	w := 0.0
+	for _, ch := range text {
+		w += signatureReportRuneWidth(ch, size)
+	}
+	return w
+}
+
+// signatureReportRuneWidth 估算字符宽度
+// 入参: ch 字符, size 字号
+// 返回: float64 字符宽度
+func signatureReportRuneWidth(ch rune, size float64) float64 {
+	if ch >= 0x2E80 {
+		return size
+	}
+	return size * 0.55
+}
+
+// wrapSignatureReportText 按宽度折行签名报告文本
+// 入参: text 文本, size 字号, width 最大宽度
+// 返回: []string 折行后的文本
+func wrapSignatureReportText(text string, size, width float64) []string {
+	var lines []string
+	var line []rune
+	w := 0.0
+	for _, ch := range text {
+		cw := signatureReportRuneWidth(ch, size)
+		if w+cw > width && len(line) > 0 {
+			lines = append(lines, string(line))
+			line, w = nil, 0
+		}
+		line = append(line, ch)
+		w += cw
+	}
+	return append(lines, string(line))
+}
@@ -0,0 +1,734 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+const (
+	signOCSPBasicResponse = "1.3.6.1.5.5.7.48.1.1"
+	signExtKeyOCSPSigning = "1.3.6.1.5.5.7.3.9"
+)
+
+// SignatureRevocationSource 证书吊销状态来源
+type SignatureRevocationSource string
+
+const (
+	// SignatureRevocationSourceCRL 调用方提供的CRL
+	SignatureRevocationSourceCRL SignatureRevocationSource = "CRL"
+	// SignatureRevocationSourceOCSP 调用方提供的OCSP响应
+	SignatureRevocationSourceOCSP SignatureRevocationSource = "OCSP"
+	// SignatureRevocationSourceFetchedCRL 在线获取的CRL
+	SignatureRevocationSourceFetchedCRL SignatureRevocationSource = "FetchedCRL"
+	// SignatureRevocationSourceFetchedOCSP 在线获取的OCSP响应
+	SignatureRevocationSourceFetchedOCSP SignatureRevocationSource = "FetchedOCSP"
+)
+
+// SignatureRevocationFetcher 证书吊销信息在线获取接口
+// 用于服务端接入在线CRL下载或OCSP查询, 返回的数据与离线提供的数据执行相同的验证
+type SignatureRevocationFetcher interface {
+	// FetchRevocation 获取证书吊销信息
+	// 入参: cert DER编码证书, issuer DER编码颁发者证书
+	// 返回: [][]byte DER编码CRL列表, [][]byte DER编码OCSP响应列表, error 错误信息
+	FetchRevocation(cert, issuer []byte) ([][]byte, [][]byte, error)
+}
+
+// SignatureRevocationContextFetcher 支持上下文的证书吊销信息在线获取接口
+// 使用VerifySignaturesContext验证时优先调用
+type SignatureRevocationContextFetcher interface {
+	SignatureRevocationFetcher
+	// FetchRevocationContext 使用上下文获取证书吊销信息
+	// 入参: ctx 上下文, cert DER编码证书, issuer DER编码颁发者证书
+	// 返回: [][]byte DER编码CRL列表, [][]byte DER编码OCSP响应列表, error 错误信息
+	FetchRevocationContext(ctx context.Context, cert, issuer []byte) ([][]byte, [][]byte, error)
+}
+
+// SignatureRevocationStatus 单张证书吊销检查结果
+type SignatureRevocationStatus struct {
+	Subject        string                    `json:"subject,omitempty"`
+	SerialNumber   string                    `json:"serialNumber,omitempty"`
+	Checked        bool                      `json:"checked"`
+	Revoked        bool                      `json:"revoked"`
+	RevocationTime time.Time                 `json:"revocationTime,omitzero"`
+	Source         SignatureRevocationSource `json:"source,omitempty"`
+	ThisUpdate     time.Time                 `json:"thisUpdate,omitzero"`
+	NextUpdate     time.Time                 `json:"nextUpdate,omitzero"`
+	Error          string                    `json:"error,omitempty"`
+}
+
+// signatureCRL 证书吊销列表
+type signatureCRL struct {
+	TBS          []byte
+	Issuer       []byte
+	ThisUpdate   time.Time
+	NextUpdate   time.Time
+	SignatureAlg string
+	Signature    []byte
+	Revoked      map[string]time.Time
+}
+
+// signatureOCSPResponse OCSP基本响应
+type signatureOCSPResponse struct {
+	TBS          []byte
+	SignatureAlg string
+	Signature    []byte
+	ResponderKey []byte
+	ResponderDN  []byte
+	Certs        [][]byte
+	Responses    []signatureOCSPSingle
+}
+
+// signatureOCSPSingle OCSP单证书响应
+type signatureOCSPSingle struct {
+	HashAlg        string
+	IssuerNameHash []byte
+	IssuerKeyHash  []byte
+	Serial         *big.Int
+	Status         int
+	RevocationTime time.Time
+	ThisUpdate     time.Time
+	NextUpdate     time.Time
+}
+
+// WithSignatureCRL 添加证书吊销列表
+// 入参: crl DER或PEM编码CRL
+// 返回: SignatureVerifyOption 签名验证选项
+func WithSignatureCRL(crl []byte) SignatureVerifyOption {
+	return func(o *signatureVerifyOptions) {
+		o.CRLs = appendSignatureCRLs(o.CRLs, crl)
+	}
+}
+
+// WithSignatureCRLs 添加多个证书吊销列表
+// 入参: crls DER或PEM编码CRL列表
+// 返回: SignatureVerifyOption 签名验证选项
+func WithSignatureCRLs(crls ...[]byte) SignatureVerifyOption {
+	return func(o *signatureVerifyOptions) {
+		o.CRLs = appendSignatureCRLs(o.CRLs, crls...)
+	}
+}
+
+// WithSignatureOCSPResponse 添加OCSP响应
+// 入参: resp DER编码OCSP响应
+// 返回: SignatureVerifyOption 签名验证选项
+func WithSignatureOCSPResponse(resp []byte) SignatureVerifyOption {
+	return func(o *signatureVerifyOptions) {
+		o.OCSPResponses = appendSignatureOCSPResponses(o.OCSPResponses, resp)
+	}
+}
+
+// WithSignatureOCSPResponses 添加多个OCSP响应
+// 入参: resps DER编码OCSP响应列表
+// 返回: SignatureVerifyOption 签名验证选项
+func WithSignatureOCSPResponses(resps ...[]byte) SignatureVerifyOption {
+	return func(o *signatureVerifyOptions) {
+		o.OCSPResponses = appendSignatureOCSPResponses(o.OCSPResponses, resps...)
+	}
+}
+
+// WithSignatureRevocationFetcher 设置证书吊销信息在线获取接口
+// 入参: fetcher 吊销信息获取接口
+// 返回: SignatureVerifyOption 签名验证选项
+func WithSignatureRevocationFetcher(fetcher SignatureRevocationFetcher) SignatureVerifyOption {
+	return func(o *signatureVerifyOptions) {
+		o.RevocationFetcher = fetcher
+	}
+}
+
+// appendSignatureCRLs 追加证书吊销列表
+// 入参: dst 目标CRL列表, crls DER或PEM编码CRL列表
+// 返回: [][]byte CRL列表
+func appendSignatureCRLs(dst [][]byte, crls ...[]byte) [][]byte {
+	for _, crl := range crls {
+		dst = append(dst, parseSignatureDERBlocks(crl, "X509 CRL")...)
+	}
+	return dst
+}
+
+// appendSignatureOCSPResponses 追加OCSP响应
+// 入参: dst 目标响应列表, resps DER编码OCSP响应列表
+// 返回: [][]byte OCSP响应列表
+func appendSignatureOCSPResponses(dst [][]byte, resps ...[]byte) [][]byte {
+	for _, resp := range resps {
+		if len(resp) != 0 {
+			dst = append(dst, append([]byte(nil), resp...))
+		}
+	}
+	return dst
+}
+
+// parseSignatureDERBlocks 解析DER或PEM编码数据
+// 入参: data DER或PEM编码数据, blockType PEM块类型
+// 返回: [][]byte DER编码数据列表
+func parseSignatureDERBlocks(data []byte, blockType string) [][]byte {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil
+	}
+	var out [][]byte
+	rest := data
+	hasPEM := false
+	for {
+		block, next := pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		hasPEM = true
+		if block.Type == blockType {
+			out = append(out, append([]byte(nil), block.Bytes...))
+		}
+		rest = next
+	}
+	if hasPEM {
+		return out
+	}
+	return [][]byte{append([]byte(nil), data...)}
+}
+
+// revocationRequested 判断是否需要检查证书吊销状态
+// 返回: bool 是否需要检查
+func (o *signatureVerifyOptions) revocationRequested() bool {
+	return len(o.CRLs) != 0 || len(o.OCSPResponses) != 0 || o.RevocationFetcher != nil
+}
+
+// applySignatureRevocationPolicy 应用证书吊销策略
+// 待检查证书及其证书链中的中间证书均需检查, 吊销时间晚于参考时间的证书视为签名时未吊销, 参考时间依次取验证时间、签名时间和当前时间
+// 入参: options 验证选项, certs 待检查证书, pool 证书索引
+func (report *SignatureVerifyReport) applySignatureRevocationPolicy(options *signatureVerifyOptions, certs [][]byte, pool *signatureCertIndex) {
+	if !options.revocationRequested() {
+		return
+	}
+	certs = signatureRevocationCerts(certs, pool)
+	refTime := time.Now()
+	if options.VerifyTime != nil {
+		refTime = *options.VerifyTime
+	} else if !report.SignatureTime.IsZero() {
+		refTime = report.SignatureTime
+	}
+	report.RevocationChecked = true
+	report.RevocationOK = len(certs) != 0
+	var sources []string
+	for _, cert := range certs {
+		status := checkSignatureRevocation(cert, pool, options, refTime)
+		report.Revocations = append(report.Revocations, status)
+		if !status.Checked || status.Revoked {
+			report.RevocationOK = false
+		}
+		if status.Source != "" && !containsString(sources, string(status.Source)) {
+			sources = append(sources, string(status.Source))
+		}
+	}
+	report.RevocationSource = strings.Join(sources, ",")
+}
+
+// signatureRevocationCerts 获取需检查吊销状态的证书
+// 沿证书链向上收集中间证书, 到达信任锚或自签名根证书时停止
+// 入参: certs 待检查证书, pool 证书索引
+// 返回: [][]byte DER编码证书列表
+func signatureRevocationCerts(certs [][]byte, pool *signatureCertIndex) [][]byte {
+	out := make([][]byte, 0, len(certs))
+	seen := make(map[string]bool)
+	for _, cert := range certs {
+		for i, info := range signatureCertChain(cert, pool) {
+			if i > 0 {
+				if pool.trusted(info.Raw) {
+					break
+				}
+				if c, err := pool.parse(info.Raw); err != nil || bytes.Equal(c.Issuer, c.Subject) {
+					break
+				}
+			}
+			if !seen[string(info.Raw)] {
+				seen[string(info.Raw)] = true
+				out = append(out, info.Raw)
+			}
+		}
+	}
+	return out
+}
+
+// containsString 判断字符串列表是否包含指定值
+// 入参: items 字符串列表, value 指定值
+// 返回: bool 是否包含
+func containsString(items []string, value string) bool {
+	for _, item := range items {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSignatureRevocation 检查单张证书吊销状态
+// 入参: cert DER编码证书, pool 证书索引, options 验证选项, refTime 参考时间
+// 返回: SignatureRevocationStatus 吊销检查结果
+func checkSignatureRevocation(cert []byte, pool *signatureCertIndex, options *signatureVerifyOptions, refTime time.Time) SignatureRevocationStatus {
+	info := signatureCertInfo(cert)
+	status := SignatureRevocationStatus{Subject: info.Subject, SerialNumber: info.SerialNumber}
+	c, err := parseSignatureCertificate(cert)
+	if err != nil {
+		status.Error = err.Error()
+		return status
+	}
+	issuer := findSignatureCertIssuer(c, pool)
+	if issuer == nil {
+		status.Error = "issuer certificate not found"
+		return status
+	}
+	if signatureRevocationFrom(&status, c, issuer, options.OCSPResponses, options.CRLs, refTime, SignatureRevocationSourceOCSP, SignatureRevocationSourceCRL) {
+		return status
+	}
+	if options.RevocationFetcher != nil {
+		var crls, ocsps [][]byte
+		var err error
+		if fetcher, ok := options.RevocationFetcher.(SignatureRevocationContextFetcher); ok && options.ctx != nil {
+			crls, ocsps, err = fetcher.FetchRevocationContext(options.ctx, cert, issuer)
+		} else {
+			crls, ocsps, err = options.RevocationFetcher.FetchRevocation(cert, issuer)
+		}
+		if err != nil {
+			status.Error = err.Error()
+			return status
+		}
+		if signatureRevocationFrom(&status, c, issuer, ocsps, appendSignatureCRLs(nil, crls...), refTime, SignatureRevocationSourceFetchedOCSP, SignatureRevocationSourceFetchedCRL) {
+			return status
+		}
+	}
+	if status.Error == "" {
+		status.Error = "revocation information not found"
+	}
+	return status
+}
+
+// signatureRevocationFrom 从OCSP响应和CRL确定证书吊销状态
+// 入参: status 吊销检查结果, c 证书, issuer 颁发者证书, ocsps OCSP响应, crls CRL列表, refTime 参考时间, ocspSource OCSP来源, crlSource CRL来源
+// 返回: bool 是否已确定状态
+func signatureRevocationFrom(status *SignatureRevocationStatus, c signatureCertificate, issuer []byte, ocsps, crls [][]byte, refTime time.Time, ocspSource, crlSource SignatureRevocationSource) bool {
+	for _, data := range ocsps {
+		single, err := matchSignatureOCSP(data, c, issuer)
+		if err != nil {
+			status.Error = err.Error()
+			continue
+		}
+		if single == nil || single.Status == 2 || !revocationCovers(single.ThisUpdate, single.NextUpdate, refTime) {
+			continue
+		}
+		status.Checked = true
+		status.Source = ocspSource
+		status.ThisUpdate = single.ThisUpdate
+		status.NextUpdate = single.NextUpdate
+		status.Revoked = single.Status == 1 && !single.RevocationTime.After(refTime)
+		status.RevocationTime = single.RevocationTime
+		status.Error = ""
+		return true
+	}
+	for _, data := range crls {
+		crl, err := parseSignatureCRL(data)
+		if err != nil {
+			status.Error = err.Error()
+			continue
+		}
+		if !bytes.Equal(crl.Issuer, c.Issuer) || !revocationCovers(crl.ThisUpdate, crl.NextUpdate, refTime) {
+			continue
+		}
+		if !signatureCRLIssuer(issuer) {
+			status.Error = "crl issuer not authorized for crl signing"
+			continue
+		}
+		if ok, err := verifyIssuedSignature(crl.SignatureAlg, crl.TBS, crl.Signature, issuer); err != nil || !ok {
+			status.Error = "invalid crl signature"
+			continue
+		}
+		status.Checked = true
+		status.Source = crlSource
+		status.ThisUpdate = crl.ThisUpdate
+		status.NextUpdate = crl.NextUpdate
+		if revoked, ok := crl.Revoked[c.Serial.String()]; ok {
+			status.Revoked = !revoked.After(refTime)
+			status.RevocationTime = revoked
+		}
+		status.Error = ""
+		return true
+	}
+	return false
+}
+
+// signatureCRLIssuer 判断颁发者证书是否可签发CRL
+// 入参: issuer DER编码颁发者证书
+// 返回: bool 未限制密钥用途或包含cRLSign时为true
+func signatureCRLIssuer(issuer []byte) bool {
+	c, err := parseSignatureCertificate(issuer)
+	if err != nil {
+		return false
+	}
+	return c.KeyUsage == 0 || c.KeyUsage&x509.KeyUsageCRLSign != 0
+}
+
+// revocationCovers 判断吊销信息是否覆盖参考时间
+// 入参: thisUpdate 本次更新时间, nextUpdate 下次更新时间, refTime 参考时间
+// 返回: bool 是否覆盖
+func revocationCovers(thisUpdate, nextUpdate, refTime time.Time) bool {
+	return thisUpdate.After(refTime) || nextUpdate.IsZero() || !refTime.After(nextUpdate)
+}
+
+// findSignatureCertIssuer 查找证书颁发者
+// 入参: c 证书, pool 证书索引
+// 返回: []byte DER编码颁发者证书
+func findSignatureCertIssuer(c signatureCertificate, pool *signatureCertIndex) []byte {
+	for _, issuer := range pool.issuers(&c) {
+		if bytes.Equal(c.Raw, issuer.Raw) {
+			continue
+		}
+		if ok, err := verifyCertificateSignature(c, issuer.Raw); err == nil && ok {
+			return issuer.Raw
+		}
+	}
+	return nil
+}
+
+// parseSignatureCRL 解析证书吊销列表
+// 入参: data DER编码CRL
+// 返回: *signatureCRL 证书吊销列表, error 错误信息
+func parseSignatureCRL(data []byte) (*signatureCRL, error) {
+	var list struct {
+		TBSCertList        asn1.RawValue
+		SignatureAlgorithm asn1.RawValue
+		SignatureValue     asn1.BitString
+	}
+	rest, err := asn1.Unmarshal(data, &list)
+	if err != nil || len(rest) != 0 || list.SignatureValue.BitLength%8 != 0 {
+		return nil, fmt.Errorf("invalid crl")
+	}
+	items, ok := asn1Children(list.TBSCertList.Bytes)
+	if !ok {
+		return nil, fmt.Errorf("invalid tbs cert list")
+	}
+	idx := 0
+	if len(items) > 0 && items[0].Class == asn1.ClassUniversal && items[0].Tag == asn1.TagInteger {
+		idx++
+	}
+	if len(items) < idx+3 {
+		return nil, fmt.Errorf("invalid crl")
+	}
+	if !bytes.Equal(items[idx].FullBytes, list.SignatureAlgorithm.FullBytes) {
+		return nil, fmt.Errorf("crl signature algorithm mismatch")
+	}
+	alg, err := parseGBTAlgorithm(list.SignatureAlgorithm)
+	if err != nil {
+		return nil, err
+	}
+	thisUpdate, err := asn1Time(items[idx+2])
+	if err != nil {
+		return nil, err
+	}
+	crl := &signatureCRL{
+		TBS:          append([]byte(nil), list.TBSCertList.FullBytes...),
+		Issuer:       append([]byte(nil), items[idx+1].FullBytes...),
+		ThisUpdate:   thisUpdate,
+		SignatureAlg: alg,
+		Signature:    append([]byte(nil), list.SignatureValue.Bytes...),
+		Revoked:      make(map[string]time.Time),
+	}
+	idx += 3
+	if idx < len(items) && items[idx].Class == asn1.ClassUniversal && (items[idx].Tag == asn1.TagUTCTime || items[idx].Tag == asn1.TagGeneralizedTime) {
+		if crl.NextUpdate, err = asn1Time(items[idx]); err != nil {
+			return nil, err
+		}
+		idx++
+	}
+	if idx < len(items) && items[idx].Class == asn1.ClassUniversal && items[idx].Tag == asn1.TagSequence {
+		entries, ok := asn1Children(items[idx].Bytes)
+		if !ok {
+			return nil, fmt.Errorf("invalid revoked certificates")
+		}
+		for _, entry := range entries {
+			fields, ok := asn1Children(entry.Bytes)
+			if !ok || len(fields) < 2 {
+				return nil, fmt.Errorf("invalid revoked certificate")
+			}
+			serial, err := asn1IntegerBig(fields[0])
+			if err != nil {
+				return nil, err
+			}
+			revoked, err := asn1Time(fields[1])
+			if err != nil {
+				return nil, err
+			}
+			crl.Revoked[serial.String()] = revoked
+		}
+	}
+	return crl, nil
+}
+
+// matchSignatureOCSP 匹配并验证OCSP响应
+// 入参: data DER编码OCSP响应, c 证书, issuer 颁发者证书
+// 返回: *signatureOCSPSingle 匹配的单证书响应, error 错误信息
+func matchSignatureOCSP(data []byte, c signatureCertificate, issuer []byte) (*signatureOCSPSingle, error) {
+	resp, err := parseSignatureOCSPResponse(data)
+	if err != nil {
+		return nil, err
+	}
+	issuerCert, err := parseSignatureCertificate(issuer)
+	if err != nil {
+		return nil, err
+	}
+	issuerKey, err := signatureSubjectPublicKey(issuerCert)
+	if err != nil {
+		return nil, err
+	}
+	var single *signatureOCSPSingle
+	for i := range resp.Responses {
+		item := &resp.Responses[i]
+		if item.Serial.Cmp(c.Serial) != 0 {
+			continue
+		}
+		nameHash, err := signatureDigest(item.HashAlg, issuerCert.Subject)
+		if err != nil || !bytes.Equal(nameHash, item.IssuerNameHash) {
+			continue
+		}
+		keyHash, err := signatureDigest(item.HashAlg, issuerKey)
+		if err != nil || !bytes.Equal(keyHash, item.IssuerKeyHash) {
+			continue
+		}
+		single = item
+		break
+	}
+	if single == nil {
+		return nil, nil
+	}
+	if !verifySignatureOCSPSigner(resp, issuer, issuerCert) {
+		return nil, fmt.Errorf("invalid ocsp response signature")
+	}
+	return single, nil
+}
+
+// verifySignatureOCSPSigner 验证OCSP响应签名者
+// 签名者须为颁发者本身, 或由颁发者直接签发且具有OCSP签名扩展用途的授权响应者
+// 入参: resp OCSP响应, issuer 颁发者证书, issuerCert 颁发者证书结构
+// 返回: bool 是否验证通过
+func verifySignatureOCSPSigner(resp *signatureOCSPResponse, issuer []byte, issuerCert signatureCertificate) bool {
+	if ok, err := verifyIssuedSignature(resp.SignatureAlg, resp.TBS, resp.Signature, issuer); err == nil && ok {
+		return true
+	}
+	for _, responder := range resp.Certs {
+		rc, err := parseSignatureCertificate(responder)
+		if err != nil || !bytes.Equal(rc.Issuer, issuerCert.Subject) {
+			continue
+		}
+		if !containsString(rc.ExtKeyUsage, signExtKeyOCSPSigning) || rc.UnhandledCritical {
+			continue
+		}
+		if rc.KeyUsage != 0 && rc.KeyUsage&x509.KeyUsageDigitalSignature == 0 {
+			continue
+		}
+		if ok, err := verifyCertificateSignature(rc, issuer); err != nil || !ok {
+			continue
+		}
+		if ok, err := verifyIssuedSignature(resp.SignatureAlg, resp.TBS, resp.Signature, responder); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// signatureSubjectPublicKey 获取证书公钥位串
+// 入参: c 证书
+// 返回: []byte 公钥数据, error 错误信息
+func signatureSubjectPublicKey(c signatureCertificate) ([]byte, error) {
+	var spki struct {
+		Algorithm        asn1.RawValue
+		SubjectPublicKey asn1.BitString
+	}
+	rest, err := asn1.Unmarshal(c.PublicKey.FullBytes, &spki)
+	if err != nil || len(rest) != 0 {
+		return nil, fmt.Errorf("invalid subject public key info")
+	}
+	return spki.SubjectPublicKey.Bytes, nil
+}
+
+// parseSignatureOCSPResponse 解析OCSP响应
+// 入参: data DER编码OCSP响应
+// 返回: *signatureOCSPResponse OCSP基本响应, error 错误信息
+func parseSignatureOCSPResponse(data []byte) (*signatureOCSPResponse, error) {
+	var root asn1.RawValue
+	rest, err := asn1.Unmarshal(data, &root)
+	if err != nil || len(rest) != 0 {
+		return nil, fmt.Errorf("invalid ocsp response")
+	}
+	items, ok := asn1Children(root.Bytes)
+	if !ok || len(items) < 2 || items[0].Tag != asn1.TagEnum {
+		return nil, fmt.Errorf("invalid ocsp response")
+	}
+	if len(items[0].Bytes) != 1 || items[0].Bytes[0] != 0 {
+		return nil, fmt.Errorf("ocsp response status not successful")
+	}
+	respBytes, err := asn1Explicit(items[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid ocsp response bytes")
+	}
+	fields, ok := asn1Children(respBytes.Bytes)
+	if !ok || len(fields) != 2 {
+		return nil, fmt.Errorf("invalid ocsp response bytes")
+	}
+	if oid, err := asn1OIDString(fields[0]); err != nil || oid != signOCSPBasicResponse {
+		return nil, fmt.Errorf("unsupported ocsp response type")
+	}
+	basic, err := asn1OctetString(fields[1])
+	if err != nil {
+		return nil, err
+	}
+	return parseSignatureOCSPBasic(basic)
+}
+
+// parseSignatureOCSPBasic 解析OCSP基本响应
+// 入参: data DER编码BasicOCSPResponse
+// 返回: *signatureOCSPResponse OCSP基本响应, error 错误信息
+func parseSignatureOCSPBasic(data []byte) (*signatureOCSPResponse, error) {
+	var root asn1.RawValue
+	rest, err := asn1.Unmarshal(data, &root)
+	if err != nil || len(rest) != 0 {
+		return nil, fmt.Errorf("invalid basic ocsp response")
+	}
+	items, ok := asn1Children(root.Bytes)
+	if !ok || len(items) < 3 {
+		return nil, fmt.Errorf("invalid basic ocsp response")
+	}
+	alg, err := parseGBTAlgorithm(items[1])
+	if err != nil {
+		return nil, err
+	}
+	signature, err := asn1BitStringBytes(items[2])
+	if err != nil {
+		return nil, err
+	}
+	resp := &signatureOCSPResponse{
+		TBS:          append([]byte(nil), items[0].FullBytes...),
+		SignatureAlg: alg,
+		Signature:    signature,
+	}
+	if len(items) > 3 {
+		certs, err := asn1Explicit(items[3])
+		if err != nil {
+			return nil, fmt.Errorf("invalid ocsp certificates")
+		}
+		list, ok := asn1Children(certs.Bytes)
+		if !ok {
+			return nil, fmt.Errorf("invalid ocsp certificates")
+		}
+		for _, cert := range list {
+			resp.Certs = append(resp.Certs, append([]byte(nil), cert.FullBytes...))
+		}
+	}
+	tbs, ok := asn1Children(items[0].Bytes)
+	if !ok {
+		return nil, fmt.Errorf("invalid ocsp response data")
+	}
+	idx := 0
+	if len(tbs) > 0 && tbs[0].Class == asn1.ClassContextSpecific && tbs[0].Tag == 0 {
+		idx++
+	}
+	if len(tbs) < idx+3 {
+		return nil, fmt.Errorf("invalid ocsp response data")
+	}
+	responder := tbs[idx]
+	switch {
+	case responder.Class == asn1.ClassContextSpecific && responder.Tag == 1:
+		resp.ResponderDN = append([]byte(nil), responder.Bytes...)
+	case responder.Class == asn1.ClassContextSpecific && responder.Tag == 2:
+		var key asn1.RawValue
+		if _, err := asn1.Unmarshal(responder.Bytes, &key); err != nil {
+			return nil, fmt.Errorf("invalid ocsp responder id")
+		}
+		resp.ResponderKey = append([]byte(nil), key.Bytes...)
+	default:
+		return nil, fmt.Errorf("invalid ocsp responder id")
+	}
+	singles, ok := asn1Children(tbs[idx+2].Bytes)
+	if !ok {
+		return nil, fmt.Errorf("invalid ocsp responses")
+	}
+	for _, raw := range singles {
+		single, err := parseSignatureOCSPSingle(raw)
+		if err != nil {
+			return nil, err
+		}
+		resp.Responses = append(resp.Responses, single)
+	}
+	return resp, nil
+}
+
+// parseSignatureOCSPSingle 解析OCSP单证书响应
+// 入参: raw ASN.1原始值
+// 返回: signatureOCSPSingle 单证书响应, error 错误信息
+func parseSignatureOCSPSingle(raw asn1.RawValue) (signatureOCSPSingle, error) {
+	var out signatureOCSPSingle
+	items, ok := asn1Children(raw.Bytes)
+	if !ok || len(items) < 3 {
+		return out, fmt.Errorf("invalid ocsp single response")
+	}
+	certID, ok := asn1Children(items[0].Bytes)
+	if !ok || len(certID) != 4 {
+		return out, fmt.Errorf("invalid ocsp cert id")
+	}
+	var err error
+	if out.HashAlg, err = parseGBTAlgorithm(certID[0]); err != nil {
+		return out, err
+	}
+	if out.IssuerNameHash, err = asn1OctetString(certID[1]); err != nil {
+		return out, err
+	}
+	if out.IssuerKeyHash, err = asn1OctetString(certID[2]); err != nil {
+		return out, err
+	}
+	if out.Serial, err = asn1IntegerBig(certID[3]); err != nil {
+		return out, err
+	}
+	status := items[1]
+	if status.Class != asn1.ClassContextSpecific || status.Tag > 2 {
+		return out, fmt.Errorf("invalid ocsp cert status")
+	}
+	out.Status = status.Tag
+	if status.Tag == 1 {
+		info, ok := asn1Children(status.Bytes)
+		if !ok || len(info) == 0 {
+			return out, fmt.Errorf("invalid ocsp revoked info")
+		}
+		if out.RevocationTime, err = asn1Time(info[0]); err != nil {
+			return out, err
+		}
+	}
+	if out.ThisUpdate, err = asn1Time(items[2]); err != nil {
+		return out, err
+	}
+	if len(items) > 3 && items[3].Class == asn1.ClassContextSpecific && items[3].Tag == 0 {
+		next, err := asn1Explicit(items[3])
+		if err != nil {
+			return out, err
+		}
+		if out.NextUpdate, err = asn1Time(next); err != nil {
+			return out, err
+		}
+	}
+	return out, nil
+}
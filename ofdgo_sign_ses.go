@@ -85,6 +85,7 @@ type sesVerifyResult struct {
 	SealRaw       []byte
 	Certs         [][]byte
 	SealType      string
+	SealPicture   []byte
 	SealInfo      SignatureSealInfo
 	SignatureTime time.Time
 }
@@ -238,6 +239,7 @@ func verifySESSignature(data, signedData []byte, options *signatureVerifyOptions
 	result.Certs = append(result.Certs, sig.Seal.CertList.Certs...)
 	result.Certs = append(result.Certs, options.SignCerts...)
 	result.SealType = sig.Seal.PicType
+	result.SealPicture = sig.Seal.PicData
 	result.SealInfo = sig.Seal.Info
 	result.SignatureTime = sig.Time
 	result.DataHashOK = bytes.Equal(sig.DataHash, signSM3(signedData))
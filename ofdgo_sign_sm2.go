@@ -15,6 +15,7 @@
 package ofdgo
 
 import (
+	"crypto/subtle"
 	"encoding/asn1"
 	"encoding/binary"
 	"math/big"
@@ -37,25 +38,33 @@ type sm2Curve struct {
 	B  *big.Int
 	Gx *big.Int
 	Gy *big.Int
+	fp *sm2Field
+	fn *sm2Field
+	b  sm2Element
 }
 
-// sm2Point SM2雅可比坐标点
+// sm2Point SM2射影坐标点
+// 无穷远点为(0:1:0), 坐标以蒙哥马利形式存放
 type sm2Point struct {
-	X *big.Int
-	Y *big.Int
-	Z *big.Int
+	X sm2Element
+	Y sm2Element
+	Z sm2Element
 }
 
 // newSM2P256 创建SM2椭圆曲线
 // 返回: *sm2Curve SM2椭圆曲线
 func newSM2P256() *sm2Curve {
-	return &sm2Curve{
+	c := &sm2Curve{
 		P:  sm2Big("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFFF00000000FFFFFFFFFFFFFFFF"),
 		N:  sm2Big("FFFFFFFEFFFFFFFFFFFFFFFFFFFFFFFF7203DF6B21C6052B53BBF40939D54123"),
 		B:  sm2Big("28E9FA9E9D9F5E344D5A9E4BCF6509A7F39789F515AB8F92DDBCBD414D940E93"),
 		Gx: sm2Big("32C4AE2C1F1981195F9904466A39C9948FE30BBFF2660BE1715A4589334C74C7"),
 		Gy: sm2Big("BC3736A2F4F6779C59BDCEE36B692153D0A9877CC62A474002DF32E52139F0A0"),
 	}
+	c.fp = newSM2Field(c.P)
+	c.fn = newSM2Field(c.N)
+	c.b = c.fp.fromBig(c.B)
+	return c
 }
 
 // sm2Big 解析SM2大整数常量
@@ -174,101 +183,142 @@ func (c *sm2Curve) isOnCurve(x, y *big.Int) bool {
 // 入参: x 公钥X坐标, y 公钥Y坐标, s 标量S, t 标量T
 // 返回: *big.Int 结果X坐标, bool 是否计算成功
 func (c *sm2Curve) combinedMult(x, y, s, t *big.Int) (*big.Int, bool) {
-	base := c.scalarMult(c.Gx, c.Gy, s.Bytes())
-	public := c.scalarMult(x, y, t.Bytes())
+	base := c.scalarMult(c.Gx, c.Gy, sm2Fixed(s))
+	public := c.scalarMult(x, y, sm2Fixed(t))
 	result := c.add(base, public)
 	affineX, _, ok := c.affine(result)
 	return affineX, ok
 }
 
 // scalarMult 计算椭圆曲线标量乘法
-// 入参: x 点X坐标, y 点Y坐标, scalar 标量
-// 返回: sm2Point 雅可比坐标点
+// 采用4位固定窗口和完备加法公式, 运算次数和内存访问不依赖标量取值, 可用于私钥和随机数
+// 入参: x 点X坐标, y 点Y坐标, 须位于曲线上, scalar 32字节大端序标量
+// 返回: sm2Point 射影坐标点
 func (c *sm2Curve) scalarMult(x, y *big.Int, scalar []byte) sm2Point {
+	var table [16]sm2Point
+	table[0] = c.infinity()
+	table[1] = sm2Point{X: c.fp.fromBig(x), Y: c.fp.fromBig(y), Z: c.fp.one}
+	for i := 2; i < len(table); i++ {
+		table[i] = c.add(table[i-1], table[1])
+	}
 	result := c.infinity()
-	point := sm2Point{X: new(big.Int).Set(x), Y: new(big.Int).Set(y), Z: big.NewInt(1)}
 	for _, value := range scalar {
-		for bit := 7; bit >= 0; bit-- {
-			result = c.double(result)
-			if value&(1<<uint(bit)) != 0 {
-				result = c.add(result, point)
+		for _, window := range [2]byte{value >> 4, value & 15} {
+			for i := 0; i < 4; i++ {
+				result = c.double(result)
 			}
+			result = c.add(result, sm2LookupPoint(&table, window))
 		}
 	}
 	return result
 }
 
+// sm2LookupPoint 按窗口值查找预计算点
+// 遍历全部表项并按掩码选择, 不按窗口值寻址
+// 入参: table 预计算点表, window 窗口值
+// 返回: sm2Point 预计算点
+func sm2LookupPoint(table *[16]sm2Point, window byte) sm2Point {
+	var out sm2Point
+	for i := range table {
+		eq := uint64(subtle.ConstantTimeByteEq(byte(i), window))
+		out.X = sm2Select(eq, table[i].X, out.X)
+		out.Y = sm2Select(eq, table[i].Y, out.Y)
+		out.Z = sm2Select(eq, table[i].Z, out.Z)
+	}
+	return out
+}
+
 // add 计算椭圆曲线点加法
+// 采用a=-3的完备射影加法公式, 对无穷远点和相同点同样成立
 // 入参: p 点P, q 点Q
 // 返回: sm2Point 结果点
 func (c *sm2Curve) add(p, q sm2Point) sm2Point {
-	if p.Z.Sign() == 0 {
-		return q
-	}
-	if q.Z.Sign() == 0 {
-		return p
-	}
-	z1z1 := c.fieldSquare(p.Z)
-	z2z2 := c.fieldSquare(q.Z)
-	u1 := c.fieldMul(p.X, z2z2)
-	u2 := c.fieldMul(q.X, z1z1)
-	s1 := c.fieldMul(p.Y, c.fieldMul(q.Z, z2z2))
-	s2 := c.fieldMul(q.Y, c.fieldMul(p.Z, z1z1))
-	if u1.Cmp(u2) == 0 {
-		if s1.Cmp(s2) != 0 {
-			return c.infinity()
-		}
-		return c.double(p)
-	}
-	h := c.fieldSub(u2, u1)
-	i := c.fieldSquare(c.fieldScale(h, 2))
-	j := c.fieldMul(h, i)
-	r := c.fieldScale(c.fieldSub(s2, s1), 2)
-	v := c.fieldMul(u1, i)
-	x := c.fieldSub(c.fieldSub(c.fieldSquare(r), j), c.fieldScale(v, 2))
-	y := c.fieldSub(c.fieldMul(r, c.fieldSub(v, x)), c.fieldScale(c.fieldMul(s1, j), 2))
-	z := c.fieldMul(c.fieldSub(c.fieldSub(c.fieldSquare(c.fieldAdd(p.Z, q.Z)), z1z1), z2z2), h)
-	return sm2Point{X: x, Y: y, Z: z}
+	f := c.fp
+	t0 := f.mul(p.X, q.X)
+	t1 := f.mul(p.Y, q.Y)
+	t2 := f.mul(p.Z, q.Z)
+	t3 := f.mul(f.add(p.X, p.Y), f.add(q.X, q.Y))
+	t3 = f.sub(t3, f.add(t0, t1))
+	t4 := f.mul(f.add(p.Y, p.Z), f.add(q.Y, q.Z))
+	t4 = f.sub(t4, f.add(t1, t2))
+	x3 := f.mul(f.add(p.X, p.Z), f.add(q.X, q.Z))
+	y3 := f.sub(x3, f.add(t0, t2))
+	z3 := f.mul(c.b, t2)
+	x3 = f.sub(y3, z3)
+	z3 = f.add(x3, x3)
+	x3 = f.add(x3, z3)
+	z3 = f.sub(t1, x3)
+	x3 = f.add(t1, x3)
+	y3 = f.mul(c.b, y3)
+	t1 = f.add(t2, t2)
+	t2 = f.add(t1, t2)
+	y3 = f.sub(f.sub(y3, t2), t0)
+	t1 = f.add(y3, y3)
+	y3 = f.add(t1, y3)
+	t1 = f.add(t0, t0)
+	t0 = f.sub(f.add(t1, t0), t2)
+	t1 = f.mul(t4, y3)
+	t2 = f.mul(t0, y3)
+	y3 = f.add(f.mul(x3, z3), t2)
+	x3 = f.sub(f.mul(t3, x3), t1)
+	z3 = f.add(f.mul(t4, z3), f.mul(t3, t0))
+	return sm2Point{X: x3, Y: y3, Z: z3}
 }
 
 // double 计算椭圆曲线点倍加
+// 采用a=-3的完备射影倍加公式
 // 入参: p 点P
 // 返回: sm2Point 结果点
 func (c *sm2Curve) double(p sm2Point) sm2Point {
-	if p.Z.Sign() == 0 || p.Y.Sign() == 0 {
-		return c.infinity()
-	}
-	delta := c.fieldSquare(p.Z)
-	gamma := c.fieldSquare(p.Y)
-	beta := c.fieldMul(p.X, gamma)
-	alpha := c.fieldScale(c.fieldMul(c.fieldSub(p.X, delta), c.fieldAdd(p.X, delta)), 3)
-	x := c.fieldSub(c.fieldSquare(alpha), c.fieldScale(beta, 8))
-	z := c.fieldSub(c.fieldSub(c.fieldSquare(c.fieldAdd(p.Y, p.Z)), gamma), delta)
-	y := c.fieldSub(c.fieldMul(alpha, c.fieldSub(c.fieldScale(beta, 4), x)), c.fieldScale(c.fieldSquare(gamma), 8))
-	return sm2Point{X: x, Y: y, Z: z}
+	f := c.fp
+	t0 := f.square(p.X)
+	t1 := f.square(p.Y)
+	t2 := f.square(p.Z)
+	t3 := f.mul(p.X, p.Y)
+	t3 = f.add(t3, t3)
+	z3 := f.mul(p.X, p.Z)
+	z3 = f.add(z3, z3)
+	y3 := f.sub(f.mul(c.b, t2), z3)
+	x3 := f.add(y3, y3)
+	y3 = f.add(x3, y3)
+	x3 = f.sub(t1, y3)
+	y3 = f.add(t1, y3)
+	y3 = f.mul(x3, y3)
+	x3 = f.mul(x3, t3)
+	t3 = f.add(t2, t2)
+	t2 = f.add(t2, t3)
+	z3 = f.mul(c.b, z3)
+	z3 = f.sub(f.sub(z3, t2), t0)
+	t3 = f.add(z3, z3)
+	z3 = f.add(z3, t3)
+	t3 = f.add(t0, t0)
+	t0 = f.sub(f.add(t3, t0), t2)
+	y3 = f.add(y3, f.mul(t0, z3))
+	t0 = f.mul(p.Y, p.Z)
+	t0 = f.add(t0, t0)
+	z3 = f.mul(t0, z3)
+	x3 = f.sub(x3, z3)
+	z3 = f.mul(t0, t1)
+	z3 = f.add(z3, z3)
+	z3 = f.add(z3, z3)
+	return sm2Point{X: x3, Y: y3, Z: z3}
 }
 
-// affine 将雅可比坐标转换为仿射坐标
-// 入参: p 雅可比坐标点
-// 返回: *big.Int X坐标, *big.Int Y坐标, bool 是否转换成功
+// affine 将射影坐标转换为仿射坐标
+// 入参: p 射影坐标点
+// 返回: *big.Int X坐标, *big.Int Y坐标, bool 是否转换成功, 无穷远点转换失败
 func (c *sm2Curve) affine(p sm2Point) (*big.Int, *big.Int, bool) {
-	if p.Z.Sign() == 0 {
-		return nil, nil, false
-	}
-	z := new(big.Int).ModInverse(p.Z, c.P)
-	if z == nil {
+	if sm2IsZero(p.Z) == 1 {
 		return nil, nil, false
 	}
-	z2 := c.fieldSquare(z)
-	x := c.fieldMul(p.X, z2)
-	y := c.fieldMul(p.Y, c.fieldMul(z2, z))
-	return x, y, true
+	z := c.fp.invert(p.Z)
+	return c.fp.toBig(c.fp.mul(p.X, z)), c.fp.toBig(c.fp.mul(p.Y, z)), true
 }
 
 // infinity 获取无穷远点
 // 返回: sm2Point 无穷远点
 func (c *sm2Curve) infinity() sm2Point {
-	return sm2Point{X: new(big.Int), Y: new(big.Int), Z: new(big.Int)}
+	return sm2Point{Y: c.fp.one}
 }
 
 // fieldAdd 计算有限域加法
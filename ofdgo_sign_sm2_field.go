@@ -0,0 +1,212 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+)
+
+// sm2Element 256位有限域元素
+// 按小端序存放4个64位字, 以蒙哥马利形式参与运算
+type sm2Element [4]uint64
+
+// sm2Field 256位素数域
+// 加减乘和求逆均不依赖操作数取值分支, 用于私钥和随机数参与的运算
+type sm2Field struct {
+	m   sm2Element
+	rr  sm2Element
+	one sm2Element
+	inv uint64
+	exp []byte
+}
+
+// newSM2Field 创建256位素数域
+// 入参: m 素数模数, 最高位须为1
+// 返回: *sm2Field 素数域
+func newSM2Field(m *big.Int) *sm2Field {
+	f := &sm2Field{m: sm2Limbs(sm2Fixed(m))}
+	x := f.m[0]
+	for i := 0; i < 5; i++ {
+		x *= 2 - f.m[0]*x
+	}
+	f.inv = -x
+	r := new(big.Int).Lsh(big.NewInt(1), 256)
+	f.one = sm2Limbs(sm2Fixed(new(big.Int).Mod(r, m)))
+	f.rr = sm2Limbs(sm2Fixed(new(big.Int).Mod(new(big.Int).Mul(r, r), m)))
+	f.exp = new(big.Int).Sub(m, big.NewInt(2)).Bytes()
+	return f
+}
+
+// sm2Limbs 将32字节大端序整数转换为小端序字
+// 入参: b 32字节大端序整数
+// 返回: sm2Element 小端序字
+func sm2Limbs(b []byte) sm2Element {
+	var out sm2Element
+	for i := range out {
+		out[i] = binary.BigEndian.Uint64(b[24-8*i:])
+	}
+	return out
+}
+
+// fromBig 将大整数转换为蒙哥马利形式
+// 入参: x 非负大整数, 不小于模数时先取模
+// 返回: sm2Element 域元素
+func (f *sm2Field) fromBig(x *big.Int) sm2Element {
+	return f.fromBytes(sm2Fixed(x))
+}
+
+// fromBytes 将32字节大端序整数转换为蒙哥马利形式
+// 入参: b 32字节大端序整数
+// 返回: sm2Element 域元素
+func (f *sm2Field) fromBytes(b []byte) sm2Element {
+	x := sm2Limbs(b)
+	var d sm2Element
+	var borrow uint64
+	for i := range d {
+		d[i], borrow = bits.Sub64(x[i], f.m[i], borrow)
+	}
+	x = sm2Select(borrow, x, d)
+	return f.mul(x, f.rr)
+}
+
+// toBig 将蒙哥马利形式转换为大整数
+// 入参: a 域元素
+// 返回: *big.Int 大整数
+func (f *sm2Field) toBig(a sm2Element) *big.Int {
+	x := f.mul(a, sm2Element{1})
+	var b [32]byte
+	for i := range x {
+		binary.BigEndian.PutUint64(b[24-8*i:], x[i])
+	}
+	return new(big.Int).SetBytes(b[:])
+}
+
+// mul 计算蒙哥马利乘法
+// 入参: a 左操作数, b 右操作数
+// 返回: sm2Element a*b/R mod m
+func (f *sm2Field) mul(a, b sm2Element) sm2Element {
+	var t [6]uint64
+	for i := 0; i < 4; i++ {
+		var c, hi, lo, cc uint64
+		for j := 0; j < 4; j++ {
+			hi, lo = bits.Mul64(a[j], b[i])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j], c = lo, hi
+		}
+		t[4], cc = bits.Add64(t[4], c, 0)
+		t[5] = cc
+		q := t[0] * f.inv
+		hi, lo = bits.Mul64(q, f.m[0])
+		_, cc = bits.Add64(lo, t[0], 0)
+		c = hi + cc
+		for j := 1; j < 4; j++ {
+			hi, lo = bits.Mul64(q, f.m[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j-1], c = lo, hi
+		}
+		t[3], cc = bits.Add64(t[4], c, 0)
+		t[4] = t[5] + cc
+	}
+	x := sm2Element{t[0], t[1], t[2], t[3]}
+	var d sm2Element
+	var borrow uint64
+	for i := range d {
+		d[i], borrow = bits.Sub64(x[i], f.m[i], borrow)
+	}
+	return sm2Select(borrow&^t[4], x, d)
+}
+
+// square 计算蒙哥马利平方
+// 入参: a 操作数
+// 返回: sm2Element a*a/R mod m
+func (f *sm2Field) square(a sm2Element) sm2Element {
+	return f.mul(a, a)
+}
+
+// add 计算模加法
+// 入参: a 左操作数, b 右操作数
+// 返回: sm2Element a+b mod m
+func (f *sm2Field) add(a, b sm2Element) sm2Element {
+	var s, d sm2Element
+	var carry, borrow uint64
+	for i := range s {
+		s[i], carry = bits.Add64(a[i], b[i], carry)
+	}
+	for i := range d {
+		d[i], borrow = bits.Sub64(s[i], f.m[i], borrow)
+	}
+	return sm2Select(borrow&^carry, s, d)
+}
+
+// sub 计算模减法
+// 入参: a 左操作数, b 右操作数
+// 返回: sm2Element a-b mod m
+func (f *sm2Field) sub(a, b sm2Element) sm2Element {
+	var d sm2Element
+	var borrow, carry uint64
+	for i := range d {
+		d[i], borrow = bits.Sub64(a[i], b[i], borrow)
+	}
+	mask := -borrow
+	for i := range d {
+		d[i], carry = bits.Add64(d[i], f.m[i]&mask, carry)
+	}
+	return d
+}
+
+// invert 计算模逆
+// 按费马小定理以公开指数m-2求幂, 零的逆为零
+// 入参: a 操作数
+// 返回: sm2Element a的模逆
+func (f *sm2Field) invert(a sm2Element) sm2Element {
+	out := f.one
+	for _, value := range f.exp {
+		for bit := 7; bit >= 0; bit-- {
+			out = f.square(out)
+			if value&(1<<uint(bit)) != 0 {
+				out = f.mul(out, a)
+			}
+		}
+	}
+	return out
+}
+
+// sm2Select 按条件选择域元素
+// 入参: cond 条件, 取值为0或1, a 条件为1时的结果, b 条件为0时的结果
+// 返回: sm2Element 选择结果
+func sm2Select(cond uint64, a, b sm2Element) sm2Element {
+	mask := -cond
+	var out sm2Element
+	for i := range out {
+		out[i] = b[i] ^ (mask & (a[i] ^ b[i]))
+	}
+	return out
+}
+
+// sm2IsZero 判断域元素是否为零
+// 入参: a 域元素
+// 返回: uint64 为零时返回1, 否则返回0
+func sm2IsZero(a sm2Element) uint64 {
+	v := a[0] | a[1] | a[2] | a[3]
+	return 1 ^ ((v | -v) >> 63)
+}
@@ -0,0 +1,505 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const signContentPKCS7SignedData = "1.2.840.113549.1.7.2"
+
+// trustStoreOptions 信任证书库选项
+type trustStoreOptions struct {
+	SystemPool        bool
+	IntermediatesOnly bool
+}
+
+// TrustStoreOption 信任证书库选项函数
+type TrustStoreOption func(*trustStoreOptions)
+
+// WithTrustStoreSystemPool 合并系统根证书池
+// 系统根证书池仅用于RSA和ECDSA证书, SM2证书需由证书库自身提供根证书
+// 返回: TrustStoreOption 信任证书库选项
+func WithTrustStoreSystemPool() TrustStoreOption {
+	return func(o *trustStoreOptions) {
+		o.SystemPool = true
+	}
+}
+
+// WithTrustStoreIntermediatesOnly 仅作为中间证书池
+// 证书库中的证书仅用于构建证书链, 不作为信任锚
+// 返回: TrustStoreOption 信任证书库选项
+func WithTrustStoreIntermediatesOnly() TrustStoreOption {
+	return func(o *trustStoreOptions) {
+		o.IntermediatesOnly = true
+	}
+}
+
+// TrustStore 签名验证信任证书库
+// 证书按主体密钥标识和主体名称建立索引, 可在多个验证之间共享并发使用
+type TrustStore struct {
+	mu                sync.RWMutex
+	index             *signatureCertIndex
+	intermediatesOnly bool
+}
+
+// NewTrustStore 创建信任证书库
+// 入参: opts 信任证书库选项
+// 返回: *TrustStore 信任证书库, error 错误信息
+func NewTrustStore(opts ...TrustStoreOption) (*TrustStore, error) {
+	options := trustStoreOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	store := &TrustStore{
+		index:             newSignatureCertIndex(nil),
+		intermediatesOnly: options.IntermediatesOnly,
+	}
+	if options.SystemPool {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			return nil, err
+		}
+		store.index.system = pool
+		store.index.x509Pool()
+	}
+	return store, nil
+}
+
+// LoadTrustStore 从目录加载信任证书库
+// 入参: dir 证书目录, opts 信任证书库选项
+// 返回: *TrustStore 信任证书库, error 错误信息
+func LoadTrustStore(dir string, opts ...TrustStoreOption) (*TrustStore, error) {
+	store, err := NewTrustStore(opts...)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.LoadDir(dir); err != nil {
+		return nil, err
+	}
+	return store, nil
+}
+
+// LoadDir 加载目录中的证书文件
+// 支持扩展名为pem、crt、cer、der、p7b和p7c的PEM、DER及PKCS#7证书包, 不递归子目录
+// 入参: dir 证书目录
+// 返回: error 错误信息
+func (s *TrustStore) LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(entry.Name())) {
+		case ".pem", ".crt", ".cer", ".der", ".p7b", ".p7c":
+		default:
+			continue
+		}
+		name := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if s.intermediatesOnly {
+			err = s.AddIntermediates(data)
+		} else {
+			err = s.AddRoots(data)
+		}
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// AddRoots 添加信任根证书
+// 入参: data PEM、DER或PKCS#7编码证书包
+// 返回: error 错误信息
+func (s *TrustStore) AddRoots(data ...[]byte) error {
+	if s.intermediatesOnly {
+		return fmt.Errorf("trust store is intermediates only")
+	}
+	return s.add(data, true)
+}
+
+// AddIntermediates 添加中间证书
+// 入参: data PEM、DER或PKCS#7编码证书包
+// 返回: error 错误信息
+func (s *TrustStore) AddIntermediates(data ...[]byte) error {
+	return s.add(data, false)
+}
+
+// add 添加证书包
+// 入参: data 证书包列表, anchor 是否为信任锚
+// 返回: error 错误信息
+func (s *TrustStore) add(data [][]byte, anchor bool) error {
+	var certs [][]byte
+	for _, item := range data {
+		parsed, err := parseTrustStoreCerts(item)
+		if err != nil {
+			return err
+		}
+		certs = append(certs, parsed...)
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	index := s.index.clone()
+	for _, cert := range certs {
+		if err := index.add(cert, anchor); err != nil {
+			return err
+		}
+	}
+	if index.system != nil {
+		index.x509Pool()
+	}
+	s.index = index
+	return nil
+}
+
+// Roots 获取信任根证书
+// 返回: [][]byte DER编码证书列表
+func (s *TrustStore) Roots() [][]byte {
+	return s.certs(true)
+}
+
+// Intermediates 获取中间证书
+// 返回: [][]byte DER编码证书列表
+func (s *TrustStore) Intermediates() [][]byte {
+	return s.certs(false)
+}
+
+// certs 获取指定类型证书
+// 入参: anchor 是否为信任锚
+// 返回: [][]byte DER编码证书列表
+func (s *TrustStore) certs(anchor bool) [][]byte {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out [][]byte
+	for _, cert := range s.index.list {
+		if s.index.anchors[string(cert.Raw)] == anchor {
+			out = append(out, cert.Raw)
+		}
+	}
+	return out
+}
+
+// WithSignatureTrustStore 设置签名验证信任证书库
+// 入参: store 信任证书库
+// 返回: SignatureVerifyOption 签名验证选项
+func WithSignatureTrustStore(store *TrustStore) SignatureVerifyOption {
+	return func(o *signatureVerifyOptions) {
+		o.TrustStore = store
+	}
+}
+
+// signatureCertIndex 证书索引
+// 按原文、主体密钥标识和主体名称索引证书, 查找时依次检索本级和上级索引
+type signatureCertIndex struct {
+	parent    *signatureCertIndex
+	system    *x509.CertPool
+	pool      *x509.CertPool
+	list      []*signatureCertificate
+	certs     map[string]*signatureCertificate
+	anchors   map[string]bool
+	bySKI     map[string][]*signatureCertificate
+	bySubject map[string][]*signatureCertificate
+}
+
+// newSignatureCertIndex 创建证书索引
+// 入参: parent 上级索引
+// 返回: *signatureCertIndex 证书索引
+func newSignatureCertIndex(parent *signatureCertIndex) *signatureCertIndex {
+	return &signatureCertIndex{
+		parent:    parent,
+		certs:     make(map[string]*signatureCertificate),
+		anchors:   make(map[string]bool),
+		bySKI:     make(map[string][]*signatureCertificate),
+		bySubject: make(map[string][]*signatureCertificate),
+	}
+}
+
+// clone 复制证书索引
+// 信任证书库添加证书时在副本上修改后整体替换, 已取得旧索引的验证可在锁外继续读取
+// 返回: *signatureCertIndex 索引副本
+func (idx *signatureCertIndex) clone() *signatureCertIndex {
+	out := newSignatureCertIndex(idx.parent)
+	out.system = idx.system
+	out.pool = idx.pool
+	out.list = append([]*signatureCertificate(nil), idx.list...)
+	for key, c := range idx.certs {
+		out.certs[key] = c
+	}
+	for key, anchor := range idx.anchors {
+		out.anchors[key] = anchor
+	}
+	for key, list := range idx.bySKI {
+		out.bySKI[key] = append([]*signatureCertificate(nil), list...)
+	}
+	for key, list := range idx.bySubject {
+		out.bySubject[key] = append([]*signatureCertificate(nil), list...)
+	}
+	return out
+}
+
+// add 添加证书
+// 入参: raw DER编码证书, anchor 是否为信任锚
+// 返回: error 错误信息
+func (idx *signatureCertIndex) add(raw []byte, anchor bool) error {
+	if len(raw) == 0 {
+		return nil
+	}
+	key := string(raw)
+	if anchor {
+		idx.anchors[key] = true
+	}
+	if _, ok := idx.certs[key]; ok {
+		return nil
+	}
+	c, err := parseSignatureCertificate(raw)
+	if err != nil {
+		return err
+	}
+	idx.certs[key] = &c
+	idx.list = append(idx.list, &c)
+	idx.pool = nil
+	if len(c.SubjectKeyID) != 0 {
+		idx.bySKI[string(c.SubjectKeyID)] = append(idx.bySKI[string(c.SubjectKeyID)], &c)
+	}
+	idx.bySubject[string(c.Subject)] = append(idx.bySubject[string(c.Subject)], &c)
+	return nil
+}
+
+// addAll 添加证书列表, 忽略无法解析的证书
+// 入参: certs DER编码证书列表, anchor 是否为信任锚
+func (idx *signatureCertIndex) addAll(certs [][]byte, anchor bool) {
+	for _, cert := range certs {
+		_ = idx.add(cert, anchor)
+	}
+}
+
+// trusted 判断证书是否为信任锚
+// 入参: raw DER编码证书
+// 返回: bool 是否为信任锚
+func (idx *signatureCertIndex) trusted(raw []byte) bool {
+	for ; idx != nil; idx = idx.parent {
+		if idx.anchors[string(raw)] {
+			return true
+		}
+	}
+	return false
+}
+
+// hasTrust 判断索引是否包含信任来源
+// 返回: bool 是否包含信任锚或系统根证书池
+func (idx *signatureCertIndex) hasTrust() bool {
+	for ; idx != nil; idx = idx.parent {
+		if len(idx.anchors) != 0 || idx.system != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// parse 解析证书, 已索引的证书直接返回缓存结果
+// 入参: raw DER编码证书
+// 返回: *signatureCertificate 证书结构, error 错误信息
+func (idx *signatureCertIndex) parse(raw []byte) (*signatureCertificate, error) {
+	for i := idx; i != nil; i = i.parent {
+		if c, ok := i.certs[string(raw)]; ok {
+			return c, nil
+		}
+	}
+	c, err := parseSignatureCertificate(raw)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// issuers 查找候选颁发者证书
+// 优先按颁发者密钥标识匹配, 未命中时按颁发者名称匹配并排除密钥标识不一致的证书
+// 入参: c 证书
+// 返回: []*signatureCertificate 候选颁发者证书
+func (idx *signatureCertIndex) issuers(c *signatureCertificate) []*signatureCertificate {
+	var out []*signatureCertificate
+	seen := make(map[*signatureCertificate]bool)
+	if len(c.AuthorityKeyID) != 0 {
+		for i := idx; i != nil; i = i.parent {
+			for _, issuer := range i.bySKI[string(c.AuthorityKeyID)] {
+				if !seen[issuer] && bytes.Equal(issuer.Subject, c.Issuer) {
+					seen[issuer] = true
+					out = append(out, issuer)
+				}
+			}
+		}
+		if len(out) != 0 {
+			return out
+		}
+	}
+	for i := idx; i != nil; i = i.parent {
+		for _, issuer := range i.bySubject[string(c.Issuer)] {
+			if seen[issuer] {
+				continue
+			}
+			if len(c.AuthorityKeyID) != 0 && len(issuer.SubjectKeyID) != 0 && !bytes.Equal(c.AuthorityKeyID, issuer.SubjectKeyID) {
+				continue
+			}
+			seen[issuer] = true
+			out = append(out, issuer)
+		}
+	}
+	return out
+}
+
+// x509Pool 获取本级及上级索引全部证书组成的中间证书池
+// 证书池在索引变化后首次使用时构建并缓存, 信任证书库在替换索引前预先构建, 共享读取时不再修改
+// 返回: *x509.CertPool 中间证书池
+func (idx *signatureCertIndex) x509Pool() *x509.CertPool {
+	if idx.pool != nil {
+		return idx.pool
+	}
+	pool := x509.NewCertPool()
+	if idx.parent != nil {
+		pool = idx.parent.x509Pool().Clone()
+	}
+	for _, c := range idx.list {
+		if x509Cert, err := x509.ParseCertificate(c.Raw); err == nil {
+			pool.AddCert(x509Cert)
+		}
+	}
+	idx.pool = pool
+	return pool
+}
+
+// systemVerify 使用系统根证书池验证证书
+// 入参: cert DER编码证书, verifyTime 验证时间
+// 返回: bool 是否受信任
+func (idx *signatureCertIndex) systemVerify(cert []byte, verifyTime *time.Time) bool {
+	var roots *x509.CertPool
+	for i := idx; i != nil && roots == nil; i = i.parent {
+		roots = i.system
+	}
+	if roots == nil {
+		return false
+	}
+	leaf, err := x509.ParseCertificate(cert)
+	if err != nil {
+		return false
+	}
+	opts := x509.VerifyOptions{
+		Roots:         roots,
+		Intermediates: idx.x509Pool(),
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	if verifyTime != nil {
+		opts.CurrentTime = *verifyTime
+	}
+	_, err = leaf.Verify(opts)
+	return err == nil
+}
+
+// parseTrustStoreCerts 解析证书包
+// 入参: data PEM、DER或PKCS#7编码证书包
+// 返回: [][]byte DER编码证书列表, error 错误信息
+func parseTrustStoreCerts(data []byte) ([][]byte, error) {
+	var certs [][]byte
+	rest := bytes.TrimSpace(data)
+	hasPEM := false
+	for {
+		block, next := pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		hasPEM = true
+		rest = next
+		switch block.Type {
+		case "CERTIFICATE":
+			certs = append(certs, block.Bytes)
+		case "PKCS7":
+			bundle, err := parsePKCS7Certs(block.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			certs = append(certs, bundle...)
+		}
+	}
+	if !hasPEM {
+		if _, err := parseSignatureCertificate(data); err == nil {
+			return [][]byte{append([]byte(nil), data...)}, nil
+		}
+		bundle, err := parsePKCS7Certs(data)
+		if err != nil {
+			return nil, err
+		}
+		certs = bundle
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("certificate not found")
+	}
+	for _, cert := range certs {
+		if _, err := parseSignatureCertificate(cert); err != nil {
+			return nil, err
+		}
+	}
+	return certs, nil
+}
+
+// parsePKCS7Certs 解析PKCS#7证书包
+// 入参: data DER编码PKCS#7 SignedData
+// 返回: [][]byte DER编码证书列表, error 错误信息
+func parsePKCS7Certs(data []byte) ([][]byte, error) {
+	if der, err := berToDefinite(data); err == nil {
+		data = der
+	}
+	contentType, content, ok, err := parseGBTContentInfoBytes(data)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || (contentType != signContentPKCS7SignedData && contentType != signContentSignedData) {
+		return nil, fmt.Errorf("invalid pkcs7 content type")
+	}
+	items, ok := asn1Children(content.Bytes)
+	if !ok || len(items) < 3 {
+		return nil, fmt.Errorf("invalid pkcs7 signed data")
+	}
+	var certs [][]byte
+	for _, item := range items[3:] {
+		if item.Class != asn1.ClassContextSpecific || item.Tag != 0 {
+			continue
+		}
+		children, ok := asn1Children(item.Bytes)
+		if !ok {
+			return nil, fmt.Errorf("invalid pkcs7 certificates")
+		}
+		for _, child := range children {
+			if child.Class == asn1.ClassUniversal && child.Tag == asn1.TagSequence {
+				certs = append(certs, append([]byte(nil), child.FullBytes...))
+			}
+		}
+	}
+	return certs, nil
+}
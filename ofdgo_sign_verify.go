@@ -16,6 +16,7 @@ package ofdgo
 
 import (
 	"bytes"
+	"context"
 	"crypto"
 	"crypto/ecdsa"
 	"crypto/md5"
@@ -48,8 +49,11 @@ type SignatureVerifyReport struct {
 	Signer               string
 	SignCert             SignatureCertInfo
 	SealCert             SignatureCertInfo
+	SignCertChain        []SignatureCertInfo
+	SealCertChain        []SignatureCertInfo
 	SealInfo             SignatureSealInfo
 	SealType             string
+	SealPicture          []byte
 	SignatureMethod      string
 	SignatureDateTime    string
 	SignatureTime        time.Time
@@ -74,6 +78,12 @@ type SignatureVerifyReport struct {
 	CertTimeOK           bool
 	CertTrustChecked     bool
 	CertTrustOK          bool
+	RevocationChecked    bool
+	RevocationOK         bool
+	RevocationSource     string
+	Revocations          []SignatureRevocationStatus
+	Coverage             SignatureCoverage
+	CoverageChecked      bool
 	Valid                bool
 	Error                string
 }
@@ -126,9 +136,14 @@ type SignatureReferenceVerify struct {
 
 // signatureVerifyOptions 签名验证选项
 type signatureVerifyOptions struct {
-	SignCerts  [][]byte
-	TrustCerts [][]byte
-	VerifyTime *time.Time
+	SignCerts         [][]byte
+	TrustCerts        [][]byte
+	VerifyTime        *time.Time
+	CRLs              [][]byte
+	OCSPResponses     [][]byte
+	RevocationFetcher SignatureRevocationFetcher
+	TrustStore        *TrustStore
+	ctx               context.Context
 }
 
 var signatureMethodReplacer = strings.NewReplacer("-", "", "_", "", " ", "")
@@ -199,6 +214,7 @@ func VerifySignaturesBytes(data []byte, opts ...SignatureVerifyOption) ([]Signat
 }
 
 // VerifySignaturesStream 验证OFD顺序流签名
+// 顺序流将完整读入内存, 远程大文件可使用NewHTTPRangeReaderAt配合VerifySignaturesReader按需读取
 // 入参: r IO顺序读取器, opts 签名验证选项
 // 返回: []SignatureVerifyReport 签名验证报告, error 错误信息
 func VerifySignaturesStream(r io.Reader, opts ...SignatureVerifyOption) ([]SignatureVerifyReport, error) {
@@ -246,8 +262,15 @@ func (r *Reader) VerifySignatures(opts ...SignatureVerifyOption) ([]SignatureVer
 	}
 	reports := make([]SignatureVerifyReport, 0, len(signatures.List))
 	for _, sigRef := range signatures.List {
+		if err := contextErr(options.ctx); err != nil {
+			return nil, err
+		}
 		reports = append(reports, r.verifySignature(sigListPath, sigRef, &options))
 	}
+	if err := contextErr(options.ctx); err != nil {
+		return nil, err
+	}
+	applySignatureCoverage(reports, r.packageEntries(), cleanPackagePath(sigListPath))
 	return reports, nil
 }
 
@@ -265,6 +288,7 @@ func (r *Reader) verifySignature(sigListPath string, sigRef Signature, options *
 	if report.Type == "" {
 		report.Type = SignTypeSeal
 	}
+	report.Coverage.SignatureFiles = append(report.Coverage.SignatureFiles, sigPath)
 	sigData, err := r.readFileExact(sigPath)
 	if err != nil {
 		report.Error = err.Error()
@@ -282,7 +306,7 @@ func (r *Reader) verifySignature(sigListPath string, sigRef Signature, options *
 	if report.DigestMethod == "" {
 		report.DigestMethod = "MD5"
 	}
-	report.References = r.verifySignatureReferences(sigPath, sigFile.SignedInfo.References)
+	report.References = r.verifySignatureReferences(options.ctx, sigPath, sigFile.SignedInfo.References)
 	report.Stamps = append(report.Stamps, sigFile.SignedInfo.StampAnnot...)
 	report.StampPositions, err = r.SignatureStampPositions(report.Stamps)
 	if err != nil {
@@ -290,6 +314,10 @@ func (r *Reader) verifySignature(sigListPath string, sigRef Signature, options *
 	}
 	report.DigestOK = referencesOK(report.References)
 	signedValuePath := signatureRefPath(sigPath, sigFile.SignedValue)
+	report.Coverage.SignatureFiles = append(report.Coverage.SignatureFiles, signedValuePath)
+	if sigFile.SignedInfo.Seal.BaseLoc != "" {
+		report.Coverage.SignatureFiles = append(report.Coverage.SignatureFiles, signatureRefPath(sigPath, sigFile.SignedInfo.Seal.BaseLoc))
+	}
 	signedValue, err := r.readFileExact(signedValuePath)
 	if err != nil {
 		report.Error = err.Error()
@@ -324,6 +352,7 @@ func (r *Reader) verifySignature(sigListPath string, sigRef Signature, options *
 		report.SealCert = sesResult.SealCert
 		report.SealInfo = sesResult.SealInfo
 		report.SealType = sesResult.SealType
+		report.SealPicture = sesResult.SealPicture
 		report.SignatureTime = sesResult.SignatureTime
 		report.Signer = sesResult.SignCert.CommonName
 	}
@@ -351,11 +380,14 @@ func (r *Reader) verifySignature(sigListPath string, sigRef Signature, options *
 }
 
 // verifySignatureReferences 验证签名保护文件列表
-// 入参: sigPath 签名文件路径, refs 签名保护文件列表
+// 入参: ctx 上下文, sigPath 签名文件路径, refs 签名保护文件列表
 // 返回: []SignatureReferenceVerify 保护文件验证结果
-func (r *Reader) verifySignatureReferences(sigPath string, refs SignatureReferences) []SignatureReferenceVerify {
+func (r *Reader) verifySignatureReferences(ctx context.Context, sigPath string, refs SignatureReferences) []SignatureReferenceVerify {
 	results := make([]SignatureReferenceVerify, 0, len(refs.Reference))
 	for _, ref := range refs.Reference {
+		if contextErr(ctx) != nil {
+			break
+		}
 		results = append(results, r.verifySignatureReference(sigPath, refs.CheckMethod, ref))
 	}
 	return results
@@ -408,7 +440,7 @@ func parseSignatureFile(data []byte) (*SignatureFile, error) {
 func (r *Reader) readFileExact(name string) ([]byte, error) {
 	name = cleanPackagePath(name)
 	if f, ok := r.fileIndex[name]; ok {
-		return readZipFile(f)
+		return r.readEntry(name, f)
 	}
 	return nil, fmt.Errorf("file not found: %s", name)
 }
@@ -647,15 +679,24 @@ func (report *SignatureVerifyReport) applySignatureCertificatePolicy(options *si
 		report.CertTimeChecked = true
 		report.CertTimeOK = signatureCertsValidAt(certs, *options.VerifyTime)
 	}
-	if len(options.TrustCerts) != 0 {
+	var parent *signatureCertIndex
+	if store := options.TrustStore; store != nil {
+		store.mu.RLock()
+		parent = store.index
+		store.mu.RUnlock()
+	}
+	pool := newSignatureCertIndex(parent)
+	pool.addAll(options.TrustCerts, true)
+	pool.addAll(options.SignCerts, false)
+	pool.addAll(extraCerts, false)
+	report.SignCertChain = signatureCertChain(report.SignCert.Raw, pool)
+	report.SealCertChain = signatureCertChain(report.SealCert.Raw, pool)
+	report.applySignatureRevocationPolicy(options, certs, pool)
+	if pool.hasTrust() {
 		report.CertTrustChecked = true
 		report.CertTrustOK = true
-		pool := append([][]byte{}, options.TrustCerts...)
-		pool = append(pool, options.SignCerts...)
-		pool = append(pool, extraCerts...)
-		pool = compactSignatureCerts(pool)
 		for _, cert := range certs {
-			if !signatureCertTrustedBy(cert, pool, options.TrustCerts, options.VerifyTime) {
+			if !signatureCertTrustedBy(cert, pool, options.VerifyTime) {
 				report.CertTrustOK = false
 				break
 			}
@@ -704,6 +745,9 @@ func (report SignatureVerifyReport) certificatePolicyOK() bool {
 	if report.CertTrustChecked && !report.CertTrustOK {
 		return false
 	}
+	if report.RevocationChecked && !report.RevocationOK {
+		return false
+	}
 	return true
 }
 
@@ -723,8 +767,12 @@ func signatureCertsValidAt(certs [][]byte, t time.Time) bool {
 	return true
 }
 
-// signatureCertMaxChecks 最大证书签名验证次数
-const signatureCertMaxChecks = 100
+const (
+	// signatureCertMaxChecks 最大证书签名验证次数
+	signatureCertMaxChecks = 100
+	// signatureCertMaxDepth 最大证书链长度
+	signatureCertMaxDepth = 16
+)
 
 // signatureCertPathState 证书路径状态
 type signatureCertPathState struct {
@@ -733,27 +781,25 @@ type signatureCertPathState struct {
 }
 
 // signatureCertTrustedBy 判断证书是否可链到信任证书
-// 入参: cert 证书, pool 证书池, trusts 信任证书, verifyTime 中间证书验证时间
+// 证书索引无法构建可信路径时, 回退到系统根证书池验证
+// 入参: cert 证书, pool 证书索引, verifyTime 中间证书验证时间
 // 返回: bool 是否受信任
-func signatureCertTrustedBy(cert []byte, pool, trusts [][]byte, verifyTime *time.Time) bool {
+func signatureCertTrustedBy(cert []byte, pool *signatureCertIndex, verifyTime *time.Time) bool {
 	state := signatureCertPathState{Visited: make(map[string]bool)}
-	return signatureCertPathTrustedBy(cert, pool, trusts, verifyTime, &state, 0, true)
+	if signatureCertPathTrustedBy(cert, pool, verifyTime, &state, 0, true) {
+		return true
+	}
+	return pool.systemVerify(cert, verifyTime)
 }
 
 // signatureCertPathTrustedBy 验证证书路径
-// 入参: cert 证书, pool 证书池, trusts 信任证书, verifyTime 中间证书验证时间, state 路径状态, caBelow 下级非自颁发中间CA数量, target 是否目标证书
+// 入参: cert 证书, pool 证书索引, verifyTime 中间证书验证时间, state 路径状态, caBelow 下级非自颁发中间CA数量, target 是否目标证书
 // 返回: bool 是否受信任
-func signatureCertPathTrustedBy(cert []byte, pool, trusts [][]byte, verifyTime *time.Time, state *signatureCertPathState, caBelow int, target bool) bool {
+func signatureCertPathTrustedBy(cert []byte, pool *signatureCertIndex, verifyTime *time.Time, state *signatureCertPathState, caBelow int, target bool) bool {
 	if len(cert) == 0 {
 		return false
 	}
-	trusted := false
-	for _, trust := range trusts {
-		if bytes.Equal(cert, trust) {
-			trusted = true
-			break
-		}
-	}
+	trusted := pool.trusted(cert)
 	if trusted && !target {
 		return true
 	}
@@ -763,7 +809,7 @@ func signatureCertPathTrustedBy(cert []byte, pool, trusts [][]byte, verifyTime *
 	}
 	state.Visited[key] = true
 	defer delete(state.Visited, key)
-	c, err := parseSignatureCertificate(cert)
+	c, err := pool.parse(cert)
 	if err != nil {
 		return false
 	}
@@ -795,22 +841,18 @@ func signatureCertPathTrustedBy(cert []byte, pool, trusts [][]byte, verifyTime *
 	if !target && !bytes.Equal(c.Issuer, c.Subject) {
 		nextCABelow++
 	}
-	for _, issuerCert := range pool {
+	for _, issuer := range pool.issuers(c) {
 		if state.SignatureChecks >= signatureCertMaxChecks {
 			return false
 		}
-		if bytes.Equal(cert, issuerCert) {
-			continue
-		}
-		issuer, err := parseSignatureCertificate(issuerCert)
-		if err != nil || !bytes.Equal(c.Issuer, issuer.Subject) {
+		if bytes.Equal(cert, issuer.Raw) {
 			continue
 		}
 		state.SignatureChecks++
-		if ok, err := verifyCertificateSignature(c, issuerCert); err != nil || !ok {
+		if ok, err := verifyCertificateSignature(*c, issuer.Raw); err != nil || !ok {
 			continue
 		}
-		if signatureCertPathTrustedBy(issuerCert, pool, trusts, verifyTime, state, nextCABelow, false) {
+		if signatureCertPathTrustedBy(issuer.Raw, pool, verifyTime, state, nextCABelow, false) {
 			return true
 		}
 	}
@@ -821,14 +863,42 @@ func signatureCertPathTrustedBy(cert []byte, pool, trusts [][]byte, verifyTime *
 // 入参: cert 证书信息, issuerCert 颁发者证书
 // 返回: bool 是否验证通过, error 错误信息
 func verifyCertificateSignature(cert signatureCertificate, issuerCert []byte) (bool, error) {
-	if isSM2SignatureMethod(cert.SignatureAlg) {
+	return verifyIssuedSignature(cert.SignatureAlg, cert.TBS, cert.Signature, issuerCert)
+}
+
+// verifyIssuedSignature 验证颁发者签名
+// 入参: alg 签名算法, tbs 被签名数据, signature 签名值, issuerCert 颁发者证书
+// 返回: bool 是否验证通过, error 错误信息
+func verifyIssuedSignature(alg string, tbs, signature, issuerCert []byte) (bool, error) {
+	if isSM2SignatureMethod(alg) {
 		pub, err := parseSM2PublicKeyFromCert(issuerCert)
 		if err != nil {
 			return false, err
 		}
-		return sm2VerifySignature(pub, nil, cert.TBS, cert.Signature), nil
+		return sm2VerifySignature(pub, nil, tbs, signature), nil
+	}
+	return verifyPublicKeySignature(alg, "", issuerCert, tbs, signature)
+}
+
+// signatureCertChain 构建证书链
+// 入参: cert DER编码证书, pool 证书索引
+// 返回: []SignatureCertInfo 由证书至根证书排列的证书链
+func signatureCertChain(cert []byte, pool *signatureCertIndex) []SignatureCertInfo {
+	if len(cert) == 0 {
+		return nil
+	}
+	var chain []SignatureCertInfo
+	seen := make(map[string]bool)
+	for len(cert) != 0 && !seen[string(cert)] && len(chain) < signatureCertMaxDepth {
+		seen[string(cert)] = true
+		chain = append(chain, signatureCertInfo(cert))
+		c, err := pool.parse(cert)
+		if err != nil || bytes.Equal(c.Issuer, c.Subject) {
+			break
+		}
+		cert = findSignatureCertIssuer(*c, pool)
 	}
-	return verifyPublicKeySignature(cert.SignatureAlg, "", issuerCert, cert.TBS, cert.Signature)
+	return chain
 }
 
 // compactSignatureCerts 清理证书列表
@@ -896,6 +966,9 @@ func parseSignatureDateTime(value string) time.Time {
 const (
 	signatureExtensionKeyUsage         = "2.5.29.15"
 	signatureExtensionBasicConstraints = "2.5.29.19"
+	signatureExtensionExtKeyUsage      = "2.5.29.37"
+	signatureExtensionSubjectKeyID     = "2.5.29.14"
+	signatureExtensionAuthorityKeyID   = "2.5.29.35"
 )
 
 // signatureCertificateExtensions 签名证书扩展
@@ -903,6 +976,9 @@ type signatureCertificateExtensions struct {
 	IsCA              bool
 	MaxPathLen        *big.Int
 	KeyUsage          x509.KeyUsage
+	ExtKeyUsage       []string
+	SubjectKeyID      []byte
+	AuthorityKeyID    []byte
 	UnhandledCritical bool
 }
 
@@ -1031,6 +1107,31 @@ func parseSignatureCertificateExtensions(items []asn1.RawValue) (signatureCertif
 				return out, err
 			}
 			out.KeyUsage = keyUsage
+		case signatureExtensionExtKeyUsage:
+			var usages []asn1.ObjectIdentifier
+			rest, err := asn1.Unmarshal(extension.Value, &usages)
+			if err != nil || len(rest) != 0 {
+				return out, fmt.Errorf("invalid extended key usage")
+			}
+			for _, usage := range usages {
+				out.ExtKeyUsage = append(out.ExtKeyUsage, usage.String())
+			}
+			if extension.Critical {
+				out.UnhandledCritical = true
+			}
+		case signatureExtensionSubjectKeyID:
+			var keyID []byte
+			rest, err := asn1.Unmarshal(extension.Value, &keyID)
+			if err != nil || len(rest) != 0 {
+				return out, fmt.Errorf("invalid subject key identifier")
+			}
+			out.SubjectKeyID = keyID
+		case signatureExtensionAuthorityKeyID:
+			keyID, err := parseSignatureAuthorityKeyID(extension.Value)
+			if err != nil {
+				return out, err
+			}
+			out.AuthorityKeyID = keyID
 		default:
 			if extension.Critical {
 				out.UnhandledCritical = true
@@ -1076,6 +1177,27 @@ func parseSignatureBasicConstraints(data []byte) (bool, *big.Int, error) {
 	return isCA, maxPathLen, nil
 }
 
+// parseSignatureAuthorityKeyID 解析证书颁发者密钥标识
+// 入参: data 扩展DER数据
+// 返回: []byte 颁发者密钥标识, error 错误信息
+func parseSignatureAuthorityKeyID(data []byte) ([]byte, error) {
+	var raw asn1.RawValue
+	rest, err := asn1.Unmarshal(data, &raw)
+	if err != nil || len(rest) != 0 || raw.Tag != asn1.TagSequence || !raw.IsCompound {
+		return nil, fmt.Errorf("invalid authority key identifier")
+	}
+	items, ok := asn1Children(raw.Bytes)
+	if !ok {
+		return nil, fmt.Errorf("invalid authority key identifier")
+	}
+	for _, item := range items {
+		if item.Class == asn1.ClassContextSpecific && item.Tag == 0 && !item.IsCompound {
+			return append([]byte(nil), item.Bytes...), nil
+		}
+	}
+	return nil, nil
+}
+
 // parseSignatureKeyUsage 解析证书密钥用途
 // 入参: data 扩展DER数据
 // 返回: x509.KeyUsage 密钥用途, error 错误信息
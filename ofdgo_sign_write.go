@@ -0,0 +1,395 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	signAttrContentType   = "1.2.840.113549.1.9.3"
+	signDigestSHA256      = "2.16.840.1.101.3.4.2.1"
+	signMethodRSASHA256   = "1.2.840.113549.1.1.11"
+	signMethodECDSASHA256 = "1.2.840.10045.4.3.2"
+	signOFDNamespace      = "http://www.ofdspec.org/2016"
+)
+
+var signDocBodyEnd = regexp.MustCompile(`</([A-Za-z_][\w.-]*:)?DocBody\s*>`)
+
+// signatureSignOptions 签名选项
+type signatureSignOptions struct {
+	Time     time.Time
+	Provider SignatureProvider
+	Rand     io.Reader
+}
+
+// SignatureSignOption 签名选项函数
+type SignatureSignOption func(*signatureSignOptions)
+
+// WithSignatureSignTime 设置签名时间
+// 入参: t 签名时间
+// 返回: SignatureSignOption 签名选项
+func WithSignatureSignTime(t time.Time) SignatureSignOption {
+	return func(o *signatureSignOptions) {
+		o.Time = t
+	}
+}
+
+// WithSignatureProvider 设置签名提供者信息
+// 入参: name 提供者名称, company 公司名称, version 版本号
+// 返回: SignatureSignOption 签名选项
+func WithSignatureProvider(name, company, version string) SignatureSignOption {
+	return func(o *signatureSignOptions) {
+		o.Provider = SignatureProvider{ProviderName: name, Company: company, Version: version}
+	}
+}
+
+// WithSignatureRand 设置签名随机数源
+// 入参: random 随机数源
+// 返回: SignatureSignOption 签名选项
+func WithSignatureRand(random io.Reader) SignatureSignOption {
+	return func(o *signatureSignOptions) {
+		o.Rand = random
+	}
+}
+
+// signatureAlgorithm 签名算法信息
+type signatureAlgorithm struct {
+	Method       string
+	DigestMethod string
+	SignerAlg    string
+	SM2          *sm2PublicKey
+}
+
+// SignBytes 对OFD字节数据添加数字签名
+// 入参: data OFD字节数据, signer 签名密钥提供者, opts 签名选项
+// 返回: []byte 签名后的OFD字节数据, error 错误信息
+func SignBytes(data []byte, signer SignatureSigner, opts ...SignatureSignOption) ([]byte, error) {
+	reader, err := NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := reader.Sign(&buf, signer, opts...); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Sign 对文档添加数字签名并写出新的OFD文件
+// 签名类型为Sign, 保护除签名列表和本签名文件外的全部包内文件, 签名值为GB/T 35275 SignedData
+// 入参: w 输出流, signer 签名密钥提供者, opts 签名选项
+// 返回: error 错误信息
+func (r *Reader) Sign(w io.Writer, signer SignatureSigner, opts ...SignatureSignOption) error {
+	options := signatureSignOptions{
+		Time:     time.Now(),
+		Provider: SignatureProvider{ProviderName: "ofdgo", Company: "xiaoqidun", Version: "1.0"},
+		Rand:     rand.Reader,
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if signer == nil {
+		return fmt.Errorf("signature signer is nil")
+	}
+	certs, err := signer.Certificates()
+	if err != nil {
+		return err
+	}
+	if len(certs) == 0 {
+		return fmt.Errorf("signature certificate not found")
+	}
+	alg, err := signatureSignAlgorithm(certs[0])
+	if err != nil {
+		return err
+	}
+	doc, err := r.Doc()
+	if err != nil {
+		return err
+	}
+	replaced := make(map[string][]byte)
+	var signatures Signatures
+	sigListPath := path.Join(r.RootDir, "Signs", "Signatures.xml")
+	if doc.Signatures != "" {
+		sigListPath = cleanPackagePath(r.ResPath(doc.Signatures))
+		data, err := r.readFileExact(sigListPath)
+		if err != nil {
+			return err
+		}
+		if err := xml.Unmarshal(data, &signatures); err != nil {
+			return err
+		}
+	} else {
+		ofdData, err := r.readFileExact("OFD.xml")
+		if err != nil {
+			return err
+		}
+		loc := signDocBodyEnd.FindSubmatchIndex(ofdData)
+		if loc == nil {
+			return fmt.Errorf("no docbody found")
+		}
+		prefix := ""
+		if loc[2] >= 0 {
+			prefix = string(ofdData[loc[2]:loc[3]])
+		}
+		element := fmt.Sprintf("<%sSignatures>%s</%sSignatures>", prefix, xmlEscapeString(sigListPath), prefix)
+		updated := make([]byte, 0, len(ofdData)+len(element))
+		updated = append(updated, ofdData[:loc[0]]...)
+		updated = append(updated, element...)
+		updated = append(updated, ofdData[loc[0]:]...)
+		replaced["OFD.xml"] = updated
+	}
+	signID := len(signatures.List) + 1
+	for _, sig := range signatures.List {
+		if id, err := strconv.Atoi(sig.ID); err == nil && id >= signID {
+			signID = id + 1
+		}
+	}
+	if id, err := strconv.Atoi(signatures.MaxSignID); err == nil && id >= signID {
+		signID = id + 1
+	}
+	sigDir := path.Join(path.Dir(sigListPath), fmt.Sprintf("Sign_%d", signID-1))
+	for n := signID; r.packageDirExists(sigDir); n++ {
+		sigDir = path.Join(path.Dir(sigListPath), fmt.Sprintf("Sign_%d", n))
+	}
+	sigPath := path.Join(sigDir, "Signature.xml")
+	valuePath := path.Join(sigDir, "SignedValue.dat")
+	var refs strings.Builder
+	for _, name := range r.packageEntries() {
+		if name == sigListPath {
+			continue
+		}
+		data, ok := replaced[name]
+		if !ok {
+			if data, err = r.readFileExact(name); err != nil {
+				return err
+			}
+		}
+		digest, err := alg.digest(signer, data)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&refs, `<ofd:Reference FileRef="/%s"><ofd:CheckValue>%s</ofd:CheckValue></ofd:Reference>`, xmlEscapeString(name), base64.StdEncoding.EncodeToString(digest))
+	}
+	provider := options.Provider
+	sigData := []byte(fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<ofd:Signature xmlns:ofd="%s"><ofd:SignedInfo><ofd:Provider ProviderName="%s" Company="%s" Version="%s"/><ofd:SignatureMethod>%s</ofd:SignatureMethod><ofd:SignatureDateTime>%s</ofd:SignatureDateTime><ofd:References CheckMethod="%s">%s</ofd:References></ofd:SignedInfo><ofd:SignedValue>/%s</ofd:SignedValue></ofd:Signature>`,
+		signOFDNamespace, xmlEscapeString(provider.ProviderName), xmlEscapeString(provider.Company), xmlEscapeString(provider.Version),
+		alg.Method, options.Time.UTC().Format("20060102150405Z07:00"), alg.DigestMethod, refs.String(), xmlEscapeString(valuePath)))
+	signedValue, err := buildGBT35275SignedData(signer, alg, certs, sigData, options.Rand)
+	if err != nil {
+		return err
+	}
+	signatures.List = append(signatures.List, Signature{ID: strconv.Itoa(signID), BaseLoc: "/" + sigPath, Type: SignTypeSign})
+	var list strings.Builder
+	fmt.Fprintf(&list, `<?xml version="1.0" encoding="UTF-8"?>`+"\n"+`<ofd:Signatures xmlns:ofd="%s"><ofd:MaxSignId>%d</ofd:MaxSignId>`, signOFDNamespace, signID)
+	for _, sig := range signatures.List {
+		sigType := sig.Type
+		if sigType == "" {
+			sigType = SignTypeSeal
+		}
+		fmt.Fprintf(&list, `<ofd:Signature ID="%s" Type="%s" BaseLoc="%s"/>`, xmlEscapeString(sig.ID), xmlEscapeString(string(sigType)), xmlEscapeString(sig.BaseLoc))
+	}
+	list.WriteString(`</ofd:Signatures>`)
+	replaced[sigListPath] = []byte(list.String())
+	replaced[sigPath] = sigData
+	replaced[valuePath] = signedValue
+	return r.writePackage(w, replaced)
+}
+
+// packageDirExists 判断包内目录是否存在
+// 入参: dir 目录路径
+// 返回: bool 是否存在
+func (r *Reader) packageDirExists(dir string) bool {
+	prefix := cleanPackagePath(dir) + "/"
+	for name := range r.fileIndex {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// writePackage 写出替换部分文件后的OFD包
+// 未替换的文件按原始压缩数据复制, 新增文件追加到包尾
+// 入参: w 输出流, replaced 替换或新增的文件
+// 返回: error 错误信息
+func (r *Reader) writePackage(w io.Writer, replaced map[string][]byte) error {
+	zw := zip.NewWriter(w)
+	written := make(map[string]bool, len(replaced))
+	for _, f := range r.entries {
+		name := cleanPackagePath(f.name)
+		if data, ok := replaced[name]; ok {
+			if written[name] {
+				continue
+			}
+			written[name] = true
+			if err := writeZipEntry(zw, name, data); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := copyPackageEntry(zw, f); err != nil {
+			return err
+		}
+	}
+	names := make([]string, 0, len(replaced))
+	for name := range replaced {
+		if !written[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := writeZipEntry(zw, name, replaced[name]); err != nil {
+			return err
+		}
+	}
+	return zw.Close()
+}
+
+// signatureSignAlgorithm 根据签名证书确定签名算法
+// 入参: cert DER编码签名证书
+// 返回: signatureAlgorithm 签名算法信息, error 错误信息
+func signatureSignAlgorithm(cert []byte) (signatureAlgorithm, error) {
+	if pub, err := parseSM2PublicKeyFromCert(cert); err == nil {
+		return signatureAlgorithm{Method: signMethodSM2SM3, DigestMethod: signDigestSM3, SignerAlg: signMethodSM2Sign, SM2: &pub}, nil
+	}
+	c, err := parseSignatureCertificate(cert)
+	if err != nil {
+		return signatureAlgorithm{}, err
+	}
+	var spki struct {
+		Algorithm struct {
+			Algorithm  asn1.ObjectIdentifier
+			Parameters asn1.RawValue `asn1:"optional"`
+		}
+		SubjectPublicKey asn1.BitString
+	}
+	if _, err := asn1.Unmarshal(c.PublicKey.FullBytes, &spki); err != nil {
+		return signatureAlgorithm{}, err
+	}
+	switch spki.Algorithm.Algorithm.String() {
+	case "1.2.840.113549.1.1.1":
+		return signatureAlgorithm{Method: signMethodRSASHA256, DigestMethod: signDigestSHA256, SignerAlg: signMethodRSASHA256}, nil
+	case signECPublicKey:
+		return signatureAlgorithm{Method: signMethodECDSASHA256, DigestMethod: signDigestSHA256, SignerAlg: signMethodECDSASHA256}, nil
+	default:
+		return signatureAlgorithm{}, fmt.Errorf("unsupported public key algorithm")
+	}
+}
+
+// digest 计算签名所需摘要
+// 入参: signer 签名密钥提供者, data 原文数据
+// 返回: []byte 摘要值, error 错误信息
+func (alg signatureAlgorithm) digest(signer SignatureSigner, data []byte) ([]byte, error) {
+	if alg.SM2 == nil {
+		sum := sha256.Sum256(data)
+		return sum[:], nil
+	}
+	if hasher, ok := signer.(SignatureHasher); ok {
+		return hasher.SumSM3(data)
+	}
+	return signSM3(data), nil
+}
+
+// buildGBT35275SignedData 生成GB/T 35275 SignedData签名值
+// 原文不内嵌, 认证属性包含内容类型与消息摘要
+// 入参: signer 签名密钥提供者, alg 签名算法信息, certs 签名证书列表, data 被签名原文, random 随机数源
+// 返回: []byte DER编码SignedData, error 错误信息
+func buildGBT35275SignedData(signer SignatureSigner, alg signatureAlgorithm, certs [][]byte, data []byte, random io.Reader) ([]byte, error) {
+	cert, err := parseSignatureCertificate(certs[0])
+	if err != nil {
+		return nil, err
+	}
+	digest, err := alg.digest(signer, data)
+	if err != nil {
+		return nil, err
+	}
+	dataOID := asn1OIDBytes(signContentData)
+	attrs := asn1SequenceBytes(asn1OIDBytes(signAttrContentType), asn1SetBytes(dataOID))
+	attrs = append(attrs, asn1SequenceBytes(asn1OIDBytes(signAttrMessageDigest), asn1SetBytes(asn1Wrap(asn1.TagOctetString, digest)))...)
+	plain := asn1SetBytes(attrs)
+	var signature []byte
+	if alg.SM2 != nil {
+		za := sm2ZA(*alg.SM2, nil)
+		e, err := alg.digest(signer, append(za, plain...))
+		if err != nil {
+			return nil, err
+		}
+		signature, err = signer.Sign(random, e, &SM2SignerOpts{UserID: []byte(sm2DefaultUserID), Message: plain})
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		sum := sha256.Sum256(plain)
+		signature, err = signer.Sign(random, sum[:], crypto.SHA256)
+		if err != nil {
+			return nil, err
+		}
+	}
+	serial, err := asn1.Marshal(cert.Serial)
+	if err != nil {
+		return nil, err
+	}
+	digestAlg := asn1SequenceBytes(asn1OIDBytes(alg.DigestMethod))
+	signerInfo := asn1SequenceBytes(
+		asn1Wrap(asn1.TagInteger, []byte{1}),
+		asn1SequenceBytes(cert.Issuer, serial),
+		digestAlg,
+		asn1Wrap(0xa0, attrs),
+		asn1SequenceBytes(asn1OIDBytes(alg.SignerAlg)),
+		asn1Wrap(asn1.TagOctetString, signature),
+	)
+	var certSet []byte
+	for _, c := range certs {
+		certSet = append(certSet, c...)
+	}
+	signedData := asn1SequenceBytes(
+		asn1Wrap(asn1.TagInteger, []byte{1}),
+		asn1SetBytes(digestAlg),
+		asn1SequenceBytes(dataOID),
+		asn1Wrap(0xa0, certSet),
+		asn1SetBytes(signerInfo),
+	)
+	return asn1SequenceBytes(asn1OIDBytes(signContentSignedData), asn1Wrap(0xa0, signedData)), nil
+}
+
+// asn1OIDBytes 编码ASN.1对象标识符
+// 入参: oid 点分格式OID
+// 返回: []byte ASN.1 DER数据
+func asn1OIDBytes(oid string) []byte {
+	var id asn1.ObjectIdentifier
+	for _, part := range strings.Split(oid, ".") {
+		n, _ := strconv.Atoi(part)
+		id = append(id, n)
+	}
+	data, _ := asn1.Marshal(id)
+	return data
+}
@@ -0,0 +1,155 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Unpack 将OFD包解压到目录
+// 加密文件按阅读器凭据解密后写出, 加密信息文件不写出, 结果可由Open按目录重新打开
+// 格式化后的XML与原文字节不同, 解压目录中的签名将无法通过验证
+// 入参: dir 目标目录, pretty 是否格式化XML文件
+// 返回: error 错误信息
+func (r *Reader) Unpack(dir string, pretty bool) error {
+	for _, name := range r.packageEntries() {
+		local := filepath.FromSlash(name)
+		if !filepath.IsLocal(local) {
+			return fmt.Errorf("invalid entry name: %s", name)
+		}
+		data, err := r.readFileExact(name)
+		if err != nil {
+			return err
+		}
+		if pretty && strings.EqualFold(path.Ext(name), ".xml") {
+			if indented, err := indentXML(data); err == nil {
+				data = indented
+			}
+		}
+		target := filepath.Join(dir, local)
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(target, data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// indentXML 格式化XML文本
+// 保留命名空间前缀和仅含文本的元素内容, 删除元素之间的空白
+// 入参: data XML数据
+// 返回: []byte 格式化后的XML数据, error 错误信息
+func indentXML(data []byte) ([]byte, error) {
+	d := xml.NewDecoder(bytes.NewReader(data))
+	var buf bytes.Buffer
+	depth := 0
+	opened, text := false, false
+	newline := func() {
+		if buf.Len() > 0 {
+			buf.WriteByte('\n')
+		}
+		buf.WriteString(strings.Repeat("  ", depth))
+	}
+	for {
+		tok, err := d.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			newline()
+			buf.WriteString("<" + xmlQualifiedName(t.Name))
+			for _, attr := range t.Attr {
+				buf.WriteString(" " + xmlQualifiedName(attr.Name) + `="` + xmlEscapeString(attr.Value) + `"`)
+			}
+			buf.WriteByte('>')
+			depth++
+			opened, text = true, false
+		case xml.EndElement:
+			depth--
+			if opened && !text {
+				buf.Truncate(buf.Len() - 1)
+				buf.WriteString("/>")
+				opened = false
+				continue
+			}
+			if !text {
+				newline()
+			}
+			buf.WriteString("</" + xmlQualifiedName(t.Name) + ">")
+			opened, text = false, false
+		case xml.CharData:
+			if opened || len(bytes.TrimSpace(t)) > 0 {
+				xmlEscapeCharData(&buf, t)
+				text = true
+			}
+		case xml.Comment:
+			newline()
+			buf.WriteString("<!--" + string(t) + "-->")
+			opened = false
+		case xml.ProcInst:
+			newline()
+			buf.WriteString("<?" + t.Target)
+			if len(t.Inst) > 0 {
+				buf.WriteString(" " + string(t.Inst))
+			}
+			buf.WriteString("?>")
+		case xml.Directive:
+			newline()
+			buf.WriteString("<!" + string(t) + ">")
+		}
+	}
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
+
+// xmlQualifiedName 获取带前缀的XML名称
+// 入参: name XML名称
+// 返回: string 带前缀的名称
+func xmlQualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// xmlEscapeCharData 转义XML文本内容, 保留换行和制表符
+// 入参: buf 输出缓冲区, data 文本内容
+func xmlEscapeCharData(buf *bytes.Buffer, data []byte) {
+	for _, c := range data {
+		switch c {
+		case '&':
+			buf.WriteString("&amp;")
+		case '<':
+			buf.WriteString("&lt;")
+		case '>':
+			buf.WriteString("&gt;")
+		default:
+			buf.WriteByte(c)
+		}
+	}
+}
@@ -0,0 +1,689 @@
+// Copyright 2025-2026 肖其顿 (XIAO QI DUN)
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ofdgo
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ValidationProfile 合规校验规范
+type ValidationProfile string
+
+const (
+	ValidationProfileGBT33190 ValidationProfile = "gbt33190" // GB/T 33190 文档结构校验
+	ValidationProfileDAT47    ValidationProfile = "dat47"    // DA/T 47 长期保存, 另要求嵌入字体、不加密且无外部链接
+)
+
+// ValidationCode 校验规则编码
+type ValidationCode string
+
+const (
+	ValidationMissingPart       ValidationCode = "missing-part"           // 引用的包内文件不存在
+	ValidationUnreadablePart    ValidationCode = "unreadable-part"        // 包内文件无法读取
+	ValidationInvalidXML        ValidationCode = "invalid-xml"            // XML结构无法解析
+	ValidationMissingElement    ValidationCode = "missing-element"        // 缺少必需的元素或属性
+	ValidationInvalidID         ValidationCode = "invalid-id"             // 标识不是正整数
+	ValidationDuplicateID       ValidationCode = "duplicate-id"           // 标识在文档内重复
+	ValidationIDExceedsMax      ValidationCode = "id-exceeds-max-unit-id" // 标识大于MaxUnitID
+	ValidationDanglingResource  ValidationCode = "dangling-resource"      // 引用的多媒体或复合图元资源不存在
+	ValidationDanglingTemplate  ValidationCode = "dangling-template"      // 引用的模板页不存在
+	ValidationDanglingFont      ValidationCode = "dangling-font"          // 引用的字体不存在
+	ValidationDanglingDrawParam ValidationCode = "dangling-draw-param"    // 引用的绘制参数不存在
+	ValidationDanglingPage      ValidationCode = "dangling-page"          // 引用的页面不存在
+	ValidationInvalidBoundary   ValidationCode = "invalid-boundary"       // 边界或页面区域格式错误
+	ValidationInvalidCTM        ValidationCode = "invalid-ctm"            // 变换矩阵格式错误
+	ValidationUnsupportedImage  ValidationCode = "unsupported-image"      // 图片格式不受支持
+	ValidationFontNotEmbedded   ValidationCode = "font-not-embedded"      // 字体未嵌入
+	ValidationEncrypted         ValidationCode = "encrypted"              // 文档已加密
+	ValidationExternalLink      ValidationCode = "external-link"          // 引用了包外资源或链接
+)
+
+// ValidationIssue 校验问题
+type ValidationIssue struct {
+	Code     ValidationCode     `json:"code"`
+	Severity DiagnosticSeverity `json:"severity"`
+	Part     string             `json:"part,omitempty"`
+	ObjectID string             `json:"objectId,omitempty"`
+	Message  string             `json:"message"`
+}
+
+// ValidationReport 校验报告
+type ValidationReport struct {
+	Profile ValidationProfile `json:"profile"`
+	Issues  []ValidationIssue `json:"issues"`
+}
+
+// Valid 判断是否通过校验
+// 返回: bool 不存在错误级别的问题时为true
+func (report *ValidationReport) Valid() bool {
+	for _, issue := range report.Issues {
+		if issue.Severity == DiagnosticError {
+			return false
+		}
+	}
+	return true
+}
+
+// validator 合规校验状态
+type validator struct {
+	reader     *Reader
+	ctx        context.Context
+	report     *ValidationReport
+	archival   bool
+	maxID      uint64
+	ids        map[string]string
+	fonts      map[string]bool
+	media      map[string]bool
+	drawParams map[string]bool
+	units      map[string]bool
+	templates  map[string]bool
+	pages      map[string]bool
+	res        map[string]bool
+}
+
+// validatorRes 已登记待检查引用的资源文件
+type validatorRes struct {
+	path string
+	res  Res
+}
+
+// Validate 按指定规范校验文档结构
+// 发现的问题记录在报告中, 仅在规范未知或上下文取消时返回错误
+// 入参: profile 校验规范, 为空时使用GB/T 33190
+// 返回: *ValidationReport 校验报告, error 错误信息
+func (r *Reader) Validate(profile ValidationProfile) (*ValidationReport, error) {
+	return r.validate(context.Background(), profile)
+}
+
+// validate 按指定规范校验文档结构
+// 入参: ctx 上下文, profile 校验规范
+// 返回: *ValidationReport 校验报告, error 错误信息
+func (r *Reader) validate(ctx context.Context, profile ValidationProfile) (*ValidationReport, error) {
+	if profile == "" {
+		profile = ValidationProfileGBT33190
+	}
+	if profile != ValidationProfileGBT33190 && profile != ValidationProfileDAT47 {
+		return nil, fmt.Errorf("unknown validation profile: %s", profile)
+	}
+	v := &validator{
+		reader:     r,
+		ctx:        ctx,
+		report:     &ValidationReport{Profile: profile, Issues: []ValidationIssue{}},
+		archival:   profile == ValidationProfileDAT47,
+		ids:        make(map[string]string),
+		fonts:      make(map[string]bool),
+		media:      make(map[string]bool),
+		drawParams: make(map[string]bool),
+		units:      make(map[string]bool),
+		templates:  make(map[string]bool),
+		pages:      make(map[string]bool),
+		res:        make(map[string]bool),
+	}
+	if err := v.run(); err != nil {
+		return nil, err
+	}
+	return v.report, nil
+}
+
+// run 执行校验
+// 返回: error 上下文取消错误
+func (v *validator) run() error {
+	r := v.reader
+	if r.OFD.Version == "" {
+		v.add(ValidationMissingElement, DiagnosticError, "OFD.xml", "", "missing Version attribute")
+	}
+	if r.OFD.DocType == "" {
+		v.add(ValidationMissingElement, DiagnosticError, "OFD.xml", "", "missing DocType attribute")
+	}
+	if r.Encrypted() {
+		if v.archival {
+			v.add(ValidationEncrypted, DiagnosticError, "", "", "package is encrypted")
+		} else {
+			v.add(ValidationEncrypted, DiagnosticWarning, "", "", "package is encrypted, content not checked")
+		}
+		return nil
+	}
+	if len(r.OFD.DocBody) == 0 || strings.TrimSpace(r.OFD.DocBody[0].DocRoot) == "" {
+		v.add(ValidationMissingElement, DiagnosticError, "OFD.xml", "", "missing DocBody or DocRoot")
+		return nil
+	}
+	docRoot := cleanPackagePath(strings.TrimSpace(r.OFD.DocBody[0].DocRoot))
+	if !v.exists(docRoot) {
+		v.add(ValidationMissingPart, DiagnosticError, docRoot, "", "document root not found")
+		return nil
+	}
+	doc, err := r.Doc()
+	if err != nil {
+		v.add(ValidationInvalidXML, DiagnosticError, docRoot, "", "%v", err)
+		return nil
+	}
+	v.checkDocument(docRoot, doc)
+	var docRes []*validatorRes
+	for _, res := range []string{doc.CommonData.DocumentRes, doc.CommonData.PublicRes} {
+		if res != "" {
+			docRes = v.registerRes(docRes, docRoot, r.ResPath(res))
+		}
+	}
+	for _, res := range docRes {
+		v.checkResRefs(res)
+	}
+	for _, tpl := range doc.CommonData.TemplatePage {
+		if err := contextErr(v.ctx); err != nil {
+			return err
+		}
+		v.checkPage(docRoot, tpl.ID, tpl.BaseLoc)
+	}
+	for _, page := range doc.Pages.Page {
+		if err := contextErr(v.ctx); err != nil {
+			return err
+		}
+		v.checkPage(docRoot, page.ID, page.BaseLoc)
+	}
+	v.checkNavigation(docRoot, doc)
+	v.checkAnnotations(docRoot, doc.Annotations)
+	v.checkSignatures(docRoot, doc.Signatures)
+	return contextErr(v.ctx)
+}
+
+// add 记录校验问题
+// 入参: code 规则编码, severity 问题级别, part 包内文件路径, objectID 对象ID, format 消息格式, args 消息参数
+func (v *validator) add(code ValidationCode, severity DiagnosticSeverity, part, objectID, format string, args ...any) {
+	v.report.Issues = append(v.report.Issues, ValidationIssue{
+		Code:     code,
+		Severity: severity,
+		Part:     part,
+		ObjectID: objectID,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// archivalSeverity 获取长期保存规范要求项的问题级别
+// 返回: DiagnosticSeverity 长期保存规范下为错误, 否则为警告
+func (v *validator) archivalSeverity() DiagnosticSeverity {
+	if v.archival {
+		return DiagnosticError
+	}
+	return DiagnosticWarning
+}
+
+// exists 判断包内文件是否存在
+// 入参: fullPath 包内文件路径
+// 返回: bool 是否存在
+func (v *validator) exists(fullPath string) bool {
+	_, ok := v.reader.packageFile(cleanPackagePath(fullPath))
+	return ok
+}
+
+// readPart 读取并解析包内XML文件
+// 入参: from 引用方文件路径, fullPath 包内文件路径, value 解析目标
+// 返回: bool 是否解析成功
+func (v *validator) readPart(from, fullPath string, value any) bool {
+	if !v.exists(fullPath) {
+		v.add(ValidationMissingPart, DiagnosticError, fullPath, "", "part not found, referenced from %s", from)
+		return false
+	}
+	data, err := v.reader.readFile(fullPath)
+	if err != nil {
+		v.add(ValidationUnreadablePart, DiagnosticError, fullPath, "", "%v", err)
+		return false
+	}
+	if err := xml.Unmarshal(data, value); err != nil {
+		v.add(ValidationInvalidXML, DiagnosticError, fullPath, "", "%v", err)
+		return false
+	}
+	return true
+}
+
+// checkLink 检查资源文件引用
+// 入参: part 引用方文件路径, objectID 对象ID, fullPath 资源文件路径, loc 原始引用
+// 返回: bool 资源文件是否存在于包内
+func (v *validator) checkLink(part, objectID, fullPath, loc string) bool {
+	if isExternalLink(loc) {
+		v.add(ValidationExternalLink, v.archivalSeverity(), part, objectID, "resource refers to external location %s", loc)
+		return false
+	}
+	if !v.exists(fullPath) {
+		v.add(ValidationMissingPart, DiagnosticError, part, objectID, "resource file %s not found", fullPath)
+		return false
+	}
+	return true
+}
+
+// isExternalLink 判断引用是否指向包外位置
+// 入参: loc 引用
+// 返回: bool 是否为包外位置
+func isExternalLink(loc string) bool {
+	loc = strings.ToLower(strings.TrimSpace(loc))
+	return strings.Contains(loc, "://") || strings.HasPrefix(loc, "file:") || strings.HasPrefix(loc, "mailto:")
+}
+
+// addID 登记对象标识
+// 入参: part 文件路径, id 对象标识
+func (v *validator) addID(part, id string) {
+	if id == "" {
+		return
+	}
+	n, err := strconv.ParseUint(id, 10, 32)
+	if err != nil || n == 0 {
+		v.add(ValidationInvalidID, DiagnosticError, part, id, "id is not a positive integer")
+		return
+	}
+	if first, ok := v.ids[id]; ok {
+		v.add(ValidationDuplicateID, DiagnosticError, part, id, "id already used in %s", first)
+		return
+	}
+	v.ids[id] = part
+	if v.maxID > 0 && n > v.maxID {
+		v.add(ValidationIDExceedsMax, DiagnosticError, part, id, "id exceeds MaxUnitID %d", v.maxID)
+	}
+}
+
+// checkBox 检查区域格式
+// 入参: part 文件路径, objectID 对象ID, name 区域名称, value 区域值
+func (v *validator) checkBox(part, objectID, name, value string) {
+	box, err := ParseBox(value)
+	if err != nil || box.W < 0 || box.H < 0 {
+		v.add(ValidationInvalidBoundary, DiagnosticError, part, objectID, "invalid %s %q", name, value)
+	}
+}
+
+// checkCTM 检查变换矩阵格式
+// 入参: part 文件路径, objectID 对象ID, ctm 变换矩阵
+func (v *validator) checkCTM(part, objectID, ctm string) {
+	if ctm == "" {
+		return
+	}
+	fields := strings.Fields(ctm)
+	valid := len(fields) == 6
+	for _, field := range fields {
+		if _, err := strconv.ParseFloat(field, 64); err != nil {
+			valid = false
+		}
+	}
+	if !valid {
+		v.add(ValidationInvalidCTM, DiagnosticError, part, objectID, "invalid CTM %q", ctm)
+	}
+}
+
+// checkDocument 检查主文档结构
+// 入参: part 文档路径, doc 文档结构
+func (v *validator) checkDocument(part string, doc *Document) {
+	if doc.CommonData.MaxUnitID <= 0 {
+		v.add(ValidationMissingElement, DiagnosticError, part, "", "missing or invalid MaxUnitID")
+	} else {
+		v.maxID = uint64(doc.CommonData.MaxUnitID)
+	}
+	if doc.CommonData.PageArea.PhysicalBox == "" {
+		v.add(ValidationMissingElement, DiagnosticError, part, "", "missing PageArea PhysicalBox")
+	}
+	v.checkArea(part, "", doc.CommonData.PageArea)
+	if len(doc.Pages.Page) == 0 {
+		v.add(ValidationMissingElement, DiagnosticError, part, "", "document has no pages")
+	}
+	for _, tpl := range doc.CommonData.TemplatePage {
+		v.addID(part, tpl.ID)
+		v.templates[tpl.ID] = true
+		if tpl.BaseLoc == "" {
+			v.add(ValidationMissingElement, DiagnosticError, part, tpl.ID, "template page has no BaseLoc")
+		}
+	}
+	for _, page := range doc.Pages.Page {
+		v.addID(part, page.ID)
+		v.pages[page.ID] = true
+		if page.BaseLoc == "" {
+			v.add(ValidationMissingElement, DiagnosticError, part, page.ID, "page has no BaseLoc")
+		}
+	}
+	for _, loc := range []string{doc.Attachments.Path, doc.CustomTags.Path, doc.Extensions.Path} {
+		if loc = strings.TrimSpace(loc); loc != "" && !v.exists(v.reader.ResPath(loc)) {
+			v.add(ValidationMissingPart, DiagnosticError, v.reader.ResPath(loc), "", "part not found, referenced from %s", part)
+		}
+	}
+}
+
+// checkNavigation 检查文档动作、大纲和书签
+// 在公共资源、文档资源和全部页面资源登记后执行, 使多媒体动作可引用任一资源文件
+// 入参: part 文档路径, doc 文档结构
+func (v *validator) checkNavigation(part string, doc *Document) {
+	v.checkActions(part, "", doc.Actions)
+	v.checkOutlines(part, doc.Outlines.OutlineElem)
+	for _, bookmark := range doc.Bookmarks.Bookmark {
+		v.checkDest(part, "", &bookmark.Dest)
+	}
+}
+
+// checkArea 检查页面区域
+// 入参: part 文件路径, objectID 对象ID, area 页面区域
+func (v *validator) checkArea(part, objectID string, area PageArea) {
+	boxes := []struct{ name, value string }{
+		{"PhysicalBox", area.PhysicalBox},
+		{"ApplicationBox", area.ApplicationBox},
+		{"ContentBox", area.ContentBox},
+		{"BleedBox", area.BleedBox},
+	}
+	for _, box := range boxes {
+		if box.value != "" {
+			v.checkBox(part, objectID, box.name, box.value)
+		}
+	}
+}
+
+// checkOutlines 检查大纲动作
+// 入参: part 文件路径, outlines 大纲节点
+func (v *validator) checkOutlines(part string, outlines []OutlineElem) {
+	for _, outline := range outlines {
+		v.checkActions(part, "", outline.Actions)
+		v.checkOutlines(part, outline.OutlineElem)
+	}
+}
+
+// checkActions 检查动作引用
+// 入参: part 文件路径, objectID 对象ID, actions 动作列表
+func (v *validator) checkActions(part, objectID string, actions []Action) {
+	for _, action := range actions {
+		if action.Goto != nil {
+			v.checkDest(part, objectID, action.Goto.Dest)
+		}
+		if action.URI != nil && v.archival {
+			v.add(ValidationExternalLink, DiagnosticError, part, objectID, "uri action links to %s", action.URI.URI)
+		}
+		if action.Sound != nil && !v.media[action.Sound.ResourceID] {
+			v.add(ValidationDanglingResource, DiagnosticError, part, objectID, "sound resource %s not found", action.Sound.ResourceID)
+		}
+		if action.Movie != nil && !v.media[action.Movie.ResourceID] {
+			v.add(ValidationDanglingResource, DiagnosticError, part, objectID, "movie resource %s not found", action.Movie.ResourceID)
+		}
+	}
+}
+
+// checkDest 检查跳转目标页面
+// 入参: part 文件路径, objectID 对象ID, dest 跳转目标
+func (v *validator) checkDest(part, objectID string, dest *Dest) {
+	if dest != nil && dest.PageID != "" && !v.pages[dest.PageID] {
+		v.add(ValidationDanglingPage, DiagnosticError, part, objectID, "destination page %s not found", dest.PageID)
+	}
+}
+
+// registerRes 登记资源文件中的资源并检查资源定义
+// 多个页面引用的同一资源文件只登记一次, 资源间引用由checkResRefs检查
+// 入参: list 已登记的资源文件, from 引用方文件路径, resPath 资源文件路径
+// 返回: []*validatorRes 追加本资源文件后的列表
+func (v *validator) registerRes(list []*validatorRes, from, resPath string) []*validatorRes {
+	key := strings.ToLower(cleanPackagePath(resPath))
+	if v.res[key] {
+		return list
+	}
+	v.res[key] = true
+	var res Res
+	if !v.readPart(from, resPath, &res) {
+		return list
+	}
+	for _, font := range res.Fonts.Font {
+		v.addID(resPath, font.ID)
+		v.fonts[font.ID] = true
+		if font.FontName == "" {
+			v.add(ValidationMissingElement, DiagnosticError, resPath, font.ID, "font has no FontName")
+		}
+		if font.FontFile == "" {
+			v.add(ValidationFontNotEmbedded, v.archivalSeverity(), resPath, font.ID, "font %s is not embedded", font.FontName)
+			continue
+		}
+		v.checkLink(resPath, font.ID, resolveResourcePath(resPath, res.BaseLoc, font.FontFile), font.FontFile)
+	}
+	for _, mm := range res.MultiMedias.MultiMedia {
+		v.addID(resPath, mm.ID)
+		v.media[mm.ID] = true
+		if mm.MediaFile == "" {
+			v.add(ValidationMissingElement, DiagnosticError, resPath, mm.ID, "multimedia has no MediaFile")
+			continue
+		}
+		mediaPath := resolveResourcePath(resPath, res.BaseLoc, mm.MediaFile)
+		if v.checkLink(resPath, mm.ID, mediaPath, mm.MediaFile) && strings.EqualFold(mm.Type, "Image") {
+			v.checkImage(resPath, mm.ID, mediaPath)
+		}
+	}
+	for _, dp := range res.DrawParams.DrawParam {
+		v.addID(resPath, dp.ID)
+		v.drawParams[dp.ID] = true
+	}
+	for _, cgu := range res.CompositeGraphicUnits.CompositeGraphicUnit {
+		v.addID(resPath, cgu.ID)
+		v.units[cgu.ID] = true
+	}
+	return append(list, &validatorRes{path: resPath, res: res})
+}
+
+// checkResRefs 检查资源文件中的引用
+// 须在同一层级全部资源文件登记完成后调用, 以便引用其他资源文件中的资源
+// 入参: r 已登记的资源文件
+func (v *validator) checkResRefs(r *validatorRes) {
+	resPath, res := r.path, r.res
+	for _, dp := range res.DrawParams.DrawParam {
+		if dp.Relative != "" && !v.drawParams[dp.Relative] {
+			v.add(ValidationDanglingDrawParam, DiagnosticError, resPath, dp.ID, "relative draw parameter %s not found", dp.Relative)
+		}
+		if dp.Font != "" && !v.fonts[dp.Font] {
+			v.add(ValidationDanglingFont, DiagnosticError, resPath, dp.ID, "font %s not found", dp.Font)
+		}
+	}
+	for _, cgu := range res.CompositeGraphicUnits.CompositeGraphicUnit {
+		v.checkObjects(resPath, cgu.Objects)
+	}
+}
+
+// checkImage 检查图片格式
+// 入参: part 资源文件路径, objectID 对象ID, imagePath 图片路径
+func (v *validator) checkImage(part, objectID, imagePath string) {
+	data, err := v.reader.readFile(imagePath)
+	if err != nil {
+		v.add(ValidationUnreadablePart, DiagnosticError, imagePath, objectID, "%v", err)
+		return
+	}
+	if _, _, err := decodeImageConfigData(data); err != nil {
+		v.add(ValidationUnsupportedImage, DiagnosticError, part, objectID, "unsupported image format in %s: %v", imagePath, err)
+	}
+}
+
+// checkPage 检查页面或模板页
+// 入参: from 引用方文件路径, id 页面ID, baseLoc 页面路径
+func (v *validator) checkPage(from, id, baseLoc string) {
+	if baseLoc == "" {
+		return
+	}
+	pagePath := v.reader.ResPath(baseLoc)
+	var page PageContent
+	if !v.readPart(from, pagePath, &page) {
+		return
+	}
+	var pageRes []*validatorRes
+	for _, res := range page.PageRes {
+		if res = strings.TrimSpace(res); res != "" {
+			pageRes = v.registerRes(pageRes, pagePath, resolveResourcePath(pagePath, "", res))
+		}
+	}
+	for _, res := range pageRes {
+		v.checkResRefs(res)
+	}
+	v.checkArea(pagePath, id, page.Area)
+	for _, tpl := range page.Template {
+		if !v.templates[tpl.TemplateID] {
+			v.add(ValidationDanglingTemplate, DiagnosticError, pagePath, id, "template %s not found", tpl.TemplateID)
+		}
+	}
+	for _, layer := range page.Content.Layer {
+		v.addID(pagePath, layer.ID)
+		v.checkDrawParam(pagePath, layer.ID, layer.DrawParam)
+		v.checkObjects(pagePath, layer.Objects)
+	}
+	v.checkActions(pagePath, id, page.Actions)
+}
+
+// checkAnnotations 检查注释文件
+// 入参: from 引用方文件路径, loc 注释列表路径
+func (v *validator) checkAnnotations(from, loc string) {
+	if loc == "" {
+		return
+	}
+	listPath := v.reader.ResPath(loc)
+	var annotations Annotations
+	if !v.readPart(from, listPath, &annotations) {
+		return
+	}
+	for _, page := range annotations.Page {
+		if !v.pages[page.PageID] {
+			v.add(ValidationDanglingPage, DiagnosticError, listPath, "", "annotation page %s not found", page.PageID)
+		}
+		annotPath := resolveResourcePath(listPath, "", page.FileLoc)
+		var pageAnnot PageAnnot
+		if !v.readPart(listPath, annotPath, &pageAnnot) {
+			continue
+		}
+		for _, annot := range pageAnnot.Annot {
+			v.addID(annotPath, annot.ID)
+			if annot.Appearance.Boundary != "" {
+				v.checkBox(annotPath, annot.ID, "Boundary", annot.Appearance.Boundary)
+			}
+			v.checkObjects(annotPath, annot.Appearance.Objects)
+		}
+	}
+}
+
+// checkSignatures 检查签名文件
+// 入参: from 引用方文件路径, loc 签名列表路径
+func (v *validator) checkSignatures(from, loc string) {
+	if loc == "" {
+		return
+	}
+	listPath := v.reader.ResPath(loc)
+	var signatures Signatures
+	if !v.readPart(from, listPath, &signatures) {
+		return
+	}
+	for _, sig := range signatures.List {
+		sigPath := resolveResourcePath(listPath, "", sig.BaseLoc)
+		var sigFile SignatureFile
+		if !v.readPart(listPath, sigPath, &sigFile) {
+			continue
+		}
+		for _, stamp := range sigFile.SignedInfo.StampAnnot {
+			if !v.pages[stamp.PageRef] {
+				v.add(ValidationDanglingPage, DiagnosticError, sigPath, stamp.ID, "stamp page %s not found", stamp.PageRef)
+			}
+			v.checkBox(sigPath, stamp.ID, "Boundary", stamp.Boundary)
+		}
+	}
+}
+
+// checkObjects 检查图元列表
+// 入参: part 文件路径, objects 图元列表
+func (v *validator) checkObjects(part string, objects []GraphicObject) {
+	for _, obj := range objects {
+		switch obj.Type {
+		case "TextObject":
+			v.checkText(part, obj.TextObject)
+		case "PathObject":
+			v.checkPath(part, obj.PathObject)
+		case "ImageObject":
+			v.checkImageObject(part, obj.ImageObject)
+		default:
+			v.checkCompositeGraphicUnit(part, obj.CompositeGraphicUnit)
+		}
+	}
+}
+
+// checkGraphic 检查图元公共属性
+// 入参: part 文件路径, id 对象ID, boundary 边界, ctm 变换矩阵, drawParam 绘制参数ID, clips 裁剪区域, actions 动作列表
+func (v *validator) checkGraphic(part, id, boundary, ctm, drawParam string, clips *Clips, actions []Action) {
+	v.addID(part, id)
+	v.checkBox(part, id, "Boundary", boundary)
+	v.checkCTM(part, id, ctm)
+	v.checkDrawParam(part, id, drawParam)
+	v.checkActions(part, id, actions)
+	if clips == nil {
+		return
+	}
+	for _, clip := range clips.Clip {
+		for _, area := range clip.Area {
+			v.checkCTM(part, id, area.CTM)
+			for _, path := range area.Path {
+				v.checkPath(part, path)
+			}
+			for _, text := range area.Text {
+				v.checkText(part, text)
+			}
+		}
+	}
+}
+
+// checkDrawParam 检查绘制参数引用
+// 入参: part 文件路径, objectID 对象ID, id 绘制参数ID
+func (v *validator) checkDrawParam(part, objectID, id string) {
+	if id != "" && !v.drawParams[id] {
+		v.add(ValidationDanglingDrawParam, DiagnosticError, part, objectID, "draw parameter %s not found", id)
+	}
+}
+
+// checkColor 检查图案填充内容
+// 入参: part 文件路径, fill 填充颜色
+func (v *validator) checkColor(part string, fill *FillColor) {
+	if fill != nil && fill.Pattern != nil {
+		v.checkObjects(part, fill.Pattern.CellContent.Objects)
+	}
+}
+
+// checkText 检查文本对象
+// 入参: part 文件路径, obj 文本对象
+func (v *validator) checkText(part string, obj TextObject) {
+	v.checkGraphic(part, obj.ID, obj.Boundary, obj.CTM, obj.DrawParam, obj.Clips, obj.Actions)
+	if obj.Font == "" {
+		v.add(ValidationMissingElement, DiagnosticError, part, obj.ID, "text object has no Font")
+	} else if !v.fonts[obj.Font] {
+		v.add(ValidationDanglingFont, DiagnosticError, part, obj.ID, "font %s not found", obj.Font)
+	}
+	v.checkColor(part, obj.FillColor)
+}
+
+// checkPath 检查路径对象
+// 入参: part 文件路径, obj 路径对象
+func (v *validator) checkPath(part string, obj PathObject) {
+	v.checkGraphic(part, obj.ID, obj.Boundary, obj.CTM, obj.DrawParam, obj.Clips, obj.Actions)
+	v.checkColor(part, obj.FillColor)
+}
+
+// checkImageObject 检查图像对象
+// 入参: part 文件路径, obj 图像对象
+func (v *validator) checkImageObject(part string, obj ImageObject) {
+	v.checkGraphic(part, obj.ID, obj.Boundary, obj.CTM, "", obj.Clips, obj.Actions)
+	if !v.media[obj.ResourceID] {
+		v.add(ValidationDanglingResource, DiagnosticError, part, obj.ID, "image resource %s not found", obj.ResourceID)
+	}
+	if obj.ImageMask != "" && !v.media[obj.ImageMask] {
+		v.add(ValidationDanglingResource, DiagnosticError, part, obj.ID, "image mask resource %s not found", obj.ImageMask)
+	}
+}
+
+// checkCompositeGraphicUnit 检查复合图元
+// 入参: part 文件路径, cgu 复合图元
+func (v *validator) checkCompositeGraphicUnit(part string, cgu CompositeGraphicUnit) {
+	v.checkGraphic(part, cgu.ID, cgu.Boundary, cgu.CTM, cgu.DrawParam, cgu.Clips, cgu.Actions)
+	if cgu.ResourceID != "" && !v.units[cgu.ResourceID] {
+		v.add(ValidationDanglingResource, DiagnosticError, part, cgu.ID, "composite graphic unit resource %s not found", cgu.ResourceID)
+	}
+	v.checkObjects(part, cgu.Objects)
+}